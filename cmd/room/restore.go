@@ -0,0 +1,58 @@
+package room
+
+import (
+	"errors"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/PeterChen1997/synctv/internal/archive"
+	"github.com/PeterChen1997/synctv/internal/bootstrap"
+	"github.com/PeterChen1997/synctv/internal/op"
+)
+
+var (
+	restoreName    string
+	restoreCreator string
+)
+
+var RestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "restore",
+	Long:  `restore room from a json or zip archive`,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return bootstrap.New().Add(
+			bootstrap.InitStdLog,
+			bootstrap.InitConfig,
+			bootstrap.InitDatabase,
+		).Run(cmd.Context())
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("missing archive file")
+		}
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Errorf("read archive failed: %s\n", err)
+			return nil
+		}
+		a, err := archive.ReadRoom(data)
+		if err != nil {
+			log.Errorf("read room archive failed: %s\n", err)
+			return nil
+		}
+		r, err := op.RestoreRoom(a, restoreName, restoreCreator)
+		if err != nil {
+			log.Errorf("restore room failed: %s\n", err)
+			return nil
+		}
+		log.Infof("restore room success: %s (%s), %d movies\n", r.Name, r.ID, len(r.Movies))
+		return nil
+	},
+}
+
+func init() {
+	RestoreCmd.Flags().StringVar(&restoreName, "name", "", "room name, the archived name if empty")
+	RestoreCmd.Flags().StringVar(&restoreCreator, "creator", "", "creator user id, the archived creator if empty")
+	RoomCmd.AddCommand(RestoreCmd)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/PeterChen1997/synctv/cmd/admin"
 	"github.com/PeterChen1997/synctv/cmd/flags"
+	"github.com/PeterChen1997/synctv/cmd/room"
 	"github.com/PeterChen1997/synctv/cmd/root"
 	"github.com/PeterChen1997/synctv/cmd/setting"
 	"github.com/PeterChen1997/synctv/cmd/user"
@@ -117,4 +118,5 @@ func init() {
 	RootCmd.AddCommand(user.UserCmd)
 	RootCmd.AddCommand(setting.SettingCmd)
 	RootCmd.AddCommand(root.RootCmd)
+	RootCmd.AddCommand(room.RoomCmd)
 }
@@ -33,7 +33,13 @@ var ServerCmd = &cobra.Command{
 			bootstrap.InitOp,
 			bootstrap.InitRtmp,
 			bootstrap.InitVendorBackend,
+			bootstrap.InitVendorPlugins,
 			bootstrap.InitSetting,
+			bootstrap.InitLocalLibrary,
+			bootstrap.InitChatHistory,
+			bootstrap.InitEPG,
+			bootstrap.InitVendorBindingValidator,
+			bootstrap.InitWatchParties,
 		)
 		if !flags.Server.DisableUpdateCheck {
 			boot.Add(bootstrap.InitCheckUpdate)
@@ -0,0 +1,189 @@
+// Package archive reads and writes the portable archives of rooms, so a playlist or a
+// whole room can be moved between rooms and instances.
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+const (
+	// PlaylistVersion is bumped when a change of the format can not be read by the
+	// older instances
+	PlaylistVersion = 1
+
+	FormatJSON = "json"
+	FormatZip  = "zip"
+
+	playlistFile = "playlist.json"
+	// MaxSize is the largest archive read, a playlist of a hundred thousand movies fits
+	MaxSize = 64 << 20
+)
+
+var ErrUnsupportedVersion = errors.New("unsupported archive version")
+
+type Playlist struct {
+	ExportedAt time.Time        `json:"exportedAt"`
+	RoomID     string           `json:"roomId"`
+	Movies     []*PlaylistMovie `json:"movies"`
+	Version    int              `json:"version"`
+}
+
+// PlaylistMovie is a movie of the tree, the parent id of its base is the id of its
+// folder in the archive.
+type PlaylistMovie struct {
+	ID        string `json:"id"`
+	CreatorID string `json:"creatorId,omitempty"`
+	model.MovieBase
+	Position uint `json:"position"`
+}
+
+// NewPlaylist archives the movies of a room, in their position order.
+func NewPlaylist(roomID string, movies []*model.Movie) *Playlist {
+	p := &Playlist{
+		Version:    PlaylistVersion,
+		ExportedAt: time.Now(),
+		RoomID:     roomID,
+		Movies:     make([]*PlaylistMovie, len(movies)),
+	}
+	for i, m := range movies {
+		p.Movies[i] = &PlaylistMovie{
+			ID:        m.ID,
+			CreatorID: m.CreatorID,
+			MovieBase: m.MovieBase,
+			Position:  m.Position,
+		}
+	}
+	return p
+}
+
+// Tree returns the movies folders first, a movie after its folder and the siblings in
+// position order, with the depth of each movie. A movie whose folder is not in the
+// archive is at the root, so is a movie of a loop of folders.
+func (p *Playlist) Tree() ([]*PlaylistMovie, map[string]int) {
+	byID := make(map[string]*PlaylistMovie, len(p.Movies))
+	for _, m := range p.Movies {
+		byID[m.ID] = m
+	}
+	depths := make(map[string]int, len(p.Movies))
+	var depth func(m *PlaylistMovie, seen int) int
+	depth = func(m *PlaylistMovie, seen int) int {
+		if d, ok := depths[m.ID]; ok {
+			return d
+		}
+		parent, ok := byID[m.ParentID.String()]
+		if !ok || seen > len(p.Movies) {
+			depths[m.ID] = 0
+			return 0
+		}
+		d := depth(parent, seen+1) + 1
+		depths[m.ID] = d
+		return d
+	}
+	movies := slices.Clone(p.Movies)
+	for _, m := range movies {
+		depth(m, 0)
+	}
+	slices.SortStableFunc(movies, func(a, b *PlaylistMovie) int {
+		if d := depths[a.ID] - depths[b.ID]; d != 0 {
+			return d
+		}
+		switch {
+		case a.Position < b.Position:
+			return -1
+		case a.Position > b.Position:
+			return 1
+		}
+		return 0
+	})
+	return movies, depths
+}
+
+// write encodes v as json, or as the file name of a zip archive.
+func write(w io.Writer, format, name string, v any) error {
+	switch format {
+	case FormatJSON, "":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case FormatZip:
+		zw := zip.NewWriter(w)
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+		return zw.Close()
+	default:
+		return fmt.Errorf("unknown archive format: %s", format)
+	}
+}
+
+// read decodes a json archive, or the file name of a zip archive.
+func read(data []byte, name string, v any) error {
+	if !bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return json.Unmarshal(data, v)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	f, err := zr.Open(name)
+	if err != nil {
+		return fmt.Errorf("open %s of the archive: %w", name, err)
+	}
+	defer f.Close()
+	return json.NewDecoder(io.LimitReader(f, MaxSize)).Decode(v)
+}
+
+func (p *Playlist) Write(w io.Writer, format string) error {
+	return write(w, format, playlistFile, p)
+}
+
+// ReadPlaylist decodes a json or zip playlist archive.
+func ReadPlaylist(data []byte) (*Playlist, error) {
+	p := &Playlist{}
+	if err := read(data, playlistFile, p); err != nil {
+		return nil, err
+	}
+	if p.Version < 1 || p.Version > PlaylistVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, p.Version)
+	}
+	return p, nil
+}
+
+// ContentType is the mime type of an archive format.
+func ContentType(format string) string {
+	if format == FormatZip {
+		return "application/zip"
+	}
+	return "application/json"
+}
+
+const (
+	ImportStatusImported = "imported"
+	ImportStatusSkipped  = "skipped"
+	ImportStatusFailed   = "failed"
+)
+
+// ImportResult is the outcome of the import of a movie of an archive.
+type ImportResult struct {
+	// ID is the id of the movie in the archive, NewID its id in the room
+	ID     string `json:"id"`
+	NewID  string `json:"newId,omitempty"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
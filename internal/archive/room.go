@@ -0,0 +1,91 @@
+package archive
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+const (
+	// RoomVersion is bumped when a change of the format can not be read by the older
+	// instances
+	RoomVersion = 1
+
+	roomFile = "room.json"
+)
+
+// Room is a backup of a room, its settings, members and movie tree. The password is
+// kept hashed.
+type Room struct {
+	ExportedAt     time.Time           `json:"exportedAt"`
+	Settings       *model.RoomSettings `json:"settings"`
+	Playlist       *Playlist           `json:"playlist"`
+	ID             string              `json:"id"`
+	Name           string              `json:"name"`
+	CreatorID      string              `json:"creatorId"`
+	HashedPassword []byte              `json:"hashedPassword,omitempty"`
+	Members        []*RoomMember       `json:"members"`
+	Status         model.RoomStatus    `json:"status"`
+	Version        int                 `json:"version"`
+}
+
+type RoomMember struct {
+	UserID           string                     `json:"userId"`
+	Permissions      model.RoomMemberPermission `json:"permissions"`
+	AdminPermissions model.RoomAdminPermission  `json:"adminPermissions"`
+	Status           model.RoomMemberStatus     `json:"status"`
+	Role             model.RoomMemberRole       `json:"role"`
+}
+
+// NewRoom archives a room with its members and movies.
+func NewRoom(room *model.Room, members []*model.RoomMember, movies []*model.Movie) *Room {
+	r := &Room{
+		Version:        RoomVersion,
+		ExportedAt:     time.Now(),
+		ID:             room.ID,
+		Name:           room.Name,
+		CreatorID:      room.CreatorID,
+		HashedPassword: room.HashedPassword,
+		Status:         room.Status,
+		Members:        make([]*RoomMember, len(members)),
+		Playlist:       NewPlaylist(room.ID, movies),
+	}
+	if room.Settings != nil {
+		s := *room.Settings
+		r.Settings = &s
+	}
+	for i, m := range members {
+		r.Members[i] = &RoomMember{
+			UserID:           m.UserID,
+			Permissions:      m.Permissions,
+			AdminPermissions: m.AdminPermissions,
+			Status:           m.Status,
+			Role:             m.Role,
+		}
+	}
+	return r
+}
+
+func (r *Room) Write(w io.Writer, format string) error {
+	return write(w, format, roomFile, r)
+}
+
+// ReadRoom decodes a json or zip room archive.
+func ReadRoom(data []byte) (*Room, error) {
+	r := &Room{}
+	if err := read(data, roomFile, r); err != nil {
+		return nil, err
+	}
+	if r.Version < 1 || r.Version > RoomVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, r.Version)
+	}
+	if r.Playlist == nil {
+		r.Playlist = &Playlist{Version: PlaylistVersion}
+	}
+	if r.Playlist.Version < 1 || r.Playlist.Version > PlaylistVersion {
+		return nil, fmt.Errorf("%w: playlist %d", ErrUnsupportedVersion, r.Playlist.Version)
+	}
+	return r, nil
+}
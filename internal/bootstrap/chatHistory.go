@@ -0,0 +1,12 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/PeterChen1997/synctv/internal/op"
+)
+
+func InitChatHistory(ctx context.Context) error {
+	go op.RecordChatHistory(ctx)
+	return nil
+}
@@ -75,12 +75,23 @@ func optConfigPath(conf *conf.Config) error {
 	if err != nil {
 		return fmt.Errorf("get log file path error: %w", err)
 	}
+	conf.Transcode.WorkDir, err = utils.OptFilePath(conf.Transcode.WorkDir)
+	if err != nil {
+		return fmt.Errorf("get transcode work dir error: %w", err)
+	}
 	for _, op := range conf.Oauth2Plugins {
 		op.PluginFile, err = utils.OptFilePath(op.PluginFile)
 		if err != nil {
 			return fmt.Errorf("get oauth2 plugin file path error: %w", err)
 		}
 	}
+	for i := range conf.VendorPlugins {
+		vp := &conf.VendorPlugins[i]
+		vp.PluginFile, err = utils.OptFilePath(vp.PluginFile)
+		if err != nil {
+			return fmt.Errorf("get vendor plugin file path error: %w", err)
+		}
+	}
 	return nil
 }
 
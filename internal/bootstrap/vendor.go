@@ -2,10 +2,82 @@ package bootstrap
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 
+	"github.com/hashicorp/go-hclog"
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/cmd/flags"
+	"github.com/PeterChen1997/synctv/internal/conf"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/email"
 	"github.com/PeterChen1997/synctv/internal/vendor"
+	"github.com/PeterChen1997/synctv/internal/vendors/plugins"
 )
 
 func InitVendorBackend(ctx context.Context) error {
-	return vendor.Init(ctx)
+	if err := vendor.Init(ctx); err != nil {
+		return err
+	}
+	go vendor.RunHealthProbes(ctx, notifyVendorBackendHealth)
+	return nil
+}
+
+// notifyVendorBackendHealth emails the admins and the roots when a backend becomes
+// unhealthy or healthy again.
+func notifyVendorBackendHealth(conn *vendor.BackendConn, status *vendor.HealthStatus) {
+	if !email.EnableEmail.Get() {
+		return
+	}
+	admins, err := db.GetAdmins()
+	if err != nil {
+		log.Errorf("notify vendor backend health: %v", err)
+		return
+	}
+	var emails []string
+	for _, u := range append(admins, db.GetRoots()...) {
+		if u.Email != "" {
+			emails = append(emails, u.Email.String())
+		}
+	}
+	if len(emails) == 0 {
+		return
+	}
+	err = email.SendVendorBackendHealthEmail(
+		emails,
+		conn.Info.Backend.Endpoint,
+		status.Healthy,
+		status.LastError,
+		status.Since,
+	)
+	if err != nil {
+		log.Errorf("send vendor backend health email: %v", err)
+	}
+}
+
+func InitVendorPlugins(_ context.Context) error {
+	logOur := log.StandardLogger().Writer()
+	logLevle := hclog.Info
+	if flags.Global.Dev {
+		logLevle = hclog.Debug
+	}
+	for _, vp := range conf.Conf.VendorPlugins {
+		log.Infof("load vendor plugin: %s", vp.PluginFile)
+		err := os.MkdirAll(filepath.Dir(vp.PluginFile), 0o755)
+		if err != nil {
+			log.Fatalf("create plugin dir: %s failed: %s", filepath.Dir(vp.PluginFile), err)
+			return err
+		}
+		err = plugins.InitVendorPlugins(vp.PluginFile, vp.Args, hclog.New(&hclog.LoggerOptions{
+			Name:   vp.PluginFile,
+			Level:  logLevle,
+			Output: logOur,
+			Color:  hclog.ForceColor,
+		}))
+		if err != nil {
+			log.Fatalf("load vendor plugin: %s failed: %s", vp.PluginFile, err)
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,12 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/PeterChen1997/synctv/internal/op"
+)
+
+func InitVendorBindingValidator(ctx context.Context) error {
+	go op.ValidateVendorBindings(ctx)
+	return nil
+}
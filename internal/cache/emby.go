@@ -29,6 +29,8 @@ type EmbyUserCacheData struct {
 	APIKey   string
 	UserID   string
 	Backend  string
+	// ReportPlayback is the opt-in of the user to report room playback to the server
+	ReportPlayback bool
 }
 
 func NewEmbyUserCache(userID string) *EmbyUserCache {
@@ -49,19 +51,21 @@ func EmbyAuthorizationCacheWithUserIDInitFunc(userID, serverID string) (*EmbyUse
 		return nil, db.NotFoundError(db.ErrVendorNotFound)
 	}
 	return &EmbyUserCacheData{
-		Host:     v.Host,
-		ServerID: v.ServerID,
-		APIKey:   v.APIKey,
-		UserID:   v.EmbyUserID,
-		Backend:  v.Backend,
+		Host:           v.Host,
+		ServerID:       v.ServerID,
+		APIKey:         v.APIKey,
+		UserID:         v.EmbyUserID,
+		Backend:        v.Backend,
+		ReportPlayback: v.ReportPlayback,
 	}, nil
 }
 
 type EmbySource struct {
-	URL         string
-	Name        string
-	Subtitles   []*EmbySubtitleCache
-	IsTranscode bool
+	URL           string
+	Name          string
+	MediaSourceID string
+	Subtitles     []*EmbySubtitleCache
+	IsTranscode   bool
 }
 
 type EmbySubtitleCache struct {
@@ -72,6 +76,7 @@ type EmbySubtitleCache struct {
 }
 
 type EmbyMovieCacheData struct {
+	ItemID             string
 	TranscodeSessionID string
 	Sources            []EmbySource
 }
@@ -154,6 +159,7 @@ func NewEmbyMovieCacheInitFunc(
 		}
 
 		resp := &EmbyMovieCacheData{
+			ItemID:             truePath,
 			Sources:            make([]EmbySource, len(data.GetMediaSourceInfo())),
 			TranscodeSessionID: data.GetPlaySessionID(),
 		}
@@ -224,7 +230,7 @@ func processMediaSource(
 	truePath string,
 	u *url.URL,
 ) (*EmbySource, error) {
-	source := &EmbySource{Name: v.GetName()}
+	source := &EmbySource{Name: v.GetName(), MediaSourceID: v.GetId()}
 
 	switch {
 	case v.GetTranscodingUrl() != "":
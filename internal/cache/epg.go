@@ -0,0 +1,29 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/iptv"
+	"github.com/zijiren233/gencontainer/refreshcache0"
+)
+
+// EPGCache holds the parsed XMLTV guides by their url, the guides are shared by every
+// room importing the same provider.
+type EPGCache = MapCache0[*iptv.Guide]
+
+func NewEPGCache(maxAge time.Duration) *EPGCache {
+	return newMapCache0(func(ctx context.Context, key string) (*iptv.Guide, error) {
+		guide, err := iptv.FetchGuide(ctx, key)
+		if err != nil {
+			// keep the previous programmes until the provider is back
+			if old, ok := ctx.Value(refreshcache0.OldValKey).(*iptv.Guide); ok && old != nil {
+				log.Warnf("refresh epg guide %s error: %v", key, err)
+				return old, nil
+			}
+			return nil, err
+		}
+		return guide, nil
+	}, maxAge)
+}
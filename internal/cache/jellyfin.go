@@ -0,0 +1,279 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	"github.com/PeterChen1997/synctv/proto/vendors/jellyfin"
+	"github.com/zijiren233/gencontainer/refreshcache"
+	"github.com/zijiren233/gencontainer/refreshcache0"
+	"github.com/zijiren233/gencontainer/refreshcache1"
+)
+
+type JellyfinUserCache = MapCache0[*JellyfinUserCacheData]
+
+type JellyfinUserCacheData struct {
+	Host     string
+	ServerID string
+	APIKey   string
+	UserID   string
+	Backend  string
+}
+
+func NewJellyfinUserCache(userID string) *JellyfinUserCache {
+	return newMapCache0(func(_ context.Context, key string) (*JellyfinUserCacheData, error) {
+		return JellyfinAuthorizationCacheWithUserIDInitFunc(userID, key)
+	}, -1)
+}
+
+func JellyfinAuthorizationCacheWithUserIDInitFunc(
+	userID, serverID string,
+) (*JellyfinUserCacheData, error) {
+	if serverID == "" {
+		return nil, errors.New("serverID is required")
+	}
+	v, err := db.GetJellyfinVendor(userID, serverID)
+	if err != nil {
+		return nil, err
+	}
+	if v.APIKey == "" || v.Host == "" {
+		return nil, db.NotFoundError(db.ErrVendorNotFound)
+	}
+	return &JellyfinUserCacheData{
+		Host:     v.Host,
+		ServerID: v.ServerID,
+		APIKey:   v.APIKey,
+		UserID:   v.JellyfinUserID,
+		Backend:  v.Backend,
+	}, nil
+}
+
+type JellyfinSource struct {
+	URL           string
+	Name          string
+	MediaSourceID string
+	Subtitles     []*JellyfinSubtitleCache
+	IsTranscode   bool
+}
+
+type JellyfinSubtitleCache = EmbySubtitleCache
+
+type JellyfinMovieCacheData struct {
+	// ItemID and PlaySessionID are needed to report the playback progress
+	ItemID        string
+	PlaySessionID string
+	Sources       []JellyfinSource
+}
+
+type JellyfinMovieCache = refreshcache1.RefreshCache[*JellyfinMovieCacheData, *JellyfinUserCache]
+
+func NewJellyfinMovieCache(movie *model.Movie, subPath string) *JellyfinMovieCache {
+	cache := refreshcache1.NewRefreshCache(NewJellyfinMovieCacheInitFunc(movie, subPath), -1)
+	cache.SetClearFunc(NewJellyfinMovieClearCacheFunc(movie, subPath))
+	return cache
+}
+
+func NewJellyfinMovieClearCacheFunc(
+	movie *model.Movie,
+	_ string,
+) func(ctx context.Context, args *JellyfinUserCache) error {
+	return func(ctx context.Context, args *JellyfinUserCache) error {
+		if !movie.VendorInfo.Jellyfin.Transcode {
+			return nil
+		}
+		if args == nil {
+			return errors.New("need jellyfin user cache")
+		}
+
+		serverID, err := movie.VendorInfo.Jellyfin.ServerID()
+		if err != nil {
+			return err
+		}
+
+		oldVal, ok := ctx.Value(refreshcache.OldValKey).(*JellyfinMovieCacheData)
+		if !ok {
+			return nil
+		}
+
+		aucd, err := args.LoadOrStore(ctx, serverID)
+		if err != nil {
+			return err
+		}
+		if aucd.Host == "" || aucd.APIKey == "" {
+			return errors.New("not bind jellyfin vendor")
+		}
+		cli := vendor.LoadJellyfinClient(aucd.Backend)
+		_, err = cli.DeleteActiveEncodings(ctx, &jellyfin.DeleteActiveEncodingsReq{
+			Host:          aucd.Host,
+			Token:         aucd.APIKey,
+			PlaySessionId: oldVal.PlaySessionID,
+		})
+		if err != nil {
+			log.Errorf("delete jellyfin active encodings: %v", err)
+		}
+		return nil
+	}
+}
+
+func NewJellyfinMovieCacheInitFunc(
+	movie *model.Movie,
+	subPath string,
+) func(ctx context.Context, args *JellyfinUserCache) (*JellyfinMovieCacheData, error) {
+	return func(ctx context.Context, args *JellyfinUserCache) (*JellyfinMovieCacheData, error) {
+		if args == nil {
+			return nil, errors.New("need jellyfin user cache")
+		}
+		if movie.IsFolder && subPath == "" {
+			return nil, errors.New("sub path is empty")
+		}
+
+		serverID, itemID, err := movie.VendorInfo.Jellyfin.ServerIDAndFilePath()
+		if err != nil {
+			return nil, err
+		}
+		if movie.IsFolder {
+			itemID = subPath
+		}
+
+		aucd, err := args.LoadOrStore(ctx, serverID)
+		if err != nil {
+			return nil, err
+		}
+		if aucd.Host == "" || aucd.APIKey == "" {
+			return nil, errors.New("not bind jellyfin vendor")
+		}
+
+		cli := vendor.LoadJellyfinClient(aucd.Backend)
+		data, err := cli.PlaybackInfo(ctx, &jellyfin.PlaybackInfoReq{
+			Host:            aucd.Host,
+			Token:           aucd.APIKey,
+			UserId:          aucd.UserID,
+			ItemId:          itemID,
+			EnableTranscode: movie.VendorInfo.Jellyfin.Transcode,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("playback info: %w", err)
+		}
+
+		resp := &JellyfinMovieCacheData{
+			ItemID:        itemID,
+			PlaySessionID: data.GetPlaySessionId(),
+			Sources:       make([]JellyfinSource, 0, len(data.GetMediaSources())),
+		}
+		for _, v := range data.GetMediaSources() {
+			source, err := processJellyfinMediaSource(v, aucd, itemID)
+			if err != nil {
+				return nil, err
+			}
+			if source == nil {
+				continue
+			}
+			source.Subtitles = processJellyfinSubtitles(v, aucd, itemID)
+			resp.Sources = append(resp.Sources, *source)
+		}
+
+		return resp, nil
+	}
+}
+
+func processJellyfinMediaSource(
+	v *jellyfin.MediaSourceInfo,
+	aucd *JellyfinUserCacheData,
+	itemID string,
+) (*JellyfinSource, error) {
+	source := &JellyfinSource{
+		Name:          v.GetName(),
+		MediaSourceID: v.GetId(),
+	}
+
+	// unlike emby, jellyfin urls are not prefixed with /emby
+	host := strings.TrimRight(aucd.Host, "/")
+	if v.GetTranscodingUrl() != "" {
+		source.URL = host + v.GetTranscodingUrl()
+		source.IsTranscode = true
+		return source, nil
+	}
+	if !v.GetSupportsDirectPlay() && !v.GetSupportsDirectStream() {
+		return nil, nil
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+	u.Path, err = url.JoinPath(u.Path, "Videos", itemID, "stream")
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("api_key", aucd.APIKey)
+	query.Set("static", "true")
+	query.Set("mediaSourceId", v.GetId())
+	if v.GetContainer() != "" {
+		query.Set("container", v.GetContainer())
+	}
+	u.RawQuery = query.Encode()
+	source.URL = u.String()
+	return source, nil
+}
+
+func processJellyfinSubtitles(
+	v *jellyfin.MediaSourceInfo,
+	aucd *JellyfinUserCacheData,
+	itemID string,
+) []*JellyfinSubtitleCache {
+	subtitles := make([]*JellyfinSubtitleCache, 0, len(v.GetMediaStreams()))
+	for _, msi := range v.GetMediaStreams() {
+		if msi.GetType() != "Subtitle" {
+			continue
+		}
+
+		u, err := url.Parse(strings.TrimRight(aucd.Host, "/"))
+		if err != nil {
+			continue
+		}
+		subtitleType := "srt"
+		u.Path, err = url.JoinPath(
+			u.Path,
+			"Videos",
+			itemID,
+			v.GetId(),
+			"Subtitles",
+			strconv.FormatUint(msi.GetIndex(), 10),
+			"0",
+			"Stream."+subtitleType,
+		)
+		if err != nil {
+			continue
+		}
+		query := url.Values{}
+		query.Set("api_key", aucd.APIKey)
+		u.RawQuery = query.Encode()
+		url := u.String()
+
+		name := msi.GetDisplayTitle()
+		if name == "" {
+			if msi.GetTitle() != "" {
+				name = msi.GetTitle()
+			} else {
+				name = msi.GetDisplayLanguage()
+			}
+		}
+
+		subtitles = append(subtitles, &JellyfinSubtitleCache{
+			URL:   url,
+			Type:  subtitleType,
+			Name:  name,
+			Cache: refreshcache0.NewRefreshCache(newEmbySubtitleCacheInitFunc(url), -1),
+		})
+	}
+	return subtitles
+}
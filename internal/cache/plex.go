@@ -0,0 +1,212 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/plex"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/gencontainer/refreshcache"
+	"github.com/zijiren233/gencontainer/refreshcache0"
+	"github.com/zijiren233/gencontainer/refreshcache1"
+)
+
+type PlexUserCache = MapCache0[*PlexUserCacheData]
+
+type PlexUserCacheData struct {
+	Host     string
+	ServerID string
+	Token    string
+}
+
+func (p *PlexUserCacheData) Client() *plex.Client {
+	return plex.NewClient(p.Host, p.Token)
+}
+
+func NewPlexUserCache(userID string) *PlexUserCache {
+	return newMapCache0(func(_ context.Context, key string) (*PlexUserCacheData, error) {
+		return PlexAuthorizationCacheWithUserIDInitFunc(userID, key)
+	}, -1)
+}
+
+func PlexAuthorizationCacheWithUserIDInitFunc(userID, serverID string) (*PlexUserCacheData, error) {
+	if serverID == "" {
+		return nil, errors.New("serverID is required")
+	}
+	v, err := db.GetPlexVendor(userID, serverID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Token == "" || v.Host == "" {
+		return nil, db.NotFoundError(db.ErrVendorNotFound)
+	}
+	return &PlexUserCacheData{
+		Host:     v.Host,
+		ServerID: v.ServerID,
+		Token:    v.Token,
+	}, nil
+}
+
+type PlexSource struct {
+	URL         string
+	Name        string
+	Subtitles   []*PlexSubtitleCache
+	IsTranscode bool
+}
+
+type PlexSubtitleCache = EmbySubtitleCache
+
+type PlexMovieCacheData struct {
+	TranscodeSession string
+	Sources          []PlexSource
+}
+
+type PlexMovieCache = refreshcache1.RefreshCache[*PlexMovieCacheData, *PlexUserCache]
+
+func NewPlexMovieCache(movie *model.Movie, subPath string) *PlexMovieCache {
+	cache := refreshcache1.NewRefreshCache(NewPlexMovieCacheInitFunc(movie, subPath), -1)
+	cache.SetClearFunc(NewPlexMovieClearCacheFunc(movie, subPath))
+	return cache
+}
+
+func NewPlexMovieClearCacheFunc(
+	movie *model.Movie,
+	_ string,
+) func(ctx context.Context, args *PlexUserCache) error {
+	return func(ctx context.Context, args *PlexUserCache) error {
+		if !movie.VendorInfo.Plex.Transcode {
+			return nil
+		}
+		if args == nil {
+			return errors.New("need plex user cache")
+		}
+
+		serverID, err := movie.VendorInfo.Plex.ServerID()
+		if err != nil {
+			return err
+		}
+
+		oldVal, ok := ctx.Value(refreshcache.OldValKey).(*PlexMovieCacheData)
+		if !ok || oldVal.TranscodeSession == "" {
+			return nil
+		}
+
+		pucd, err := args.LoadOrStore(ctx, serverID)
+		if err != nil {
+			return err
+		}
+		err = pucd.Client().StopTranscode(ctx, oldVal.TranscodeSession)
+		if err != nil {
+			log.Errorf("stop plex transcode session: %v", err)
+		}
+		return nil
+	}
+}
+
+func NewPlexMovieCacheInitFunc(
+	movie *model.Movie,
+	subPath string,
+) func(ctx context.Context, args *PlexUserCache) (*PlexMovieCacheData, error) {
+	return func(ctx context.Context, args *PlexUserCache) (*PlexMovieCacheData, error) {
+		if args == nil {
+			return nil, errors.New("need plex user cache")
+		}
+		if movie.IsFolder && subPath == "" {
+			return nil, errors.New("sub path is empty")
+		}
+
+		serverID, itemPath, err := movie.VendorInfo.Plex.ServerIDAndFilePath()
+		if err != nil {
+			return nil, err
+		}
+		if movie.IsFolder {
+			itemPath = subPath
+		}
+		prefix, ratingKey, err := plex.ParsePath(itemPath)
+		if err != nil {
+			return nil, err
+		}
+		if prefix != plex.MetadataPrefix {
+			return nil, fmt.Errorf("plex path is not playable: %s", itemPath)
+		}
+
+		pucd, err := args.LoadOrStore(ctx, serverID)
+		if err != nil {
+			return nil, err
+		}
+		if pucd.Host == "" || pucd.Token == "" {
+			return nil, errors.New("not bind plex vendor")
+		}
+
+		cli := pucd.Client()
+		item, err := cli.Metadata(ctx, ratingKey)
+		if err != nil {
+			return nil, fmt.Errorf("plex metadata: %w", err)
+		}
+
+		resp := &PlexMovieCacheData{
+			Sources: make([]PlexSource, 0, len(item.Media)),
+		}
+		if movie.VendorInfo.Plex.Transcode {
+			resp.TranscodeSession = utils.SortUUID()
+		}
+		for i, media := range item.Media {
+			if len(media.Part) == 0 {
+				continue
+			}
+			part := media.Part[0]
+			source := PlexSource{
+				Name:      media.Name(),
+				Subtitles: processPlexSubtitles(cli, part),
+			}
+			if movie.VendorInfo.Plex.Transcode {
+				source.URL = cli.TranscodeURL(ratingKey, i, resp.TranscodeSession)
+				source.IsTranscode = true
+			} else {
+				source.URL = cli.PartURL(part)
+			}
+			resp.Sources = append(resp.Sources, source)
+		}
+
+		return resp, nil
+	}
+}
+
+// processPlexSubtitles only keeps external subtitles, embedded ones have no stream url.
+func processPlexSubtitles(cli *plex.Client, part *plex.Part) []*PlexSubtitleCache {
+	subtitles := make([]*PlexSubtitleCache, 0, len(part.Stream))
+	for _, s := range part.Stream {
+		if s.StreamType != plex.StreamTypeSubtitle {
+			continue
+		}
+		url := cli.StreamURL(s)
+		if url == "" {
+			continue
+		}
+
+		subtitleType := s.Codec
+		if subtitleType == "" {
+			subtitleType = "srt"
+		}
+		name := s.DisplayTitle
+		if name == "" {
+			if s.Title != "" {
+				name = s.Title
+			} else {
+				name = s.Language
+			}
+		}
+
+		subtitles = append(subtitles, &PlexSubtitleCache{
+			URL:   url,
+			Type:  subtitleType,
+			Name:  name,
+			Cache: refreshcache0.NewRefreshCache(newEmbySubtitleCacheInitFunc(url), -1),
+		})
+	}
+	return subtitles
+}
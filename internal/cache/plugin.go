@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/plugins"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+	"github.com/zijiren233/gencontainer/refreshcache0"
+	"github.com/zijiren233/gencontainer/refreshcache1"
+)
+
+// PluginUserCache is keyed by PluginUserCacheKey, a user can bind accounts of several
+// plugins.
+type PluginUserCache = MapCache0[*PluginUserCacheData]
+
+type PluginUserCacheData struct {
+	ExpireAt   time.Time
+	Plugin     string
+	ServerID   string
+	Name       string
+	Credential string
+}
+
+func PluginUserCacheKey(plugin, serverID string) string {
+	return plugin + "/" + serverID
+}
+
+func NewPluginUserCache(userID string) *PluginUserCache {
+	return newMapCache0(func(_ context.Context, key string) (*PluginUserCacheData, error) {
+		return PluginAuthorizationCacheWithUserIDInitFunc(userID, key)
+	}, -1)
+}
+
+func PluginAuthorizationCacheWithUserIDInitFunc(
+	userID, key string,
+) (*PluginUserCacheData, error) {
+	plugin, serverID, ok := strings.Cut(key, "/")
+	if !ok || plugin == "" || serverID == "" {
+		return nil, errors.New("plugin and serverID are required")
+	}
+	v, err := db.GetPluginVendor(userID, plugin, serverID)
+	if err != nil {
+		return nil, err
+	}
+	return &PluginUserCacheData{
+		ExpireAt:   v.ExpireAt,
+		Plugin:     v.Plugin,
+		ServerID:   v.ServerID,
+		Name:       v.Name,
+		Credential: v.Credential,
+	}, nil
+}
+
+type PluginSource struct {
+	Name    string
+	URL     string
+	Type    string
+	Headers map[string]string
+}
+
+type PluginSubtitleCache struct {
+	Cache *refreshcache0.RefreshCache[[]byte]
+	Name  string
+	Type  string
+	URL   string
+}
+
+type PluginMovieCacheData struct {
+	ExpireAt  time.Time
+	Sources   []*PluginSource
+	Subtitles []*PluginSubtitleCache
+	Duration  float64
+	Live      bool
+}
+
+// PluginMovieCache refreshes the resolved urls once the plugin says they expired.
+type PluginMovieCache struct {
+	*refreshcache1.RefreshCache[*PluginMovieCacheData, *PluginUserCache]
+}
+
+func NewPluginMovieCache(movie *model.Movie, subPath string) *PluginMovieCache {
+	return &PluginMovieCache{
+		RefreshCache: refreshcache1.NewRefreshCache(NewPluginMovieCacheInitFunc(movie, subPath), -1),
+	}
+}
+
+func (c *PluginMovieCache) Get(
+	ctx context.Context,
+	args *PluginUserCache,
+) (*PluginMovieCacheData, error) {
+	data, err := c.RefreshCache.Get(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	if !data.ExpireAt.IsZero() && time.Now().After(data.ExpireAt) {
+		return c.Refresh(ctx, args)
+	}
+	return data, nil
+}
+
+func NewPluginMovieCacheInitFunc(
+	movie *model.Movie,
+	subPath string,
+) func(ctx context.Context, args *PluginUserCache) (*PluginMovieCacheData, error) {
+	return func(ctx context.Context, args *PluginUserCache) (*PluginMovieCacheData, error) {
+		if args == nil {
+			return nil, errors.New("need plugin user cache")
+		}
+		if movie.IsFolder && subPath == "" {
+			return nil, errors.New("sub path is empty")
+		}
+
+		info := movie.VendorInfo.Plugin
+		v, ok := plugins.LoadVendor(info.Name)
+		if !ok {
+			return nil, errors.New("vendor plugin not loaded: " + info.Name)
+		}
+		serverID, filePath, err := info.ServerIDAndFilePath()
+		if err != nil {
+			return nil, err
+		}
+		if movie.IsFolder {
+			filePath = subPath
+		}
+
+		pucd, err := args.LoadOrStore(ctx, PluginUserCacheKey(info.Name, serverID))
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := v.Resolve(ctx, &pb.ResolveReq{
+			Credential: pucd.Credential,
+			Path:       filePath,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(data.GetSources()) == 0 {
+			return nil, errors.New("no source")
+		}
+		resp := &PluginMovieCacheData{
+			Duration: data.GetDuration(),
+			Live:     data.GetLive(),
+		}
+		if data.GetExpireAt() != 0 {
+			resp.ExpireAt = time.Unix(data.GetExpireAt(), 0)
+		}
+		for _, s := range data.GetSources() {
+			resp.Sources = append(resp.Sources, &PluginSource{
+				Name:    s.GetName(),
+				URL:     s.GetUrl(),
+				Type:    s.GetType(),
+				Headers: s.GetHeaders(),
+			})
+		}
+
+		subtitles, err := v.Subtitles(ctx, &pb.SubtitlesReq{
+			Credential: pucd.Credential,
+			Path:       filePath,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range subtitles.GetSubtitles() {
+			resp.Subtitles = append(resp.Subtitles, &PluginSubtitleCache{
+				Name: s.GetName(),
+				Type: s.GetType(),
+				URL:  s.GetUrl(),
+				Cache: refreshcache0.NewRefreshCache(
+					newHeaderSubtitleCacheInitFunc(s.GetUrl(), s.GetHeaders()),
+					-1,
+				),
+			})
+		}
+
+		return resp, nil
+	}
+}
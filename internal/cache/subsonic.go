@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/subsonic"
+	"github.com/zijiren233/gencontainer/refreshcache1"
+)
+
+type SubsonicUserCache = MapCache0[*SubsonicUserCacheData]
+
+type SubsonicUserCacheData struct {
+	Host     string
+	ServerID string
+	Username string
+	Password string
+}
+
+func (s *SubsonicUserCacheData) Client() (*subsonic.Client, error) {
+	return subsonic.NewClient(s.Host, s.Username, s.Password)
+}
+
+func NewSubsonicUserCache(userID string) *SubsonicUserCache {
+	return newMapCache0(func(_ context.Context, key string) (*SubsonicUserCacheData, error) {
+		return SubsonicAuthorizationCacheWithUserIDInitFunc(userID, key)
+	}, -1)
+}
+
+func SubsonicAuthorizationCacheWithUserIDInitFunc(
+	userID, serverID string,
+) (*SubsonicUserCacheData, error) {
+	if serverID == "" {
+		return nil, errors.New("serverID is required")
+	}
+	v, err := db.GetSubsonicVendor(userID, serverID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Host == "" {
+		return nil, db.NotFoundError(db.ErrVendorNotFound)
+	}
+	return &SubsonicUserCacheData{
+		Host:     v.Host,
+		ServerID: v.ServerID,
+		Username: v.Username,
+		Password: v.Password,
+	}, nil
+}
+
+// SubsonicMovieCacheData holds the signed upstream urls of a song, they are only used
+// by the proxy so the credentials never reach the browser.
+type SubsonicMovieCacheData struct {
+	Song     *subsonic.Song
+	URL      string
+	CoverURL string
+	// NextSongID is the song after this one in its album, empty for the last song or
+	// when the movie is a single song
+	NextSongID string
+}
+
+type SubsonicMovieCache = refreshcache1.RefreshCache[*SubsonicMovieCacheData, *SubsonicUserCache]
+
+func NewSubsonicMovieCache(movie *model.Movie, subPath string) *SubsonicMovieCache {
+	return refreshcache1.NewRefreshCache(NewSubsonicMovieCacheInitFunc(movie, subPath), -1)
+}
+
+// coverArtSize is big enough for a now playing view and much smaller than the originals
+const coverArtSize = 600
+
+func NewSubsonicMovieCacheInitFunc(
+	movie *model.Movie,
+	subPath string,
+) func(ctx context.Context, args *SubsonicUserCache) (*SubsonicMovieCacheData, error) {
+	return func(ctx context.Context, args *SubsonicUserCache) (*SubsonicMovieCacheData, error) {
+		if args == nil {
+			return nil, errors.New("need subsonic user cache")
+		}
+		if movie.IsFolder && subPath == "" {
+			return nil, errors.New("sub path is empty")
+		}
+
+		serverID, itemPath, err := movie.VendorInfo.Subsonic.ServerIDAndItemPath()
+		if err != nil {
+			return nil, err
+		}
+		folderKind, folderID, err := subsonic.ParsePath(itemPath)
+		if err != nil {
+			return nil, err
+		}
+		songPath := itemPath
+		if movie.IsFolder {
+			songPath = subPath
+		}
+		kind, songID, err := subsonic.ParsePath(songPath)
+		if err != nil {
+			return nil, err
+		}
+		if kind != subsonic.KindSong {
+			return nil, errors.New("subsonic path is not a song")
+		}
+
+		sucd, err := args.LoadOrStore(ctx, serverID)
+		if err != nil {
+			return nil, err
+		}
+		cli, err := sucd.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		song, err := cli.Song(ctx, songID)
+		if err != nil {
+			return nil, fmt.Errorf("subsonic get song: %w", err)
+		}
+		if movie.IsFolder {
+			switch folderKind {
+			case subsonic.KindArtist:
+				// the track artist of a compilation differs from the album artist
+				_, albums, err := cli.ArtistAlbums(ctx, folderID)
+				if err != nil {
+					return nil, fmt.Errorf("subsonic get artist: %w", err)
+				}
+				if !slices.ContainsFunc(albums, func(a *subsonic.Album) bool {
+					return a.ID == song.AlbumID
+				}) {
+					return nil, errors.New("sub path is not in parent path")
+				}
+			case subsonic.KindAlbum:
+				if song.AlbumID != folderID {
+					return nil, errors.New("sub path is not in parent path")
+				}
+			}
+		}
+
+		resp := &SubsonicMovieCacheData{
+			Song: song,
+			URL:  cli.StreamURL(song.ID),
+		}
+		if song.CoverArt != "" {
+			resp.CoverURL = cli.CoverArtURL(song.CoverArt, coverArtSize)
+		}
+		if movie.IsFolder && song.AlbumID != "" {
+			_, songs, err := cli.AlbumSongs(ctx, song.AlbumID)
+			if err != nil {
+				return nil, fmt.Errorf("subsonic get album: %w", err)
+			}
+			for i, s := range songs {
+				if s.ID == song.ID && i+1 < len(songs) {
+					resp.NextSongID = songs[i+1].ID
+					break
+				}
+			}
+		}
+
+		return resp, nil
+	}
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/webdav"
+	"github.com/zijiren233/gencontainer/refreshcache0"
+	"github.com/zijiren233/gencontainer/refreshcache1"
+	"github.com/zijiren233/go-uhc"
+)
+
+type WebDAVUserCache = MapCache0[*WebDAVUserCacheData]
+
+type WebDAVUserCacheData struct {
+	Host     string
+	ServerID string
+	Username string
+	Password string
+}
+
+func (w *WebDAVUserCacheData) Client() (*webdav.Client, error) {
+	return webdav.NewClient(w.Host, w.Username, w.Password)
+}
+
+func NewWebDAVUserCache(userID string) *WebDAVUserCache {
+	return newMapCache0(func(_ context.Context, key string) (*WebDAVUserCacheData, error) {
+		return WebDAVAuthorizationCacheWithUserIDInitFunc(userID, key)
+	}, -1)
+}
+
+func WebDAVAuthorizationCacheWithUserIDInitFunc(
+	userID, serverID string,
+) (*WebDAVUserCacheData, error) {
+	if serverID == "" {
+		return nil, errors.New("serverID is required")
+	}
+	v, err := db.GetWebDAVVendor(userID, serverID)
+	if err != nil {
+		return nil, err
+	}
+	if v.Host == "" {
+		return nil, db.NotFoundError(db.ErrVendorNotFound)
+	}
+	return &WebDAVUserCacheData{
+		Host:     v.Host,
+		ServerID: v.ServerID,
+		Username: v.Username,
+		Password: v.Password,
+	}, nil
+}
+
+type WebDAVSubtitleCache struct {
+	Cache *refreshcache0.RefreshCache[[]byte]
+	Name  string
+	Type  string
+}
+
+// WebDAVMovieCacheData holds the upstream url and its auth headers, it is only used by
+// the proxy so the credentials never reach the browser.
+type WebDAVMovieCacheData struct {
+	URL       string
+	Name      string
+	Headers   map[string]string
+	Subtitles []*WebDAVSubtitleCache
+}
+
+type WebDAVMovieCache = refreshcache1.RefreshCache[*WebDAVMovieCacheData, *WebDAVUserCache]
+
+func NewWebDAVMovieCache(movie *model.Movie, subPath string) *WebDAVMovieCache {
+	return refreshcache1.NewRefreshCache(NewWebDAVMovieCacheInitFunc(movie, subPath), -1)
+}
+
+func NewWebDAVMovieCacheInitFunc(
+	movie *model.Movie,
+	subPath string,
+) func(ctx context.Context, args *WebDAVUserCache) (*WebDAVMovieCacheData, error) {
+	return func(ctx context.Context, args *WebDAVUserCache) (*WebDAVMovieCacheData, error) {
+		if args == nil {
+			return nil, errors.New("need webdav user cache")
+		}
+		if movie.IsFolder && subPath == "" {
+			return nil, errors.New("sub path is empty")
+		}
+
+		serverID, filePath, err := movie.VendorInfo.WebDAV.ServerIDAndFilePath()
+		if err != nil {
+			return nil, err
+		}
+		if movie.IsFolder {
+			folder, err := webdav.CleanPath(filePath)
+			if err != nil {
+				return nil, err
+			}
+			filePath, err = webdav.CleanPath(subPath)
+			if err != nil {
+				return nil, err
+			}
+			if !webdav.InDir(folder, filePath) {
+				return nil, errors.New("sub path is not in parent path")
+			}
+		}
+
+		wucd, err := args.LoadOrStore(ctx, serverID)
+		if err != nil {
+			return nil, err
+		}
+		cli, err := wucd.Client()
+		if err != nil {
+			return nil, err
+		}
+
+		f, err := cli.Stat(ctx, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("webdav stat: %w", err)
+		}
+		if f.IsDir {
+			return nil, errors.New("webdav path is a directory")
+		}
+		u, err := cli.URL(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		resp := &WebDAVMovieCacheData{
+			URL:     u,
+			Name:    f.Name,
+			Headers: cli.Headers(),
+		}
+
+		subtitles, err := cli.Subtitles(ctx, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("webdav subtitles: %w", err)
+		}
+		for _, s := range subtitles {
+			su, err := cli.URL(s.File.Path)
+			if err != nil {
+				continue
+			}
+			resp.Subtitles = append(resp.Subtitles, &WebDAVSubtitleCache{
+				Name: s.Name,
+				Type: s.Type,
+				Cache: refreshcache0.NewRefreshCache(
+					newHeaderSubtitleCacheInitFunc(su, resp.Headers),
+					-1,
+				),
+			})
+		}
+
+		return resp, nil
+	}
+}
+
+func newHeaderSubtitleCacheInitFunc(
+	url string,
+	headers map[string]string,
+) func(ctx context.Context) ([]byte, error) {
+	return func(ctx context.Context) ([]byte, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		resp, err := uhc.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.New("bad status code")
+		}
+		return io.ReadAll(resp.Body)
+	}
+}
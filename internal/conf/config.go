@@ -21,8 +21,14 @@ type Config struct {
 	// Oauth2Plugins
 	Oauth2Plugins Oauth2Plugins `yaml:"oauth2_plugins"`
 
+	// VendorPlugins
+	VendorPlugins VendorPlugins `yaml:"vendor_plugins"`
+
 	// RateLimit
 	RateLimit RateLimitConfig `yaml:"rate_limit"`
+
+	// Transcode
+	Transcode TranscodeConfig `yaml:"transcode"`
 }
 
 func (c *Config) Save(file string) error {
@@ -46,7 +52,13 @@ func DefaultConfig() *Config {
 		// OAuth2
 		Oauth2Plugins: DefaultOauth2Plugins(),
 
+		// Vendor plugins
+		VendorPlugins: DefaultVendorPlugins(),
+
 		// RateLimit
 		RateLimit: DefaultRateLimitConfig(),
+
+		// Transcode
+		Transcode: DefaultTranscodeConfig(),
 	}
 }
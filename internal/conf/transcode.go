@@ -0,0 +1,30 @@
+package conf
+
+//nolint:tagliatelle
+type TranscodeConfig struct {
+	Enable     bool                 `env:"TRANSCODE_ENABLE"      yaml:"enable"      lc:"default: false"`
+	FFmpegPath string               `env:"TRANSCODE_FFMPEG_PATH" yaml:"ffmpeg_path" hc:"ffmpeg binary, searched in PATH when it is not a path"`
+	WorkDir    string               `env:"TRANSCODE_WORK_DIR"    yaml:"work_dir"    hc:"renditions are written here, empty means the system temp dir"`
+	Ladder     []TranscodeRendition `                            yaml:"ladder"      hc:"renditions produced for every transcoded live channel"`
+}
+
+//nolint:tagliatelle
+type TranscodeRendition struct {
+	Name         string `yaml:"name"`
+	Height       uint16 `yaml:"height"`
+	VideoBitrate string `yaml:"video_bitrate" hc:"required, example: 2800k"`
+	AudioBitrate string `yaml:"audio_bitrate" hc:"empty means 128k, example: 128k"`
+}
+
+func DefaultTranscodeConfig() TranscodeConfig {
+	return TranscodeConfig{
+		Enable:     false,
+		FFmpegPath: "ffmpeg",
+		WorkDir:    "",
+		Ladder: []TranscodeRendition{
+			{Name: "1080p", Height: 1080, VideoBitrate: "5000k", AudioBitrate: "192k"},
+			{Name: "720p", Height: 720, VideoBitrate: "2800k", AudioBitrate: "128k"},
+			{Name: "480p", Height: 480, VideoBitrate: "1400k", AudioBitrate: "96k"},
+		},
+	}
+}
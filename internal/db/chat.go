@@ -0,0 +1,67 @@
+package db
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+const ErrChatFilterNotFound = "chat filter"
+
+// chat messages are written in batches, danmu of a busy live room comes in bursts
+const chatMessageBatchSize = 100
+
+func CreateChatMessages(messages []*model.ChatMessage) error {
+	return db.CreateInBatches(messages, chatMessageBatchSize).Error
+}
+
+// GetChatMessages returns up to limit messages of a room sent before the time, newest
+// first.
+func GetChatMessages(roomID string, before time.Time, limit int) ([]*model.ChatMessage, error) {
+	var messages []*model.ChatMessage
+	err := db.Where("room_id = ? AND created_at < ?", roomID, before).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&messages).
+		Error
+	return messages, err
+}
+
+func DeleteChatMessagesBefore(t time.Time) (int64, error) {
+	result := db.Where("created_at < ?", t).Delete(&model.ChatMessage{})
+	return result.RowsAffected, result.Error
+}
+
+func DeleteChatMessage(roomID, id string) error {
+	return db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.ChatMessage{}).Error
+}
+
+func GetChatFilters(roomID string) ([]*model.ChatFilter, error) {
+	var filters []*model.ChatFilter
+	err := db.Where("room_id = ?", roomID).Order("created_at asc").Find(&filters).Error
+	return filters, err
+}
+
+func CreateChatFilter(f *model.ChatFilter) error {
+	return db.Create(f).Error
+}
+
+func DeleteChatFilter(roomID, id string) error {
+	result := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.ChatFilter{})
+	return HandleUpdateResult(result, ErrChatFilterNotFound)
+}
+
+func GetChatMessage(roomID, id string) (*model.ChatMessage, error) {
+	var m model.ChatMessage
+	err := db.Where("room_id = ? AND id = ?", roomID, id).First(&m).Error
+	return &m, HandleNotFound(err, "chat message")
+}
+
+// UpdateChatMessage saves the content, the mentions, the edit time and the reactions of
+// a message.
+func UpdateChatMessage(m *model.ChatMessage) error {
+	return db.Model(&model.ChatMessage{}).
+		Where("room_id = ? AND id = ?", m.RoomID, m.ID).
+		Select("content", "mentions", "edited_at", "reactions").
+		Updates(m).Error
+}
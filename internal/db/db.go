@@ -4,10 +4,10 @@ import (
 	"errors"
 	"strings"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/PeterChen1997/synctv/internal/conf"
 	"github.com/PeterChen1997/synctv/internal/model"
 	"github.com/PeterChen1997/synctv/utils"
+	log "github.com/sirupsen/logrus"
 	// import fastjson serializer
 	_ "github.com/PeterChen1997/synctv/utils/fastJSONSerializer"
 	"gorm.io/gorm"
@@ -342,6 +342,12 @@ func WhereIDIn(ids []string) func(db *gorm.DB) *gorm.DB {
 	}
 }
 
+func WhereUsernameIn(names []string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("username IN ?", names)
+	}
+}
+
 func WhereRoomSettingWithoutHidden() func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		return db.Where("hidden = ?", false)
@@ -0,0 +1,68 @@
+package db
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+	"gorm.io/gorm/clause"
+)
+
+const ErrUserBlockNotFound = "blocked user"
+
+func CreateDirectMessage(m *model.DirectMessage) error {
+	return db.Create(m).Error
+}
+
+// GetDirectMessages returns up to limit messages between two members of a room sent
+// before the time, newest first.
+func GetDirectMessages(
+	roomID, userID, otherID string,
+	before time.Time,
+	limit int,
+) ([]*model.DirectMessage, error) {
+	var messages []*model.DirectMessage
+	err := db.Where("room_id = ? AND created_at < ?", roomID, before).
+		Where(
+			db.Where("sender_id = ? AND recipient_id = ?", userID, otherID).
+				Or("sender_id = ? AND recipient_id = ?", otherID, userID),
+		).
+		Order("created_at desc").
+		Limit(limit).
+		Find(&messages).
+		Error
+	return messages, err
+}
+
+func DeleteDirectMessagesBefore(t time.Time) (int64, error) {
+	result := db.Where("created_at < ?", t).Delete(&model.DirectMessage{})
+	return result.RowsAffected, result.Error
+}
+
+func BlockUser(userID, blockedUserID string) error {
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.UserBlock{
+		UserID:        userID,
+		BlockedUserID: blockedUserID,
+	}).Error
+}
+
+func UnblockUser(userID, blockedUserID string) error {
+	result := db.Where("user_id = ? AND blocked_user_id = ?", userID, blockedUserID).
+		Delete(&model.UserBlock{})
+	return HandleUpdateResult(result, ErrUserBlockNotFound)
+}
+
+func GetBlockedUsers(userID string) ([]*model.UserBlock, error) {
+	var blocks []*model.UserBlock
+	err := db.Where("user_id = ?", userID).Order("created_at asc").Find(&blocks).Error
+	return blocks, err
+}
+
+// IsBlockedBetween reports whether either of the users blocked the other.
+func IsBlockedBetween(userID, otherID string) (bool, error) {
+	var count int64
+	err := db.Model(&model.UserBlock{}).
+		Where("user_id = ? AND blocked_user_id = ?", userID, otherID).
+		Or("user_id = ? AND blocked_user_id = ?", otherID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
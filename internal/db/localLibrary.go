@@ -0,0 +1,154 @@
+package db
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/conf"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	ErrLocalLibraryNotFound   = "local library"
+	ErrLocalMediaFileNotFound = "local media file"
+)
+
+func GetLocalLibraries(scopes ...func(*gorm.DB) *gorm.DB) ([]*model.LocalLibrary, error) {
+	var libraries []*model.LocalLibrary
+	err := db.Scopes(scopes...).Find(&libraries).Error
+	return libraries, err
+}
+
+func GetLocalLibrariesCount(scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Model(&model.LocalLibrary{}).Scopes(scopes...).Count(&count).Error
+	return count, err
+}
+
+func GetLocalLibrary(id string) (*model.LocalLibrary, error) {
+	var library model.LocalLibrary
+	err := db.Where("id = ?", id).First(&library).Error
+	return &library, HandleNotFound(err, ErrLocalLibraryNotFound)
+}
+
+func CreateLocalLibrary(library *model.LocalLibrary) error {
+	return db.Create(library).Error
+}
+
+func UpdateLocalLibrary(library *model.LocalLibrary) error {
+	result := db.Model(&model.LocalLibrary{}).
+		Where("id = ?", library.ID).
+		Select("name", "root", "watch").
+		Updates(library)
+	return HandleUpdateResult(result, ErrLocalLibraryNotFound)
+}
+
+func DeleteLocalLibraries(ids []string) error {
+	return Transactional(func(tx *gorm.DB) error {
+		if err := tx.Where("library_id IN ?", ids).Delete(&model.LocalMediaFile{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id IN ?", ids).Delete(&model.LocalLibrary{})
+		return HandleUpdateResult(result, ErrLocalLibraryNotFound)
+	})
+}
+
+// ReplaceLocalMediaFiles replaces the whole index of a library with the result of a scan.
+func ReplaceLocalMediaFiles(libraryID string, files []*model.LocalMediaFile) error {
+	return Transactional(func(tx *gorm.DB) error {
+		if err := tx.Where("library_id = ?", libraryID).Delete(&model.LocalMediaFile{}).Error; err != nil {
+			return err
+		}
+		if len(files) != 0 {
+			if err := tx.CreateInBatches(files, 200).Error; err != nil {
+				return err
+			}
+		}
+		result := tx.Model(&model.LocalLibrary{}).
+			Where("id = ?", libraryID).
+			Updates(map[string]any{
+				"scanned_at": time.Now(),
+				"scan_error": "",
+				"file_count": len(files),
+			})
+		return HandleUpdateResult(result, ErrLocalLibraryNotFound)
+	})
+}
+
+func SetLocalLibraryScanError(libraryID string, scanErr error) error {
+	result := db.Model(&model.LocalLibrary{}).
+		Where("id = ?", libraryID).
+		Updates(map[string]any{
+			"scanned_at": time.Now(),
+			"scan_error": scanErr.Error(),
+		})
+	return HandleUpdateResult(result, ErrLocalLibraryNotFound)
+}
+
+func WhereLocalLibraryWatched(db *gorm.DB) *gorm.DB {
+	return db.Where("watch = ?", true)
+}
+
+// WhereLocalMediaFileDir selects the entries of a directory.
+func WhereLocalMediaFileDir(dir string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("dir = ?", dir)
+	}
+}
+
+// WhereLocalMediaFileNameLikeIn selects the entries below a directory whose name
+// contains keyword.
+func WhereLocalMediaFileNameLikeIn(dir, keyword string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if dir != "" {
+			db = db.Where("(dir = ? OR dir LIKE ?)", dir, dir+"/%")
+		}
+		switch dbType {
+		case conf.DatabaseTypePostgres:
+			return db.Where("name ILIKE ?", utils.LIKE(keyword))
+		default:
+			return db.Where("name LIKE ?", utils.LIKE(keyword))
+		}
+	}
+}
+
+func GetLocalMediaFiles(
+	libraryID string,
+	scopes ...func(*gorm.DB) *gorm.DB,
+) ([]*model.LocalMediaFile, error) {
+	var files []*model.LocalMediaFile
+	err := db.Where("library_id = ?", libraryID).
+		Order("is_dir DESC").
+		Order("name ASC").
+		Scopes(scopes...).
+		Find(&files).
+		Error
+	return files, err
+}
+
+func GetLocalMediaFilesCount(libraryID string, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Model(&model.LocalMediaFile{}).
+		Where("library_id = ?", libraryID).
+		Scopes(scopes...).
+		Count(&count).
+		Error
+	return count, err
+}
+
+// GetLocalMediaFile finds an indexed file by its path relative to the library root.
+func GetLocalMediaFile(libraryID, path string) (*model.LocalMediaFile, error) {
+	var (
+		file model.LocalMediaFile
+		dir  string
+	)
+	if i := strings.LastIndexByte(path, '/'); i >= 0 {
+		dir = path[:i]
+	}
+	err := db.Where("library_id = ? AND dir = ? AND path = ?", libraryID, dir, path).
+		First(&file).
+		Error
+	return &file, HandleNotFound(err, ErrLocalMediaFileNotFound)
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"time"
+
 	"github.com/PeterChen1997/synctv/internal/model"
 	"gorm.io/gorm"
 )
@@ -90,6 +92,14 @@ func RoomUnbanMember(roomID, userID string) error {
 	return HandleUpdateResult(result, ErrRoomMemberNotFound)
 }
 
+// SetRoomMemberMutedUntil mutes a member until t, nil unmutes them.
+func SetRoomMemberMutedUntil(roomID, userID string, t *time.Time) error {
+	result := db.Model(&model.RoomMember{}).
+		Where("room_id = ? AND user_id = ?", roomID, userID).
+		Update("muted_until", t)
+	return HandleUpdateResult(result, ErrRoomMemberNotFound)
+}
+
 func DeleteRoomMember(roomID, userID string) error {
 	result := db.
 		Where("NOT EXISTS (?)",
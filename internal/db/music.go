@@ -0,0 +1,37 @@
+package db
+
+import (
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+const (
+	ErrMusicQueueItemNotFound = "music queue item"
+)
+
+func CreateMusicQueueItem(item *model.MusicQueueItem) error {
+	return db.Create(item).Error
+}
+
+// GetMusicQueue returns the queue of a room in request order.
+func GetMusicQueue(roomID string) ([]*model.MusicQueueItem, error) {
+	var items []*model.MusicQueueItem
+	err := db.Where("room_id = ?", roomID).Order("created_at asc").Find(&items).Error
+	return items, err
+}
+
+func GetMusicQueueCount(roomID string) (int64, error) {
+	var count int64
+	err := db.Model(&model.MusicQueueItem{}).Where("room_id = ?", roomID).Count(&count).Error
+	return count, err
+}
+
+func GetMusicQueueItem(roomID, id string) (*model.MusicQueueItem, error) {
+	var item model.MusicQueueItem
+	err := db.Where("room_id = ? AND id = ?", roomID, id).First(&item).Error
+	return &item, HandleNotFound(err, ErrMusicQueueItemNotFound)
+}
+
+func DeleteMusicQueueItem(roomID, id string) error {
+	result := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.MusicQueueItem{})
+	return HandleUpdateResult(result, ErrMusicQueueItemNotFound)
+}
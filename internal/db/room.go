@@ -26,7 +26,7 @@ func WithSetting(setting *model.RoomSettings) CreateRoomConfig {
 func WithCreator(creator *model.User) CreateRoomConfig {
 	return func(r *model.Room) {
 		r.CreatorID = creator.ID
-		r.RoomMembers = []*model.RoomMember{
+		r.RoomMembers = append([]*model.RoomMember{
 			{
 				UserID:           creator.ID,
 				Status:           model.RoomMemberStatusActive,
@@ -34,7 +34,7 @@ func WithCreator(creator *model.User) CreateRoomConfig {
 				Permissions:      model.AllPermissions,
 				AdminPermissions: model.AllAdminPermissions,
 			},
-		}
+		}, r.RoomMembers...)
 	}
 }
 
@@ -44,6 +44,21 @@ func WithRelations(relations []*model.RoomMember) CreateRoomConfig {
 	}
 }
 
+// WithHashedPassword sets the password of the room, a password passed to CreateRoom
+// replaces it.
+func WithHashedPassword(hashedPassword []byte) CreateRoomConfig {
+	return func(r *model.Room) {
+		r.HashedPassword = hashedPassword
+	}
+}
+
+// WithMovies adds the movies to the room, a folder must come before its movies.
+func WithMovies(movies []*model.Movie) CreateRoomConfig {
+	return func(r *model.Room) {
+		r.Movies = append(r.Movies, movies...)
+	}
+}
+
 func WithStatus(status model.RoomStatus) CreateRoomConfig {
 	return func(r *model.Room) {
 		r.Status = status
@@ -83,6 +98,9 @@ func CreateRoom(
 		r.HashedPassword = hashedPassword
 	}
 
+	// the movies are created one by one after the room, so their folders exist
+	movies := r.Movies
+	r.Movies = nil
 	err := Transactional(func(tx *gorm.DB) error {
 		if maxCount > 0 {
 			var count int64
@@ -99,11 +117,18 @@ func CreateRoom(
 			}
 			return fmt.Errorf("failed to create room: %w", err)
 		}
+		for _, m := range movies {
+			m.RoomID = r.ID
+			if err := tx.Create(m).Error; err != nil {
+				return fmt.Errorf("failed to create movie %s: %w", m.Name, err)
+			}
+		}
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	r.Movies = movies
 	return r, nil
 }
 
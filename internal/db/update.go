@@ -15,7 +15,7 @@ type dbVersion struct {
 	NextVersion string
 }
 
-const CurrentVersion = "0.0.13"
+const CurrentVersion = "0.0.31"
 
 var models = []any{
 	new(model.Setting),
@@ -25,10 +25,24 @@ var models = []any{
 	new(model.RoomSettings),
 	new(model.RoomMember),
 	new(model.Movie),
+	new(model.ChatMessage),
+	new(model.ChatFilter),
+	new(model.DirectMessage),
+	new(model.UserBlock),
+	new(model.MusicQueueItem),
+	new(model.WatchParty),
+	new(model.WatchPartyRSVP),
 	new(model.BilibiliVendor),
 	new(model.AlistVendor),
 	new(model.EmbyVendor),
+	new(model.JellyfinVendor),
+	new(model.PlexVendor),
+	new(model.WebDAVVendor),
+	new(model.SubsonicVendor),
+	new(model.PluginVendor),
 	new(model.VendorBackend),
+	new(model.LocalLibrary),
+	new(model.LocalMediaFile),
 }
 
 var dbVersions = map[string]dbVersion{
@@ -88,6 +102,74 @@ var dbVersions = map[string]dbVersion{
 		NextVersion: "0.0.13",
 	},
 	"0.0.13": {
+		NextVersion: "0.0.14",
+	},
+	"0.0.14": {
+		NextVersion: "0.0.15",
+	},
+	"0.0.15": {
+		NextVersion: "0.0.16",
+	},
+	"0.0.16": {
+		NextVersion: "0.0.17",
+	},
+	"0.0.17": {
+		NextVersion: "0.0.18",
+	},
+	"0.0.18": {
+		NextVersion: "0.0.19",
+	},
+	"0.0.19": {
+		NextVersion: "0.0.20",
+	},
+	"0.0.20": {
+		NextVersion: "0.0.21",
+	},
+	"0.0.21": {
+		NextVersion: "0.0.22",
+	},
+	"0.0.22": {
+		NextVersion: "0.0.23",
+	},
+	"0.0.23": {
+		NextVersion: "0.0.24",
+		Upgrade: func(d *gorm.DB) error {
+			return grantMemberPermission(d, model.PermissionRequestTrack)
+		},
+	},
+	"0.0.24": {
+		NextVersion: "0.0.25",
+	},
+	"0.0.25": {
+		NextVersion: "0.0.26",
+	},
+	"0.0.26": {
+		NextVersion: "0.0.27",
+	},
+	"0.0.27": {
+		NextVersion: "0.0.28",
+	},
+	"0.0.28": {
+		NextVersion: "0.0.29",
+		Upgrade: func(d *gorm.DB) error {
+			return grantAdminPermission(
+				d,
+				model.PermissionMuteRoomMember|
+					model.PermissionModerateChat|
+					model.PermissionDeleteChatMessage,
+			)
+		},
+	},
+	"0.0.29": {
+		NextVersion: "0.0.30",
+	},
+	"0.0.30": {
+		NextVersion: "0.0.31",
+		Upgrade: func(d *gorm.DB) error {
+			return grantMemberPermission(d, model.PermissionSendDirectMessage)
+		},
+	},
+	"0.0.31": {
 		NextVersion: "",
 	},
 }
@@ -137,6 +219,32 @@ func UpgradeDatabase() error {
 	return nil
 }
 
+// grantMemberPermission adds a permission introduced by an upgrade to the members and
+// the default permissions of the rooms, which were created without it.
+func grantMemberPermission(d *gorm.DB, permission model.RoomMemberPermission) error {
+	err := d.Exec(
+		"UPDATE room_settings SET user_default_permissions = user_default_permissions | ?",
+		uint32(permission),
+	).Error
+	if err != nil {
+		return err
+	}
+	return d.Exec(
+		"UPDATE room_members SET permissions = permissions | ?",
+		uint32(permission),
+	).Error
+}
+
+// grantAdminPermission adds a permission introduced by an upgrade to the admins of the
+// rooms.
+func grantAdminPermission(d *gorm.DB, permission model.RoomAdminPermission) error {
+	return d.Exec(
+		"UPDATE room_members SET admin_permissions = admin_permissions | ? WHERE role = ?",
+		uint32(permission),
+		model.RoomMemberRoleAdmin,
+	).Error
+}
+
 func autoMigrate(dst ...any) error {
 	log.Info("migrating database...")
 	switch conf.Conf.Database.Type {
@@ -128,8 +128,328 @@ func CreateOrSaveEmbyVendor(vendorInfo *model.EmbyVendor) (*model.EmbyVendor, er
 	})
 }
 
+func SetEmbyVendorReportPlayback(userID, serverID string, report bool) error {
+	result := db.Model(&model.EmbyVendor{}).
+		Where("user_id = ? AND server_id = ?", userID, serverID).
+		Update("report_playback", report)
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
 func DeleteEmbyVendor(userID, serverID string) error {
 	result := db.Where("user_id = ? AND server_id = ?", userID, serverID).
 		Delete(&model.EmbyVendor{})
 	return HandleUpdateResult(result, ErrVendorNotFound)
 }
+
+func GetJellyfinVendors(userID string, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.JellyfinVendor, error) {
+	var vendors []*model.JellyfinVendor
+	err := db.Scopes(scopes...).Where("user_id = ?", userID).Find(&vendors).Error
+	return vendors, err
+}
+
+func GetJellyfinVendorsCount(userID string, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Scopes(scopes...).
+		Where("user_id = ?", userID).
+		Model(&model.JellyfinVendor{}).
+		Count(&count).
+		Error
+	return count, err
+}
+
+func GetJellyfinVendor(userID, serverID string) (*model.JellyfinVendor, error) {
+	var vendor model.JellyfinVendor
+	err := db.Where("user_id = ? AND server_id = ?", userID, serverID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func GetJellyfinFirstVendor(userID string) (*model.JellyfinVendor, error) {
+	var vendor model.JellyfinVendor
+	err := db.Where("user_id = ?", userID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func CreateOrSaveJellyfinVendor(vendorInfo *model.JellyfinVendor) (*model.JellyfinVendor, error) {
+	if vendorInfo.UserID == "" || vendorInfo.ServerID == "" {
+		return nil, errors.New("user_id and server_id must not be empty")
+	}
+	return vendorInfo, Transactional(func(tx *gorm.DB) error {
+		if errors.Is(tx.First(&model.JellyfinVendor{
+			UserID:   vendorInfo.UserID,
+			ServerID: vendorInfo.ServerID,
+		}).Error, gorm.ErrRecordNotFound) {
+			return tx.Create(&vendorInfo).Error
+		}
+		result := tx.Omit("created_at").Save(&vendorInfo)
+		return HandleUpdateResult(result, ErrVendorNotFound)
+	})
+}
+
+func DeleteJellyfinVendor(userID, serverID string) error {
+	result := db.Where("user_id = ? AND server_id = ?", userID, serverID).
+		Delete(&model.JellyfinVendor{})
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
+func GetPlexVendors(userID string, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.PlexVendor, error) {
+	var vendors []*model.PlexVendor
+	err := db.Scopes(scopes...).Where("user_id = ?", userID).Find(&vendors).Error
+	return vendors, err
+}
+
+func GetPlexVendorsCount(userID string, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Scopes(scopes...).
+		Where("user_id = ?", userID).
+		Model(&model.PlexVendor{}).
+		Count(&count).
+		Error
+	return count, err
+}
+
+func GetPlexVendor(userID, serverID string) (*model.PlexVendor, error) {
+	var vendor model.PlexVendor
+	err := db.Where("user_id = ? AND server_id = ?", userID, serverID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func GetPlexFirstVendor(userID string) (*model.PlexVendor, error) {
+	var vendor model.PlexVendor
+	err := db.Where("user_id = ?", userID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func CreateOrSavePlexVendor(vendorInfo *model.PlexVendor) (*model.PlexVendor, error) {
+	if vendorInfo.UserID == "" || vendorInfo.ServerID == "" {
+		return nil, errors.New("user_id and server_id must not be empty")
+	}
+	return vendorInfo, Transactional(func(tx *gorm.DB) error {
+		if errors.Is(tx.First(&model.PlexVendor{
+			UserID:   vendorInfo.UserID,
+			ServerID: vendorInfo.ServerID,
+		}).Error, gorm.ErrRecordNotFound) {
+			return tx.Create(&vendorInfo).Error
+		}
+		result := tx.Omit("created_at").Save(&vendorInfo)
+		return HandleUpdateResult(result, ErrVendorNotFound)
+	})
+}
+
+func DeletePlexVendor(userID, serverID string) error {
+	result := db.Where("user_id = ? AND server_id = ?", userID, serverID).
+		Delete(&model.PlexVendor{})
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
+func GetWebDAVVendors(userID string, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.WebDAVVendor, error) {
+	var vendors []*model.WebDAVVendor
+	err := db.Scopes(scopes...).Where("user_id = ?", userID).Find(&vendors).Error
+	return vendors, err
+}
+
+func GetWebDAVVendorsCount(userID string, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Scopes(scopes...).
+		Where("user_id = ?", userID).
+		Model(&model.WebDAVVendor{}).
+		Count(&count).
+		Error
+	return count, err
+}
+
+func GetWebDAVVendor(userID, serverID string) (*model.WebDAVVendor, error) {
+	var vendor model.WebDAVVendor
+	err := db.Where("user_id = ? AND server_id = ?", userID, serverID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func GetWebDAVFirstVendor(userID string) (*model.WebDAVVendor, error) {
+	var vendor model.WebDAVVendor
+	err := db.Where("user_id = ?", userID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func CreateOrSaveWebDAVVendor(vendorInfo *model.WebDAVVendor) (*model.WebDAVVendor, error) {
+	if vendorInfo.UserID == "" || vendorInfo.ServerID == "" {
+		return nil, errors.New("user_id and server_id must not be empty")
+	}
+	return vendorInfo, Transactional(func(tx *gorm.DB) error {
+		if errors.Is(tx.First(&model.WebDAVVendor{
+			UserID:   vendorInfo.UserID,
+			ServerID: vendorInfo.ServerID,
+		}).Error, gorm.ErrRecordNotFound) {
+			return tx.Create(&vendorInfo).Error
+		}
+		result := tx.Omit("created_at").Save(&vendorInfo)
+		return HandleUpdateResult(result, ErrVendorNotFound)
+	})
+}
+
+func DeleteWebDAVVendor(userID, serverID string) error {
+	result := db.Where("user_id = ? AND server_id = ?", userID, serverID).
+		Delete(&model.WebDAVVendor{})
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
+func GetSubsonicVendors(userID string, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.SubsonicVendor, error) {
+	var vendors []*model.SubsonicVendor
+	err := db.Scopes(scopes...).Where("user_id = ?", userID).Find(&vendors).Error
+	return vendors, err
+}
+
+func GetSubsonicVendorsCount(userID string, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	var count int64
+	err := db.Scopes(scopes...).
+		Where("user_id = ?", userID).
+		Model(&model.SubsonicVendor{}).
+		Count(&count).
+		Error
+	return count, err
+}
+
+func GetSubsonicVendor(userID, serverID string) (*model.SubsonicVendor, error) {
+	var vendor model.SubsonicVendor
+	err := db.Where("user_id = ? AND server_id = ?", userID, serverID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func GetSubsonicFirstVendor(userID string) (*model.SubsonicVendor, error) {
+	var vendor model.SubsonicVendor
+	err := db.Where("user_id = ?", userID).First(&vendor).Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func CreateOrSaveSubsonicVendor(vendorInfo *model.SubsonicVendor) (*model.SubsonicVendor, error) {
+	if vendorInfo.UserID == "" || vendorInfo.ServerID == "" {
+		return nil, errors.New("user_id and server_id must not be empty")
+	}
+	return vendorInfo, Transactional(func(tx *gorm.DB) error {
+		if errors.Is(tx.First(&model.SubsonicVendor{
+			UserID:   vendorInfo.UserID,
+			ServerID: vendorInfo.ServerID,
+		}).Error, gorm.ErrRecordNotFound) {
+			return tx.Create(&vendorInfo).Error
+		}
+		result := tx.Omit("created_at").Save(&vendorInfo)
+		return HandleUpdateResult(result, ErrVendorNotFound)
+	})
+}
+
+func DeleteSubsonicVendor(userID, serverID string) error {
+	result := db.Where("user_id = ? AND server_id = ?", userID, serverID).
+		Delete(&model.SubsonicVendor{})
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
+func GetPluginVendors(
+	userID, plugin string,
+	scopes ...func(*gorm.DB) *gorm.DB,
+) ([]*model.PluginVendor, error) {
+	var vendors []*model.PluginVendor
+	err := db.Scopes(scopes...).
+		Where("user_id = ? AND plugin = ?", userID, plugin).
+		Find(&vendors).
+		Error
+	return vendors, err
+}
+
+func GetPluginVendorsCount(
+	userID, plugin string,
+	scopes ...func(*gorm.DB) *gorm.DB,
+) (int64, error) {
+	var count int64
+	err := db.Scopes(scopes...).
+		Where("user_id = ? AND plugin = ?", userID, plugin).
+		Model(&model.PluginVendor{}).
+		Count(&count).
+		Error
+	return count, err
+}
+
+func GetPluginVendor(userID, plugin, serverID string) (*model.PluginVendor, error) {
+	var vendor model.PluginVendor
+	err := db.Where("user_id = ? AND plugin = ? AND server_id = ?", userID, plugin, serverID).
+		First(&vendor).
+		Error
+	return &vendor, HandleNotFound(err, ErrVendorNotFound)
+}
+
+func CreateOrSavePluginVendor(vendorInfo *model.PluginVendor) (*model.PluginVendor, error) {
+	if vendorInfo.UserID == "" || vendorInfo.Plugin == "" || vendorInfo.ServerID == "" {
+		return nil, errors.New("user_id, plugin and server_id must not be empty")
+	}
+	return vendorInfo, Transactional(func(tx *gorm.DB) error {
+		if errors.Is(tx.First(&model.PluginVendor{
+			UserID:   vendorInfo.UserID,
+			Plugin:   vendorInfo.Plugin,
+			ServerID: vendorInfo.ServerID,
+		}).Error, gorm.ErrRecordNotFound) {
+			return tx.Create(&vendorInfo).Error
+		}
+		result := tx.Omit("created_at").Save(&vendorInfo)
+		return HandleUpdateResult(result, ErrVendorNotFound)
+	})
+}
+
+func DeletePluginVendor(userID, plugin, serverID string) error {
+	result := db.Where("user_id = ? AND plugin = ? AND server_id = ?", userID, plugin, serverID).
+		Delete(&model.PluginVendor{})
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
+const vendorBatchSize = 100
+
+func bindingStatusColumns(status *model.BindingStatus) map[string]any {
+	return map[string]any{
+		"status_checked_at": status.CheckedAt,
+		"status_error":      status.Error,
+		"status_fails":      status.Fails,
+		"status_expired":    status.Expired,
+		"status_notified":   status.Notified,
+	}
+}
+
+// RangeBilibiliVendors calls fn with every bilibili binding in batches.
+func RangeBilibiliVendors(fn func([]*model.BilibiliVendor) error) error {
+	var vendors []*model.BilibiliVendor
+	return db.FindInBatches(&vendors, vendorBatchSize, func(_ *gorm.DB, _ int) error {
+		return fn(vendors)
+	}).Error
+}
+
+// SetBilibiliVendorStatus updates the status without touching updated_at, which is
+// when the user last logged in.
+func SetBilibiliVendorStatus(userID string, status *model.BindingStatus) error {
+	result := db.Model(&model.BilibiliVendor{}).
+		Where("user_id = ?", userID).
+		UpdateColumns(bindingStatusColumns(status))
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
+func RangeAlistVendors(fn func([]*model.AlistVendor) error) error {
+	var vendors []*model.AlistVendor
+	return db.FindInBatches(&vendors, vendorBatchSize, func(_ *gorm.DB, _ int) error {
+		return fn(vendors)
+	}).Error
+}
+
+func SetAlistVendorStatus(userID, serverID string, status *model.BindingStatus) error {
+	result := db.Model(&model.AlistVendor{}).
+		Where("user_id = ? AND server_id = ?", userID, serverID).
+		UpdateColumns(bindingStatusColumns(status))
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
+
+func RangeEmbyVendors(fn func([]*model.EmbyVendor) error) error {
+	var vendors []*model.EmbyVendor
+	return db.FindInBatches(&vendors, vendorBatchSize, func(_ *gorm.DB, _ int) error {
+		return fn(vendors)
+	}).Error
+}
+
+func SetEmbyVendorStatus(userID, serverID string, status *model.BindingStatus) error {
+	result := db.Model(&model.EmbyVendor{}).
+		Where("user_id = ? AND server_id = ?", userID, serverID).
+		UpdateColumns(bindingStatusColumns(status))
+	return HandleUpdateResult(result, ErrVendorNotFound)
+}
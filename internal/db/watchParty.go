@@ -0,0 +1,101 @@
+package db
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ErrWatchPartyNotFound = "watch party"
+)
+
+func CreateWatchParty(p *model.WatchParty) error {
+	return db.Create(p).Error
+}
+
+// GetWatchParties returns the parties of a room in start order.
+func GetWatchParties(roomID string, scopes ...func(*gorm.DB) *gorm.DB) ([]*model.WatchParty, error) {
+	var parties []*model.WatchParty
+	err := db.Where("room_id = ?", roomID).
+		Scopes(scopes...).
+		Order("start_at asc").
+		Find(&parties).Error
+	return parties, err
+}
+
+// WithWatchPartyStartAfter keeps the parties starting after t.
+func WithWatchPartyStartAfter(t time.Time) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("start_at > ?", t)
+	}
+}
+
+func GetWatchParty(roomID, id string) (*model.WatchParty, error) {
+	var p model.WatchParty
+	err := db.Where("room_id = ? AND id = ?", roomID, id).First(&p).Error
+	return &p, HandleNotFound(err, ErrWatchPartyNotFound)
+}
+
+// GetDueWatchParties returns the scheduled parties of all rooms starting before t.
+func GetDueWatchParties(t time.Time) ([]*model.WatchParty, error) {
+	var parties []*model.WatchParty
+	err := db.Where("status = ? AND start_at <= ?", model.WatchPartyStatusScheduled, t).
+		Order("start_at asc").
+		Find(&parties).Error
+	return parties, err
+}
+
+// UpdateWatchParty saves the movie, start, description and reminder of a party and
+// schedules it again.
+func UpdateWatchParty(p *model.WatchParty) error {
+	p.Status = model.WatchPartyStatusScheduled
+	result := db.Model(&model.WatchParty{}).
+		Where("room_id = ? AND id = ?", p.RoomID, p.ID).
+		Updates(map[string]any{
+			"movie_id":    p.MovieID,
+			"start_at":    p.StartAt,
+			"description": p.Description,
+			"status":      p.Status,
+			"reminded":    p.Reminded,
+		})
+	return HandleUpdateResult(result, ErrWatchPartyNotFound)
+}
+
+func DeleteWatchParty(roomID, id string) error {
+	result := db.Where("room_id = ? AND id = ?", roomID, id).Delete(&model.WatchParty{})
+	return HandleUpdateResult(result, ErrWatchPartyNotFound)
+}
+
+func SetWatchPartyStatus(id string, status model.WatchPartyStatus) error {
+	result := db.Model(&model.WatchParty{}).Where("id = ?", id).Update("status", status)
+	return HandleUpdateResult(result, ErrWatchPartyNotFound)
+}
+
+func SetWatchPartyReminded(id string) error {
+	result := db.Model(&model.WatchParty{}).Where("id = ?", id).Update("reminded", true)
+	return HandleUpdateResult(result, ErrWatchPartyNotFound)
+}
+
+func SetWatchPartyRSVP(partyID, userID string, response model.RSVPResponse) error {
+	return db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "party_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"response", "updated_at"}),
+	}).Create(&model.WatchPartyRSVP{
+		PartyID:  partyID,
+		UserID:   userID,
+		Response: response,
+	}).Error
+}
+
+// GetWatchPartyRSVPs returns the answers to the parties.
+func GetWatchPartyRSVPs(partyIDs ...string) ([]*model.WatchPartyRSVP, error) {
+	var rsvps []*model.WatchPartyRSVP
+	if len(partyIDs) == 0 {
+		return rsvps, nil
+	}
+	err := db.Where("party_id IN ?", partyIDs).Order("created_at asc").Find(&rsvps).Error
+	return rsvps, err
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	htmlTemplate "html/template"
 	"net/url"
 	"strings"
 	"text/template"
@@ -61,9 +62,12 @@ var (
 )
 
 var (
-	testTemplate             *template.Template
-	captchaTemplate          *template.Template
-	retrievePasswordTemplate *template.Template
+	testTemplate                 *template.Template
+	captchaTemplate              *template.Template
+	retrievePasswordTemplate     *template.Template
+	vendorBackendHealthTemplate  *template.Template
+	vendorBindingExpiredTemplate *template.Template
+	watchPartyReminderTemplate   *template.Template
 )
 
 func init() {
@@ -108,6 +112,48 @@ func init() {
 		log.Fatalf("parse retrieve password template error: %v", err)
 	}
 	retrievePasswordTemplate = t
+
+	body, err = mjml.ToHTML(
+		context.Background(),
+		stream.BytesToString(emailtemplate.VendorBackendHealthMjml),
+		mjml.WithMinify(true),
+	)
+	if err != nil {
+		log.Fatalf("mjml vendor backend health template error: %v", err)
+	}
+	t, err = template.New("").Parse(body)
+	if err != nil {
+		log.Fatalf("parse vendor backend health template error: %v", err)
+	}
+	vendorBackendHealthTemplate = t
+
+	body, err = mjml.ToHTML(
+		context.Background(),
+		stream.BytesToString(emailtemplate.VendorBindingExpiredMjml),
+		mjml.WithMinify(true),
+	)
+	if err != nil {
+		log.Fatalf("mjml vendor binding expired template error: %v", err)
+	}
+	t, err = template.New("").Parse(body)
+	if err != nil {
+		log.Fatalf("parse vendor binding expired template error: %v", err)
+	}
+	vendorBindingExpiredTemplate = t
+
+	body, err = mjml.ToHTML(
+		context.Background(),
+		stream.BytesToString(emailtemplate.WatchPartyReminderMjml),
+		mjml.WithMinify(true),
+	)
+	if err != nil {
+		log.Fatalf("mjml watch party reminder template error: %v", err)
+	}
+	t, err = template.New("").Parse(body)
+	if err != nil {
+		log.Fatalf("parse watch party reminder template error: %v", err)
+	}
+	watchPartyReminderTemplate = t
 }
 
 type testPayload struct {
@@ -129,6 +175,33 @@ type retrievePasswordPayload struct {
 	Year int
 }
 
+type vendorBackendHealthPayload struct {
+	Message string
+	Error   string
+	Since   string
+
+	Year int
+}
+
+type vendorBindingExpiredPayload struct {
+	Username string
+	Message  string
+	Error    string
+
+	Year int
+}
+
+type watchPartyReminderPayload struct {
+	Username    string
+	Message     string
+	StartAt     string
+	Description string
+	// Link is the html link to the room, empty when the host is not set
+	Link string
+
+	Year int
+}
+
 func SendBindCaptchaEmail(userID, userEmail string) error {
 	if !EnableEmail.Get() {
 		return ErrEmailNotEnabled
@@ -227,6 +300,140 @@ func SendTestEmail(username, email string) error {
 	)
 }
 
+// SendVendorBackendHealthEmail tells the admins a vendor backend became unhealthy or
+// healthy again.
+func SendVendorBackendHealthEmail(
+	emails []string,
+	endpoint string,
+	healthy bool,
+	lastError string,
+	since time.Time,
+) error {
+	if len(emails) == 0 {
+		return errors.New("email is empty")
+	}
+
+	pool, err := getSMTPPool()
+	if err != nil {
+		return err
+	}
+
+	payload := vendorBackendHealthPayload{
+		Since: since.Format(time.RFC3339),
+		Year:  time.Now().Year(),
+	}
+	subject := "SyncTV Vendor Backend Unhealthy"
+	if healthy {
+		subject = "SyncTV Vendor Backend Recovered"
+		payload.Message = fmt.Sprintf(
+			"后端 %s 已恢复正常。Vendor backend %s is healthy again.",
+			endpoint, endpoint,
+		)
+	} else {
+		payload.Message = fmt.Sprintf(
+			"后端 %s 连续探测失败，已切换到其他可用后端。Vendor backend %s failed its health probes, its users were moved to another backend.",
+			endpoint, endpoint,
+		)
+		payload.Error = htmlTemplate.HTMLEscapeString(lastError)
+	}
+	payload.Message = htmlTemplate.HTMLEscapeString(payload.Message)
+
+	out := bytes.NewBuffer(nil)
+	if err := vendorBackendHealthTemplate.Execute(out, payload); err != nil {
+		return err
+	}
+
+	return pool.SendEmail(emails, subject, out.String())
+}
+
+// SendVendorBindingExpiredEmail asks a user to log in to a vendor again, host is empty
+// for the vendors bound once per user.
+func SendVendorBindingExpiredEmail(username, email, vendor, host, lastError string) error {
+	if email == "" {
+		return errors.New("email is empty")
+	}
+
+	pool, err := getSMTPPool()
+	if err != nil {
+		return err
+	}
+
+	name := vendor
+	if host != "" {
+		name = fmt.Sprintf("%s (%s)", vendor, host)
+	}
+	out := bytes.NewBuffer(nil)
+	err = vendorBindingExpiredTemplate.Execute(out, vendorBindingExpiredPayload{
+		Username: htmlTemplate.HTMLEscapeString(username),
+		Message: htmlTemplate.HTMLEscapeString(fmt.Sprintf(
+			"%s 的登录已失效，请重新登录。The login of %s expired, please log in again.",
+			name, name,
+		)),
+		Error: htmlTemplate.HTMLEscapeString(lastError),
+		Year:  time.Now().Year(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return pool.SendEmail(
+		[]string{email},
+		"SyncTV Vendor Login Expired",
+		out.String(),
+	)
+}
+
+// SendWatchPartyReminderEmail reminds a member of a watch party of a room, the room is
+// linked when the host is set.
+func SendWatchPartyReminderEmail(
+	username, email, roomID, roomName, movieName, description string,
+	startAt time.Time,
+) error {
+	if email == "" {
+		return errors.New("email is empty")
+	}
+
+	pool, err := getSMTPPool()
+	if err != nil {
+		return err
+	}
+
+	var link string
+	if host := settings.HOST.Get(); host != "" {
+		u, err := url.Parse(host)
+		if err != nil {
+			return err
+		}
+		u.Path = "web/cinema/" + roomID
+		link = fmt.Sprintf(
+			`<a href="%s" target="_blank" style="text-decoration: none;font-weight: 600;color: #2563eb">进入房间 Join the room</a>`,
+			htmlTemplate.HTMLEscapeString(u.String()),
+		)
+	}
+
+	out := bytes.NewBuffer(nil)
+	err = watchPartyReminderTemplate.Execute(out, watchPartyReminderPayload{
+		Username: htmlTemplate.HTMLEscapeString(username),
+		Message: htmlTemplate.HTMLEscapeString(fmt.Sprintf(
+			"房间 %s 将放映 %s。Room %s will show %s.",
+			roomName, movieName, roomName, movieName,
+		)),
+		StartAt:     htmlTemplate.HTMLEscapeString(startAt.UTC().Format(time.RFC1123)),
+		Description: htmlTemplate.HTMLEscapeString(description),
+		Link:        link,
+		Year:        time.Now().Year(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return pool.SendEmail(
+		[]string{email},
+		"SyncTV Watch Party: "+movieName,
+		out.String(),
+	)
+}
+
 func SendSignupCaptchaEmail(email string) error {
 	if !EnableEmail.Get() {
 		return ErrEmailNotEnabled
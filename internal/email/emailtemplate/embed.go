@@ -11,4 +11,13 @@ var (
 
 	//go:embed retrieve_password.mjml
 	RetrievePasswordMjml []byte
+
+	//go:embed vendor_backend_health.mjml
+	VendorBackendHealthMjml []byte
+
+	//go:embed vendor_binding_expired.mjml
+	VendorBindingExpiredMjml []byte
+
+	//go:embed watch_party_reminder.mjml
+	WatchPartyReminderMjml []byte
 )
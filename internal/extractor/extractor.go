@@ -0,0 +1,209 @@
+// Package extractor finds the media of a web page, so a page url can be pushed as a
+// movie without looking for the direct url of its video first.
+package extractor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/settings"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+const maxPageSize = 8 << 20
+
+// client refuses the local ips on every hop, the page is given by a user
+var client = utils.NewRemoteHTTPClient(settings.AllowProxyToLocal.Get)
+
+var (
+	ErrUnsupportedScheme = errors.New("unsupported scheme")
+	ErrNoCandidate       = errors.New("no media found in the page")
+)
+
+// Extractor finds the movies of the pages it matches. Extract must only read the page,
+// so an extractor can be run against a local html fixture.
+type Extractor interface {
+	Name() string
+	Match(u *url.URL) bool
+	Extract(ctx context.Context, page *Page) ([]*model.MovieBase, error)
+}
+
+type Candidate struct {
+	Movie *model.MovieBase
+	// Extractor is the name of the extractor that found the movie
+	Extractor string
+}
+
+var (
+	extractorsLock sync.RWMutex
+	extractors     []Extractor
+)
+
+// Register adds an extractor, the extractors registered later are run first so a site
+// extractor comes before the generic ones.
+func Register(e Extractor) {
+	extractorsLock.Lock()
+	defer extractorsLock.Unlock()
+	extractors = append([]Extractor{e}, extractors...)
+}
+
+func loadExtractors() []Extractor {
+	extractorsLock.RLock()
+	defer extractorsLock.RUnlock()
+	return extractors
+}
+
+// the generic extractors, the links of the source come last as they have no name
+func init() {
+	Register(&linkExtractor{})
+	Register(&videoExtractor{})
+	Register(&jsonLDExtractor{})
+	Register(&openGraphExtractor{})
+}
+
+// Resolve downloads a page and returns the movies found by the extractors matching it,
+// a url of a media file is its own candidate.
+func Resolve(ctx context.Context, rawURL string) ([]*Candidate, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedScheme, u.Scheme)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", utils.UA)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get page error: status code %d", resp.StatusCode)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if t := mediaType(contentType, u.String()); t != "" {
+		return []*Candidate{{
+			Extractor: "direct",
+			Movie: &model.MovieBase{
+				Name: nameOfURL(u),
+				URL:  u.String(),
+				Type: t,
+			},
+		}}, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+	if err != nil {
+		return nil, err
+	}
+	return Extract(ctx, NewPage(resp.Request.URL, contentType, body))
+}
+
+// Extract runs the extractors matching the page and merges their movies, the first
+// extractor finding a url keeps it.
+func Extract(ctx context.Context, page *Page) ([]*Candidate, error) {
+	var (
+		candidates []*Candidate
+		errs       []error
+		seen       = map[string]struct{}{}
+	)
+	for _, e := range loadExtractors() {
+		if !e.Match(page.URL) {
+			continue
+		}
+		movies, err := e.Extract(ctx, page)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", e.Name(), err))
+			continue
+		}
+		for _, m := range movies {
+			if m.URL == "" {
+				continue
+			}
+			if _, ok := seen[m.URL]; ok {
+				continue
+			}
+			seen[m.URL] = struct{}{}
+			if m.Name == "" {
+				m.Name = page.Title()
+			}
+			if m.Name == "" {
+				m.Name = m.URL
+			}
+			if m.Type == "" {
+				m.Type = utils.GetURLExtension(m.URL)
+			}
+			candidates = append(candidates, &Candidate{
+				Extractor: e.Name(),
+				Movie:     m,
+			})
+		}
+	}
+	if len(candidates) == 0 {
+		if len(errs) != 0 {
+			return nil, errors.Join(errs...)
+		}
+		return nil, ErrNoCandidate
+	}
+	return candidates, nil
+}
+
+// mediaTypes maps the mime types of media to the movie types.
+var mediaTypes = map[string]string{
+	"application/vnd.apple.mpegurl": "m3u8",
+	"application/x-mpegurl":         "m3u8",
+	"audio/mpegurl":                 "m3u8",
+	"audio/x-mpegurl":               "m3u8",
+	"application/dash+xml":          "mpd",
+	"video/mp4":                     "mp4",
+	"video/webm":                    "webm",
+	"video/x-flv":                   "flv",
+	"video/mp2t":                    "ts",
+	"audio/mpeg":                    "mp3",
+	"audio/mp4":                     "m4a",
+	"audio/ogg":                     "ogg",
+	"audio/flac":                    "flac",
+}
+
+// mediaType returns the movie type of a media content type, or of the extension of the
+// url for the servers sending media as a generic binary or text type.
+func mediaType(contentType, u string) string {
+	mt, _, _ := mime.ParseMediaType(contentType)
+	if t, ok := mediaTypes[mt]; ok {
+		return t
+	}
+	if strings.HasPrefix(mt, "video/") || strings.HasPrefix(mt, "audio/") {
+		return utils.GetURLExtension(u)
+	}
+	switch mt {
+	case "", "application/octet-stream", "text/plain", "binary/octet-stream":
+		switch ext := strings.ToLower(utils.GetURLExtension(u)); ext {
+		case "m3u8", "m3u", "mpd", "mp4", "webm", "flv", "mkv", "ts", "mp3", "m4a", "flac":
+			return ext
+		}
+	}
+	return ""
+}
+
+func nameOfURL(u *url.URL) string {
+	p := strings.TrimRight(u.Path, "/")
+	if i := strings.LastIndexByte(p, '/'); i != -1 {
+		p = p[i+1:]
+	}
+	if n, err := url.PathUnescape(p); err == nil && n != "" {
+		return n
+	}
+	return u.Host
+}
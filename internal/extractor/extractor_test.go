@@ -0,0 +1,155 @@
+package extractor_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/PeterChen1997/synctv/internal/extractor"
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+type result struct {
+	Extractor string
+	Name      string
+	URL       string
+	Type      string
+	More      []string
+	Duration  float64
+	Live      bool
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		fixture string
+		pageURL string
+		want    []result
+		wantErr error
+	}{
+		{
+			fixture: "opengraph.html",
+			pageURL: "https://www.example.com/watch/42",
+			want: []result{
+				{
+					Extractor: "opengraph",
+					Name:      "Open Graph Movie",
+					URL:       "https://cdn.example.com/videos/movie.mp4",
+					Type:      "mp4",
+				},
+			},
+		},
+		{
+			fixture: "video.html",
+			pageURL: "https://www.example.com/trailer",
+			want: []result{
+				{
+					Extractor: "html5",
+					Name:      "Trailer",
+					URL:       "https://static.example.com/media/trailer-1080.webm",
+					Type:      "webm",
+					More:      []string{"720p https://static.example.com/media/trailer-720.mp4"},
+				},
+				{
+					Extractor: "html5",
+					Name:      "HTML5 Video",
+					URL:       "https://static.example.com/clips/clip.m3u8",
+					Type:      "m3u8",
+				},
+			},
+		},
+		{
+			fixture: "links.html",
+			pageURL: "https://www.example.com/live",
+			want: []result{
+				{
+					Extractor: "link",
+					Name:      "Live Channel",
+					URL:       "https://live.example.com/channel/index.m3u8?token=abc&exp=1",
+					Type:      "m3u8",
+				},
+				{
+					Extractor: "link",
+					Name:      "Live Channel",
+					URL:       "https://www.example.com/dash/manifest.mpd",
+					Type:      "mpd",
+				},
+			},
+		},
+		{
+			fixture: "jsonld.html",
+			pageURL: "https://www.example.com/show/1",
+			want: []result{
+				{
+					Extractor: "jsonld",
+					Name:      "Episode 1: Pilot",
+					URL:       "https://media.example.com/ep1/master.m3u8",
+					Type:      "m3u8",
+					Duration:  3723,
+				},
+				{
+					Extractor: "jsonld",
+					Name:      "Live",
+					URL:       "https://live.example.com/stream.flv",
+					Type:      "flv",
+					Live:      true,
+				},
+			},
+		},
+		{
+			fixture: "empty.html",
+			pageURL: "https://www.example.com/",
+			wantErr: extractor.ErrNoCandidate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			u, err := url.Parse(tt.pageURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			candidates, err := extractor.Extract(
+				context.Background(),
+				extractor.NewPage(u, "text/html; charset=utf-8", body),
+			)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Extract() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			got := make([]result, len(candidates))
+			for i, c := range candidates {
+				got[i] = toResult(c.Extractor, c.Movie)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Extract() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func toResult(name string, m *model.MovieBase) result {
+	r := result{
+		Extractor: name,
+		Name:      m.Name,
+		URL:       m.URL,
+		Type:      m.Type,
+		Duration:  m.Duration,
+		Live:      m.Live,
+	}
+	for _, s := range m.MoreSources {
+		r.More = append(r.More, s.Name+" "+s.URL)
+	}
+	return r
+}
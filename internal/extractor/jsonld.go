@@ -0,0 +1,158 @@
+package extractor
+
+import (
+	"context"
+	"maps"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// jsonLDExtractor reads the VideoObject and AudioObject of the schema.org json-ld of
+// the page, wherever they are nested.
+type jsonLDExtractor struct{}
+
+func (e *jsonLDExtractor) Name() string {
+	return "jsonld"
+}
+
+func (e *jsonLDExtractor) Match(_ *url.URL) bool {
+	return true
+}
+
+func (e *jsonLDExtractor) Extract(_ context.Context, page *Page) ([]*model.MovieBase, error) {
+	doc, err := page.Document()
+	if err != nil {
+		return nil, err
+	}
+	var movies []*model.MovieBase
+	walk(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.Script ||
+			!strings.EqualFold(strings.TrimSpace(attr(n, "type")), "application/ld+json") {
+			return true
+		}
+		var v any
+		// a broken block of a page does not hide the others
+		if err := json.UnmarshalFromString(text(n), &v); err != nil {
+			return true
+		}
+		movies = append(movies, jsonLDMovies(page, v)...)
+		return true
+	})
+	return movies, nil
+}
+
+func jsonLDMovies(page *Page, v any) []*model.MovieBase {
+	var movies []*model.MovieBase
+	switch v := v.(type) {
+	case []any:
+		for _, i := range v {
+			movies = append(movies, jsonLDMovies(page, i)...)
+		}
+	case map[string]any:
+		if isMediaObject(v["@type"]) {
+			if m := jsonLDMovie(page, v); m != nil {
+				movies = append(movies, m)
+			}
+		}
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if k != "@context" {
+				movies = append(movies, jsonLDMovies(page, v[k])...)
+			}
+		}
+	}
+	return movies
+}
+
+func isMediaObject(t any) bool {
+	switch t := t.(type) {
+	case string:
+		return t == "VideoObject" || t == "AudioObject"
+	case []any:
+		for _, i := range t {
+			if isMediaObject(i) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func jsonLDMovie(page *Page, v map[string]any) *model.MovieBase {
+	u := page.ResolveURL(jsonLDString(v["contentUrl"]))
+	// the embedUrl is mostly a player page, it is only taken when it is the media
+	if embed := page.ResolveURL(jsonLDString(v["embedUrl"])); u == "" && isMediaURL(embed) {
+		u = embed
+	}
+	if u == "" {
+		return nil
+	}
+	m := &model.MovieBase{
+		URL:      u,
+		Name:     strings.TrimSpace(jsonLDString(v["name"])),
+		Type:     mediaType(jsonLDString(v["encodingFormat"]), u),
+		Duration: parseISODuration(jsonLDString(v["duration"])),
+	}
+	if live, ok := v["isLiveBroadcast"].(bool); ok {
+		m.Live = live
+	}
+	if p, ok := v["publication"].(map[string]any); ok {
+		if live, ok := p["isLiveBroadcast"].(bool); ok {
+			m.Live = live
+		}
+	}
+	return m
+}
+
+// jsonLDString is a string value, or the first of a list of them.
+func jsonLDString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []any:
+		if len(v) != 0 {
+			return jsonLDString(v[0])
+		}
+	case map[string]any:
+		// a url given as an object
+		if u, ok := v["@id"].(string); ok {
+			return u
+		}
+		if u, ok := v["url"].(string); ok {
+			return u
+		}
+	}
+	return ""
+}
+
+var isoDurationRe = regexp.MustCompile(
+	`^P(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`,
+)
+
+// parseISODuration parses the PnDTnHnMnS durations of schema.org into seconds, 0 when
+// the duration is missing or invalid.
+func parseISODuration(s string) float64 {
+	m := isoDurationRe.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return 0
+	}
+	var d float64
+	for i, unit := range []float64{24 * 3600, 3600, 60, 1} {
+		if m[i+1] == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(m[i+1], 64)
+		if err != nil {
+			return 0
+		}
+		d += n * unit
+	}
+	return d
+}
@@ -0,0 +1,62 @@
+package extractor
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+var (
+	// absoluteLinkRe matches the absolute hls and dash urls anywhere in the source, the
+	// players configured by a script have them in a string
+	absoluteLinkRe = regexp.MustCompile(
+		`https?://[^\s"'<>()\\]+?\.(?:m3u8|mpd)(?:\?[^\s"'<>()\\]*)?(?:[\s"'<>()\\]|$)`,
+	)
+	// relativeLinkRe matches the quoted urls relative to the page
+	relativeLinkRe = regexp.MustCompile(
+		`["'](\.{0,2}/[^\s"'<>()\\]+?\.(?:m3u8|mpd)(?:\?[^\s"'<>()\\]*)?)["']`,
+	)
+
+	// unescaper undoes the escaping of the urls in json and html attributes
+	unescaper = strings.NewReplacer(
+		`\/`, `/`,
+		`\u002F`, `/`,
+		`\u002f`, `/`,
+		`\u0026`, `&`,
+		`&amp;`, `&`,
+	)
+)
+
+// linkExtractor finds the m3u8 and mpd links in the source of the page.
+type linkExtractor struct{}
+
+func (e *linkExtractor) Name() string {
+	return "link"
+}
+
+func (e *linkExtractor) Match(_ *url.URL) bool {
+	return true
+}
+
+func (e *linkExtractor) Extract(_ context.Context, page *Page) ([]*model.MovieBase, error) {
+	src := unescaper.Replace(string(page.Body))
+	var movies []*model.MovieBase
+	add := func(u string) {
+		if u = page.ResolveURL(u); u != "" {
+			movies = append(movies, &model.MovieBase{
+				URL:  u,
+				Type: mediaType("", u),
+			})
+		}
+	}
+	for _, m := range absoluteLinkRe.FindAllString(src, -1) {
+		add(strings.TrimRight(m, " \t\r\n\"'<>()\\"))
+	}
+	for _, m := range relativeLinkRe.FindAllStringSubmatch(src, -1) {
+		add(m[1])
+	}
+	return movies, nil
+}
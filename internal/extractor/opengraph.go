@@ -0,0 +1,80 @@
+package extractor
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// openGraphExtractor reads the og:video and twitter:player:stream tags, a page can have
+// several og:video each followed by its own og:video:type.
+type openGraphExtractor struct{}
+
+func (e *openGraphExtractor) Name() string {
+	return "opengraph"
+}
+
+func (e *openGraphExtractor) Match(_ *url.URL) bool {
+	return true
+}
+
+func (e *openGraphExtractor) Extract(_ context.Context, page *Page) ([]*model.MovieBase, error) {
+	doc, err := page.Document()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		movies []*model.MovieBase
+		cur    *model.MovieBase
+	)
+	walk(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.Meta {
+			return true
+		}
+		content := attr(n, "content")
+		switch metaKey(n) {
+		case "og:video", "og:video:url", "og:video:secure_url", "twitter:player:stream":
+			u := page.ResolveURL(content)
+			if u == "" {
+				return true
+			}
+			// og:video:url and og:video:secure_url repeat the og:video before them
+			if cur != nil && sameMedia(cur.URL, u) {
+				if strings.HasPrefix(u, "https:") {
+					cur.URL = u
+				}
+				return true
+			}
+			cur = &model.MovieBase{URL: u}
+			movies = append(movies, cur)
+		case "og:video:type", "twitter:player:stream:content_type":
+			if cur != nil {
+				cur.Type = mediaType(content, cur.URL)
+			}
+		}
+		return true
+	})
+	// the players embedded as text/html are pages, not media
+	filtered := movies[:0]
+	for _, m := range movies {
+		if m.Type != "" || isMediaURL(m.URL) {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered, nil
+}
+
+// sameMedia reports whether two urls differ by their scheme only.
+func sameMedia(a, b string) bool {
+	_, ra, _ := strings.Cut(a, "://")
+	_, rb, _ := strings.Cut(b, "://")
+	return ra == rb
+}
+
+func isMediaURL(u string) bool {
+	return mediaType("", u) != ""
+}
@@ -0,0 +1,145 @@
+package extractor
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Page is a downloaded page, the html is parsed once on the first use.
+type Page struct {
+	URL         *url.URL
+	doc         *html.Node
+	docErr      error
+	ContentType string
+	Body        []byte
+	docOnce     sync.Once
+}
+
+func NewPage(u *url.URL, contentType string, body []byte) *Page {
+	return &Page{
+		URL:         u,
+		ContentType: contentType,
+		Body:        body,
+	}
+}
+
+func (p *Page) Document() (*html.Node, error) {
+	p.docOnce.Do(func() {
+		p.doc, p.docErr = html.Parse(bytes.NewReader(p.Body))
+	})
+	return p.doc, p.docErr
+}
+
+// ResolveURL resolves a url of the page against the base of the page, it returns an
+// empty string for the urls that are not http.
+func (p *Page) ResolveURL(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	u := p.base().ResolveReference(r)
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+	return u.String()
+}
+
+// base is the url of the <base> of the page, or the url of the page.
+func (p *Page) base() *url.URL {
+	doc, err := p.Document()
+	if err != nil {
+		return p.URL
+	}
+	var base *url.URL
+	walk(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.Base {
+			return true
+		}
+		if href := attr(n, "href"); href != "" {
+			if u, err := p.URL.Parse(href); err == nil {
+				base = u
+			}
+		}
+		return false
+	})
+	if base == nil {
+		return p.URL
+	}
+	return base
+}
+
+// Title is the og:title of the page, or its <title>.
+func (p *Page) Title() string {
+	doc, err := p.Document()
+	if err != nil {
+		return ""
+	}
+	var og, title string
+	walk(doc, func(n *html.Node) bool {
+		switch n.DataAtom {
+		case atom.Meta:
+			if og == "" && metaKey(n) == "og:title" {
+				og = strings.TrimSpace(attr(n, "content"))
+			}
+		case atom.Title:
+			if title == "" {
+				title = strings.TrimSpace(text(n))
+			}
+		}
+		return og == ""
+	})
+	if og != "" {
+		return og
+	}
+	return title
+}
+
+// walk calls fn with the nodes of the tree in document order until it returns false.
+func walk(n *html.Node, fn func(*html.Node) bool) bool {
+	if n.Type == html.ElementNode && !fn(n) {
+		return false
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if !walk(c, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func text(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+		}
+	}
+	return sb.String()
+}
+
+// metaKey is the lowercased property or name of a <meta>, open graph uses the first and
+// twitter cards the second.
+func metaKey(n *html.Node) string {
+	if k := attr(n, "property"); k != "" {
+		return strings.ToLower(k)
+	}
+	return strings.ToLower(attr(n, "name"))
+}
@@ -0,0 +1,88 @@
+package extractor
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// videoExtractor reads the html5 <video> and <audio> elements, the <source> children of
+// an element are the other sources of its first one.
+type videoExtractor struct{}
+
+func (e *videoExtractor) Name() string {
+	return "html5"
+}
+
+func (e *videoExtractor) Match(_ *url.URL) bool {
+	return true
+}
+
+func (e *videoExtractor) Extract(_ context.Context, page *Page) ([]*model.MovieBase, error) {
+	doc, err := page.Document()
+	if err != nil {
+		return nil, err
+	}
+	var movies []*model.MovieBase
+	walk(doc, func(n *html.Node) bool {
+		if n.DataAtom != atom.Video && n.DataAtom != atom.Audio {
+			return true
+		}
+		var m *model.MovieBase
+		if u := page.ResolveURL(mediaSrc(n)); u != "" {
+			m = &model.MovieBase{
+				URL:  u,
+				Type: mediaType(attr(n, "type"), u),
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode || c.DataAtom != atom.Source {
+				continue
+			}
+			u := page.ResolveURL(mediaSrc(c))
+			if u == "" {
+				continue
+			}
+			t := mediaType(attr(c, "type"), u)
+			if m == nil {
+				m = &model.MovieBase{URL: u, Type: t}
+				continue
+			}
+			m.MoreSources = append(m.MoreSources, &model.MoreSource{
+				Name: sourceName(c, t),
+				URL:  u,
+				Type: t,
+			})
+		}
+		if m != nil {
+			m.Name = strings.TrimSpace(attr(n, "title"))
+			movies = append(movies, m)
+		}
+		return true
+	})
+	return movies, nil
+}
+
+// mediaSrc is the src of an element, lazy loading players keep it in data-src.
+func mediaSrc(n *html.Node) string {
+	if src := attr(n, "src"); src != "" && !strings.HasPrefix(src, "blob:") {
+		return src
+	}
+	return attr(n, "data-src")
+}
+
+func sourceName(n *html.Node, t string) string {
+	for _, k := range []string{"label", "title", "size", "res"} {
+		if v := strings.TrimSpace(attr(n, k)); v != "" {
+			return v
+		}
+	}
+	if t != "" {
+		return t
+	}
+	return "source"
+}
@@ -0,0 +1,99 @@
+// Package ical writes the iCalendar (RFC 5545) feeds calendar apps subscribe to.
+package ical
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	timeFormat = "20060102T150405Z"
+	// lines longer than this many octets are folded
+	lineLimit = 75
+)
+
+type Event struct {
+	Start       time.Time
+	End         time.Time
+	Stamp       time.Time
+	UID         string
+	Summary     string
+	Description string
+	URL         string
+	Cancelled   bool
+}
+
+type Calendar struct {
+	// Name is shown by the calendar apps for the subscription
+	Name   string
+	Events []*Event
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func (c *Calendar) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+	line(&b, "BEGIN:VCALENDAR")
+	line(&b, "VERSION:2.0")
+	line(&b, "PRODID:-//SyncTV//Watch Parties//EN")
+	line(&b, "CALSCALE:GREGORIAN")
+	line(&b, "METHOD:PUBLISH")
+	if c.Name != "" {
+		line(&b, "X-WR-CALNAME:"+textEscaper.Replace(c.Name))
+	}
+	for _, e := range c.Events {
+		line(&b, "BEGIN:VEVENT")
+		line(&b, "UID:"+e.UID)
+		line(&b, "DTSTAMP:"+e.Stamp.UTC().Format(timeFormat))
+		line(&b, "DTSTART:"+e.Start.UTC().Format(timeFormat))
+		if !e.End.IsZero() {
+			line(&b, "DTEND:"+e.End.UTC().Format(timeFormat))
+		}
+		line(&b, "SUMMARY:"+textEscaper.Replace(e.Summary))
+		if e.Description != "" {
+			line(&b, "DESCRIPTION:"+textEscaper.Replace(e.Description))
+		}
+		if e.URL != "" {
+			line(&b, "URL:"+e.URL)
+		}
+		if e.Cancelled {
+			line(&b, "STATUS:CANCELLED")
+		} else {
+			line(&b, "STATUS:CONFIRMED")
+		}
+		line(&b, "END:VEVENT")
+	}
+	line(&b, "END:VCALENDAR")
+	return b.WriteTo(w)
+}
+
+// line writes a content line, folded into lines of at most 75 octets that do not split
+// a character.
+func line(b *bytes.Buffer, s string) {
+	limit := lineLimit
+	for len(s) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		b.WriteString(s[:i])
+		b.WriteString("\r\n ")
+		s = s[i:]
+		// the leading space of a continuation line counts
+		limit = lineLimit - 1
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+}
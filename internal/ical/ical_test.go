@@ -0,0 +1,83 @@
+package ical_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/ical"
+)
+
+var (
+	start = time.Date(2026, 10, 24, 20, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	stamp = time.Date(2026, 10, 19, 12, 30, 0, 0, time.UTC)
+)
+
+func TestCalendarWriteTo(t *testing.T) {
+	tests := []struct {
+		fixture string
+		cal     *ical.Calendar
+	}{
+		{
+			fixture: "escaping.ics",
+			cal: &ical.Calendar{
+				Name: `Movie night; friends, family \ others`,
+				Events: []*ical.Event{
+					{
+						Start:       start,
+						End:         start.Add(2 * time.Hour),
+						Stamp:       stamp,
+						UID:         "party-1@synctv",
+						Summary:     "Alien; director's cut, part 1",
+						Description: "Bring snacks\nDoors open at 19:45\r\nC:\\movies",
+						URL:         "https://synctv.example.com/web/cinema/room-1",
+					},
+				},
+			},
+		},
+		{
+			fixture: "folding.ics",
+			cal: &ical.Calendar{
+				Events: []*ical.Event{
+					{
+						Start:       start,
+						Stamp:       stamp,
+						UID:         "party-2@synctv",
+						Summary:     strings.TrimSpace(strings.Repeat("Long title ", 10)),
+						Description: strings.Repeat("电影之夜", 12),
+						Cancelled:   true,
+					},
+				},
+			},
+		},
+		{
+			fixture: "empty.ics",
+			cal:     &ical.Calendar{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			want, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b bytes.Buffer
+			if _, err := tt.cal.WriteTo(&b); err != nil {
+				t.Fatalf("WriteTo() error = %v", err)
+			}
+			// the fixtures are stored with lf line endings
+			got := strings.ReplaceAll(b.String(), "\r\n", "\n")
+			if got != string(want) {
+				t.Errorf("WriteTo() =\n%s\nwant\n%s", got, want)
+			}
+			for _, l := range strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n") {
+				if len(l) > 75 {
+					t.Errorf("line of %d octets: %q", len(l), l)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package iptv
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/settings"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+const (
+	maxPlaylistSize = 32 << 20
+	// the full guides of big providers are larger, a guide of the channels of a
+	// playlist fits
+	maxGuideSize = 128 << 20
+
+	// GuideWindow is how far ahead the programmes of a guide are kept
+	GuideWindow = 48 * time.Hour
+)
+
+// client refuses the local ips on every hop, the playlists and guides are given by
+// users and the guides are fetched again on a timer
+var client = utils.NewRemoteHTTPClient(settings.AllowProxyToLocal.Get)
+
+func get(ctx context.Context, u string, limit int64) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", utils.UA)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("get %s error: status code %d", u, resp.StatusCode)
+	}
+	// guides are often served as .gz files without a content encoding
+	br := bufio.NewReader(resp.Body)
+	var r io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		r = gr
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.LimitReader(r, limit),
+		Closer: resp.Body,
+	}, nil
+}
+
+func FetchM3U(ctx context.Context, u string) (*Playlist, error) {
+	body, err := get(ctx, u, maxPlaylistSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return ParseM3U(body)
+}
+
+// FetchGuide downloads an XMLTV guide and keeps the programmes of the next
+// GuideWindow.
+func FetchGuide(ctx context.Context, u string) (*Guide, error) {
+	body, err := get(ctx, u, maxGuideSize)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	now := time.Now()
+	return ParseXMLTV(body, now, now.Add(GuideWindow))
+}
@@ -0,0 +1,198 @@
+// Package iptv parses the extended m3u playlists and the XMLTV guides of IPTV
+// providers.
+package iptv
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	json "github.com/json-iterator/go"
+)
+
+var ErrNotExtendedM3U = errors.New("not an extended m3u playlist")
+
+type Channel struct {
+	Headers map[string]string
+	Name    string
+	URL     string
+	TvgID   string
+	TvgName string
+	Group   string
+	Logo    string
+}
+
+type Playlist struct {
+	// EPGURL is the guide named by the x-tvg-url or url-tvg attribute of the header,
+	// the first one when it lists several
+	EPGURL   string
+	Channels []*Channel
+}
+
+// vlcHeaders maps the http options of #EXTVLCOPT and of the #EXTINF attributes to
+// request headers.
+var vlcHeaders = map[string]string{
+	"http-user-agent": "User-Agent",
+	"user-agent":      "User-Agent",
+	"http-referrer":   "Referer",
+	"http-referer":    "Referer",
+	"referer":         "Referer",
+	"http-origin":     "Origin",
+	"http-cookie":     "Cookie",
+}
+
+// ParseM3U parses an extended m3u playlist, entries without a url are dropped.
+func ParseM3U(r io.Reader) (*Playlist, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var (
+		p       = &Playlist{}
+		cur     *Channel
+		started bool
+	)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !started {
+			line = strings.TrimPrefix(line, "\ufeff")
+			if line == "" {
+				continue
+			}
+			if !strings.HasPrefix(line, "#EXTM3U") {
+				return nil, ErrNotExtendedM3U
+			}
+			started = true
+			attrs, _ := parseAttrs(strings.TrimPrefix(line, "#EXTM3U"))
+			epg := attrs["x-tvg-url"]
+			if epg == "" {
+				epg = attrs["url-tvg"]
+			}
+			p.EPGURL, _, _ = strings.Cut(epg, ",")
+			p.EPGURL = strings.TrimSpace(p.EPGURL)
+			continue
+		}
+		if line == "" {
+			continue
+		}
+		if cur == nil {
+			cur = &Channel{Headers: map[string]string{}}
+		}
+		switch {
+		case strings.HasPrefix(line, "#EXTINF:"):
+			attrs, name := parseAttrs(strings.TrimPrefix(line, "#EXTINF:"))
+			cur.Name = strings.TrimSpace(name)
+			cur.TvgID = attrs["tvg-id"]
+			cur.TvgName = attrs["tvg-name"]
+			cur.Logo = attrs["tvg-logo"]
+			if g := attrs["group-title"]; g != "" {
+				cur.Group = g
+			}
+			for k, v := range attrs {
+				if h, ok := vlcHeaders[k]; ok && v != "" {
+					cur.Headers[h] = v
+				}
+			}
+		case strings.HasPrefix(line, "#EXTGRP:"):
+			if cur.Group == "" {
+				cur.Group = strings.TrimSpace(strings.TrimPrefix(line, "#EXTGRP:"))
+			}
+		case strings.HasPrefix(line, "#EXTVLCOPT:"):
+			k, v, ok := strings.Cut(strings.TrimPrefix(line, "#EXTVLCOPT:"), "=")
+			if h, known := vlcHeaders[strings.ToLower(strings.TrimSpace(k))]; ok && known {
+				cur.Headers[h] = strings.TrimSpace(v)
+			}
+		case strings.HasPrefix(line, "#EXTHTTP:"):
+			var hs map[string]string
+			if err := json.UnmarshalFromString(strings.TrimPrefix(line, "#EXTHTTP:"), &hs); err == nil {
+				for k, v := range hs {
+					cur.Headers[http.CanonicalHeaderKey(k)] = v
+				}
+			}
+		case strings.HasPrefix(line, "#"):
+		default:
+			cur.URL = parseURL(line, cur.Headers)
+			if cur.Name == "" {
+				cur.Name = cur.TvgName
+			}
+			if cur.Name == "" {
+				cur.Name = cur.URL
+			}
+			p.Channels = append(p.Channels, cur)
+			cur = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !started {
+		return nil, ErrNotExtendedM3U
+	}
+	return p, nil
+}
+
+// parseURL splits the headers off a url of the form url|Header=value&Header=value.
+func parseURL(line string, headers map[string]string) string {
+	u, opts, ok := strings.Cut(line, "|")
+	if !ok {
+		return line
+	}
+	for _, kv := range strings.Split(opts, "&") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			continue
+		}
+		if uv, err := url.QueryUnescape(v); err == nil {
+			v = uv
+		}
+		headers[http.CanonicalHeaderKey(k)] = v
+	}
+	return strings.TrimSpace(u)
+}
+
+// parseAttrs parses the key="value" attributes of a directive and the title after the
+// first comma outside of the quotes.
+func parseAttrs(s string) (map[string]string, string) {
+	attrs := map[string]string{}
+	i := 0
+	for i < len(s) {
+		switch c := s[i]; {
+		case c == ',':
+			return attrs, s[i+1:]
+		case c == ' ' || c == '\t':
+			i++
+		default:
+			eq := strings.IndexAny(s[i:], "=, \t")
+			if eq == -1 {
+				return attrs, ""
+			}
+			if s[i+eq] != '=' {
+				// the duration, or a flag without a value
+				i += eq
+				continue
+			}
+			key := strings.ToLower(s[i : i+eq])
+			i += eq + 1
+			if i < len(s) && s[i] == '"' {
+				end := strings.IndexByte(s[i+1:], '"')
+				if end == -1 {
+					attrs[key] = s[i+1:]
+					return attrs, ""
+				}
+				attrs[key] = s[i+1 : i+1+end]
+				i += end + 2
+				continue
+			}
+			end := strings.IndexAny(s[i:], ", \t")
+			if end == -1 {
+				attrs[key] = s[i:]
+				return attrs, ""
+			}
+			attrs[key] = s[i : i+end]
+			i += end
+		}
+	}
+	return attrs, ""
+}
@@ -0,0 +1,90 @@
+package iptv_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/PeterChen1997/synctv/internal/iptv"
+)
+
+func TestParseM3U(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    *iptv.Playlist
+		wantErr error
+	}{
+		{
+			fixture: "channels.m3u",
+			want: &iptv.Playlist{
+				EPGURL: "https://epg.example.com/guide.xml.gz",
+				Channels: []*iptv.Channel{
+					{
+						Headers: map[string]string{},
+						Name:    "News HD, the 24h channel",
+						URL:     "https://live.example.com/news/index.m3u8",
+						TvgID:   "news.example",
+						TvgName: "News HD",
+						Group:   "News, World",
+						Logo:    "https://logo.example.com/news.png",
+					},
+					{
+						Headers: map[string]string{
+							"User-Agent": "Player",
+							"Referer":    "https://www.example.com/",
+							"Origin":     "https://www.example.com",
+						},
+						Name:  "Sports",
+						URL:   "https://live.example.com/sports.m3u8",
+						TvgID: "sports.example",
+						Group: "Sports",
+					},
+					{
+						Headers: map[string]string{
+							"Cookie": "session=1",
+						},
+						Name:    "Movies",
+						URL:     "rtmp://live.example.com/app/movies",
+						TvgName: "Movies",
+					},
+				},
+			},
+		},
+		{
+			fixture: "plain.m3u",
+			wantErr: iptv.ErrNotExtendedM3U,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			got, err := iptv.ParseM3U(f)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ParseM3U() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseM3U() error = %v", err)
+			}
+			if got.EPGURL != tt.want.EPGURL {
+				t.Errorf("ParseM3U() EPGURL = %q, want %q", got.EPGURL, tt.want.EPGURL)
+			}
+			if len(got.Channels) != len(tt.want.Channels) {
+				t.Fatalf("ParseM3U() got %d channels, want %d", len(got.Channels), len(tt.want.Channels))
+			}
+			for i, c := range got.Channels {
+				if !reflect.DeepEqual(c, tt.want.Channels[i]) {
+					t.Errorf("ParseM3U() channel %d = %+v, want %+v", i, c, tt.want.Channels[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package iptv
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+type Programme struct {
+	Start time.Time
+	Stop  time.Time
+	Title string
+	Desc  string
+}
+
+// Guide holds the programmes of an XMLTV guide by channel id, sorted by start.
+type Guide struct {
+	programmes map[string][]*Programme
+	// names maps the lowercased display names of the channels to their ids, for the
+	// playlists whose tvg-id does not match the guide
+	names map[string]string
+}
+
+// ParseXMLTV parses an XMLTV guide, only the programmes overlapping [from, to) are
+// kept so a guide of weeks does not stay in memory.
+func ParseXMLTV(r io.Reader, from, to time.Time) (*Guide, error) {
+	g := &Guide{
+		programmes: map[string][]*Programme{},
+		names:      map[string]string{},
+	}
+	dec := xml.NewDecoder(r)
+	// most guides are utf-8, the others are read as is instead of failing
+	dec.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+	var root bool
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		se, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch se.Name.Local {
+		case "tv":
+			root = true
+		case "channel":
+			var c struct {
+				ID           string   `xml:"id,attr"`
+				DisplayNames []string `xml:"display-name"`
+			}
+			if err := dec.DecodeElement(&c, &se); err != nil {
+				return nil, err
+			}
+			for _, n := range c.DisplayNames {
+				n = strings.ToLower(strings.TrimSpace(n))
+				if _, ok := g.names[n]; n != "" && !ok {
+					g.names[n] = c.ID
+				}
+			}
+		case "programme":
+			var p struct {
+				Start   string   `xml:"start,attr"`
+				Stop    string   `xml:"stop,attr"`
+				Channel string   `xml:"channel,attr"`
+				Titles  []string `xml:"title"`
+				Descs   []string `xml:"desc"`
+			}
+			if err := dec.DecodeElement(&p, &se); err != nil {
+				return nil, err
+			}
+			start, err := parseTime(p.Start)
+			if err != nil {
+				continue
+			}
+			// the stop is optional, the start of the next programme ends it
+			stop, _ := parseTime(p.Stop)
+			if !start.Before(to) || (!stop.IsZero() && !stop.After(from)) {
+				continue
+			}
+			prog := &Programme{
+				Start: start,
+				Stop:  stop,
+			}
+			if len(p.Titles) != 0 {
+				prog.Title = strings.TrimSpace(p.Titles[0])
+			}
+			if len(p.Descs) != 0 {
+				prog.Desc = strings.TrimSpace(p.Descs[0])
+			}
+			g.programmes[p.Channel] = append(g.programmes[p.Channel], prog)
+		}
+	}
+	if !root {
+		return nil, errors.New("not an xmltv guide")
+	}
+	for id, ps := range g.programmes {
+		slices.SortFunc(ps, func(a, b *Programme) int {
+			return a.Start.Compare(b.Start)
+		})
+		for i, p := range ps {
+			if p.Stop.IsZero() && i+1 < len(ps) {
+				p.Stop = ps[i+1].Start
+			}
+		}
+		g.programmes[id] = ps
+	}
+	return g, nil
+}
+
+// parseTime parses the YYYYMMDDhhmmss +zzzz times of XMLTV, a time without a zone is
+// in UTC.
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 14 {
+		return time.Time{}, errors.New("invalid xmltv time")
+	}
+	if zone := strings.TrimSpace(s[14:]); zone != "" {
+		return time.Parse("20060102150405 -0700", s[:14]+" "+zone)
+	}
+	return time.ParseInLocation("20060102150405", s[:14], time.UTC)
+}
+
+// NowNext returns the programme at now and the one after it of the first channel
+// found by the keys, each key is tried as an id and then as a display name.
+func (g *Guide) NowNext(now time.Time, keys ...string) (cur, next *Programme) {
+	var ps []*Programme
+	for _, k := range keys {
+		if k == "" {
+			continue
+		}
+		if p, ok := g.programmes[k]; ok {
+			ps = p
+			break
+		}
+		if id, ok := g.names[strings.ToLower(k)]; ok {
+			ps = g.programmes[id]
+			break
+		}
+	}
+	i, _ := slices.BinarySearchFunc(ps, now, func(p *Programme, t time.Time) int {
+		return p.Start.Compare(t)
+	})
+	// ps[i] is the first programme starting after now, the one before may be on air
+	if i < len(ps) && ps[i].Start.Equal(now) {
+		return ps[i], nextOf(ps, i)
+	}
+	if i > 0 {
+		if p := ps[i-1]; p.Stop.IsZero() || p.Stop.After(now) {
+			cur = p
+		}
+	}
+	if i < len(ps) {
+		next = ps[i]
+	}
+	return cur, next
+}
+
+func nextOf(ps []*Programme, i int) *Programme {
+	if i+1 < len(ps) {
+		return ps[i+1]
+	}
+	return nil
+}
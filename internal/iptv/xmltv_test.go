@@ -0,0 +1,108 @@
+package iptv_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/iptv"
+)
+
+func TestParseXMLTV(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "guide.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	from := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	g, err := iptv.ParseXMLTV(f, from, from.Add(iptv.GuideWindow))
+	if err != nil {
+		t.Fatalf("ParseXMLTV() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		now      string
+		keys     []string
+		wantCur  string
+		wantNext string
+	}{
+		{
+			name:     "zone east of utc",
+			now:      "2026-10-19T06:30:00Z",
+			keys:     []string{"news.example"},
+			wantCur:  "Morning News 06:00-07:00",
+			wantNext: "Weather 07:00-08:00",
+		},
+		{
+			name:     "start of a programme",
+			now:      "2026-10-19T07:00:00Z",
+			keys:     []string{"news.example"},
+			wantCur:  "Weather 07:00-08:00",
+			wantNext: "Talk Show 08:00-",
+		},
+		{
+			name:    "zone west of utc without stop",
+			now:     "2026-10-19T12:00:00Z",
+			keys:    []string{"NEWS"},
+			wantCur: "Talk Show 08:00-",
+		},
+		{
+			name:     "before the first programme",
+			now:      "2026-10-19T05:00:00Z",
+			keys:     []string{"news.example"},
+			wantNext: "Morning News 06:00-07:00",
+		},
+		{
+			name:    "time without zone",
+			now:     "2026-10-19T07:00:00Z",
+			keys:    []string{"", "missing", "sports"},
+			wantCur: "Match 06:00-08:00",
+		},
+		{
+			name:     "programme before the window",
+			now:      "2026-10-01T07:00:00Z",
+			keys:     []string{"sports.example"},
+			wantNext: "Match 06:00-08:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now, err := time.Parse(time.RFC3339, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cur, next := g.NowNext(now, tt.keys...)
+			if got := programme(cur); got != tt.wantCur {
+				t.Errorf("NowNext() cur = %q, want %q", got, tt.wantCur)
+			}
+			if got := programme(next); got != tt.wantNext {
+				t.Errorf("NowNext() next = %q, want %q", got, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestParseXMLTVNotGuide(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "notxmltv.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := iptv.ParseXMLTV(f, time.Time{}, time.Now()); err == nil {
+		t.Fatal("ParseXMLTV() error = nil, want an error")
+	}
+}
+
+// programme formats a programme as its title and its utc start and stop.
+func programme(p *iptv.Programme) string {
+	if p == nil {
+		return ""
+	}
+	s := p.Title + " " + p.Start.UTC().Format("15:04") + "-"
+	if !p.Stop.IsZero() {
+		s += p.Stop.UTC().Format("15:04")
+	}
+	return s
+}
@@ -0,0 +1,147 @@
+package llhls
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SegmentName is the name of a full segment, PartName of one of its parts.
+func SegmentName(msn int64) string {
+	return "s" + strconv.FormatInt(msn, 10)
+}
+
+func PartName(msn int64, part int) string {
+	return fmt.Sprintf("s%dp%d", msn, part)
+}
+
+// ParseName parses a segment or part name, part is -1 for a full segment.
+func ParseName(name string) (msn int64, part int, err error) {
+	rest, ok := strings.CutPrefix(name, "s")
+	if !ok {
+		return 0, 0, ErrBadName
+	}
+	part = -1
+	if m, p, ok := strings.Cut(rest, "p"); ok {
+		part, err = strconv.Atoi(p)
+		if err != nil || part < 0 {
+			return 0, 0, ErrBadName
+		}
+		rest = m
+	}
+	msn, err = strconv.ParseInt(rest, 10, 64)
+	if err != nil || msn < 0 {
+		return 0, 0, ErrBadName
+	}
+	return msn, part, nil
+}
+
+// Playlist renders the media playlist. When msn is not negative this is a blocking
+// playlist reload: the response is held until segment msn, or its part when part
+// is not negative, is available.
+func (s *Source) Playlist(
+	ctx context.Context,
+	msn int64,
+	part int,
+	uri func(name string) string,
+) ([]byte, error) {
+	if msn >= 0 {
+		s.mu.RLock()
+		tooNew := msn > s.nextMSNLocked()+1
+		s.mu.RUnlock()
+		if tooNew {
+			return nil, ErrMSNRange
+		}
+		err := s.wait(ctx, blockTimeout(), func() bool {
+			next := s.nextMSNLocked()
+			if s.cur == nil {
+				return false
+			}
+			if part < 0 {
+				return msn < next
+			}
+			return msn < next || (msn == next && part < len(s.cur.Parts))
+		})
+		if err != nil && ctx.Err() != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cur == nil {
+		return nil, ErrNotFound
+	}
+
+	var (
+		targetDuration int64 = SegmentTarget
+		partTarget     int64 = PartTarget
+		discSeq              = s.discSeq
+	)
+	for i, seg := range s.segments {
+		targetDuration = max(targetDuration, seg.Duration)
+		if len(s.segments)-i <= maxPartSegments {
+			partTarget = max(partTarget, longestPart(seg))
+		}
+		if seg.Discontinuity {
+			discSeq--
+		}
+	}
+	// a part cut after a gap in the stream can exceed the target
+	partTarget = max(partTarget, longestPart(s.cur))
+	if s.cur.Discontinuity {
+		discSeq--
+	}
+
+	w := bytes.NewBuffer(make([]byte, 0, 2048))
+	fmt.Fprintf(w,
+		"#EXTM3U\n#EXT-X-VERSION:6\n#EXT-X-TARGETDURATION:%d\n"+
+			"#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,PART-HOLD-BACK=%.3f\n"+
+			"#EXT-X-PART-INF:PART-TARGET=%.3f\n#EXT-X-MEDIA-SEQUENCE:%d\n",
+		(targetDuration+999)/1000,
+		float64(3*partTarget)/1000,
+		float64(partTarget)/1000,
+		s.firstMSNLocked(),
+	)
+	if discSeq != 0 {
+		fmt.Fprintf(w, "#EXT-X-DISCONTINUITY-SEQUENCE:%d\n", discSeq)
+	}
+	for i, seg := range s.segments {
+		if seg.Discontinuity {
+			w.WriteString("#EXT-X-DISCONTINUITY\n")
+		}
+		if len(s.segments)-i <= maxPartSegments {
+			writeParts(w, seg, uri)
+		}
+		fmt.Fprintf(w, "#EXTINF:%.3f,\n%s\n", float64(seg.Duration)/1000, uri(SegmentName(seg.MSN)))
+	}
+	if s.cur.Discontinuity {
+		w.WriteString("#EXT-X-DISCONTINUITY\n")
+	}
+	writeParts(w, s.cur, uri)
+	fmt.Fprintf(w,
+		"#EXT-X-PRELOAD-HINT:TYPE=PART,URI=\"%s\"\n",
+		uri(PartName(s.cur.MSN, len(s.cur.Parts))),
+	)
+	return w.Bytes(), nil
+}
+
+func longestPart(seg *Segment) int64 {
+	var d int64
+	for _, p := range seg.Parts {
+		d = max(d, p.Duration)
+	}
+	return d
+}
+
+func writeParts(w *bytes.Buffer, seg *Segment, uri func(name string) string) {
+	for i, p := range seg.Parts {
+		fmt.Fprintf(w, "#EXT-X-PART:DURATION=%.3f,URI=\"%s\"", float64(p.Duration)/1000, uri(PartName(seg.MSN, i)))
+		if p.Independent {
+			w.WriteString(",INDEPENDENT=YES")
+		}
+		w.WriteByte('\n')
+	}
+}
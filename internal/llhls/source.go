@@ -0,0 +1,423 @@
+package llhls
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/zijiren233/livelib/av"
+	"github.com/zijiren233/livelib/container/flv"
+	"github.com/zijiren233/livelib/container/ts"
+	"github.com/zijiren233/livelib/protocol/hls"
+	"github.com/zijiren233/livelib/protocol/hls/parser"
+)
+
+const (
+	// PartTarget is the target duration of a partial segment in milliseconds.
+	PartTarget = 500
+	// SegmentTarget is the minimum duration of a segment in milliseconds,
+	// segments are only cut on key frames so they can be longer.
+	SegmentTarget = 2000
+
+	maxSegments = 6
+	// parts are only listed for the last segments, older ones are plain segments
+	maxPartSegments = 2
+	maxQueueNum     = 512
+)
+
+var (
+	ErrClosed   = errors.New("low latency hls source closed")
+	ErrNotFound = errors.New("segment not found")
+	ErrBadName  = errors.New("invalid segment name")
+	ErrMSNRange = errors.New("media sequence number out of range")
+)
+
+type Part struct {
+	Duration    int64
+	Independent bool
+	Data        []byte
+}
+
+type Segment struct {
+	MSN      int64
+	Duration int64
+	Parts    []*Part
+	// Discontinuity is set on the first segment after a republish
+	Discontinuity bool
+}
+
+func (s *Segment) Data() []byte {
+	n := 0
+	for _, p := range s.Parts {
+		n += len(p.Data)
+	}
+	b := make([]byte, 0, n)
+	for _, p := range s.Parts {
+		b = append(b, p.Data...)
+	}
+	return b
+}
+
+// Source is a channel player that muxes the stream into mpeg-ts segments split
+// into partial segments, as required by low latency hls.
+type Source struct {
+	packetQueue chan *av.Packet
+	demuxer     *flv.Demuxer
+	muxer       *ts.Muxer
+	parser      *parser.CodecParser
+	parseBuf    *bytes.Buffer
+
+	// muxing state, only touched by SendPacket
+	partBuf         *bytes.Buffer
+	partIndependent bool
+	partStart       uint32
+	segStart        uint32
+	video           track
+	audio           track
+
+	// a source that follows a republish continues the media sequence
+	firstMSN int64
+	discSeq  int64
+
+	mu       sync.RWMutex
+	closed   bool
+	segments []*Segment
+	cur      *Segment
+	notify   chan struct{}
+}
+
+func NewSource() *Source {
+	return &Source{
+		packetQueue: make(chan *av.Packet, maxQueueNum),
+		demuxer:     flv.NewDemuxer(),
+		muxer:       ts.NewMuxer(),
+		parser:      parser.NewCodecParser(),
+		parseBuf:    bytes.NewBuffer(make([]byte, 0, 100*1024)),
+		partBuf:     bytes.NewBuffer(nil),
+		notify:      make(chan struct{}),
+	}
+}
+
+// NewSourceAfter returns a source for a republish of the stream of prev, it continues
+// the media sequence of prev and starts with a discontinuity.
+func NewSourceAfter(prev *Source) *Source {
+	s := NewSource()
+	prev.mu.RLock()
+	defer prev.mu.RUnlock()
+	if prev.cur == nil {
+		// nothing was published
+		s.firstMSN, s.discSeq = prev.firstMSN, prev.discSeq
+		return s
+	}
+	// the segment being built is never completed
+	s.firstMSN = prev.cur.MSN + 1
+	s.discSeq = prev.discSeq + 1
+	return s
+}
+
+func (s *Source) Write(p *av.Packet) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return av.ErrClosed
+	}
+	for {
+		select {
+		case s.packetQueue <- p:
+			return nil
+		default:
+			av.DropPacket(s.packetQueue)
+		}
+	}
+}
+
+func (s *Source) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return av.ErrClosed
+	}
+	s.closed = true
+	close(s.packetQueue)
+	close(s.notify)
+	return nil
+}
+
+func (s *Source) SendPacket(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case p, ok := <-s.packetQueue:
+			if !ok {
+				return nil
+			}
+			if p.IsMetadata {
+				continue
+			}
+			p = p.DeepClone()
+			if err := s.demuxer.Demux(p); err != nil {
+				if errors.Is(err, flv.ErrAvcEndSEQ) {
+					continue
+				}
+				return err
+			}
+			keyFrame, ok, err := s.parse(p)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if err := s.mux(p, keyFrame); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parse converts the packet payload to annex-b / adts, sequence headers are consumed.
+func (s *Source) parse(p *av.Packet) (keyFrame, ok bool, err error) {
+	if p.IsVideo {
+		vh := p.Header.(av.VideoPacketHeader)
+		if vh.CodecID() != av.CODEC_AVC {
+			return false, false, hls.ErrNoSupportVideoCodec
+		}
+		if vh.IsKeyFrame() && vh.IsSeq() {
+			return false, false, s.parser.Parse(p, s.parseBuf)
+		}
+		keyFrame = vh.IsKeyFrame()
+	} else {
+		ah := p.Header.(av.AudioPacketHeader)
+		if ah.SoundFormat() != av.SOUND_AAC {
+			return false, false, hls.ErrNoSupportAudioCodec
+		}
+		if ah.AACPacketType() == av.AAC_SEQHDR {
+			return false, false, s.parser.Parse(p, s.parseBuf)
+		}
+	}
+	s.parseBuf.Reset()
+	if err := s.parser.Parse(p, s.parseBuf); err != nil {
+		return false, false, err
+	}
+	p.Data = s.parseBuf.Bytes()
+	return keyFrame, true, nil
+}
+
+func (s *Source) mux(p *av.Packet, keyFrame bool) error {
+	s.mu.RLock()
+	started := s.cur != nil
+	s.mu.RUnlock()
+
+	next := s.nextTimeStamp(p)
+
+	switch {
+	case keyFrame && (!started || int64(p.TimeStamp-s.segStart) >= SegmentTarget):
+		s.cutSegment(p.TimeStamp)
+	case !started:
+		// wait for the first key frame
+		return nil
+	case p.TimeStamp != s.partStart && int64(next-s.partStart) > PartTarget:
+		// the part would exceed the target with the packet after this one
+		s.cutPart(p.TimeStamp, keyFrame)
+	}
+
+	return s.muxer.Mux(p, s.partBuf)
+}
+
+// track follows the interval between the packets of a track.
+type track struct {
+	last     uint32
+	interval uint32
+	seen     bool
+}
+
+func (t *track) add(ts uint32) {
+	if t.seen && ts > t.last {
+		t.interval = ts - t.last
+	}
+	t.last, t.seen = ts, true
+}
+
+// nextTimeStamp records a packet and returns when the packet after it is expected, the
+// earliest next packet of the tracks.
+func (s *Source) nextTimeStamp(p *av.Packet) uint32 {
+	if p.IsVideo {
+		s.video.add(p.TimeStamp)
+	} else {
+		s.audio.add(p.TimeStamp)
+	}
+	var (
+		next  = p.TimeStamp
+		found bool
+	)
+	for _, t := range []*track{&s.video, &s.audio} {
+		n := t.last + t.interval
+		// a track that stopped is not waited for
+		if t.interval == 0 || n < p.TimeStamp {
+			continue
+		}
+		if !found || n < next {
+			next, found = n, true
+		}
+	}
+	return next
+}
+
+func (s *Source) flushPart(ts uint32) {
+	if s.partBuf.Len() == 0 {
+		return
+	}
+	data := make([]byte, s.partBuf.Len())
+	copy(data, s.partBuf.Bytes())
+	s.partBuf.Reset()
+	part := &Part{
+		Duration:    int64(ts - s.partStart),
+		Independent: s.partIndependent,
+		Data:        data,
+	}
+	s.cur.Parts = append(s.cur.Parts, part)
+	s.cur.Duration += part.Duration
+}
+
+func (s *Source) cutPart(ts uint32, keyFrame bool) {
+	s.mu.Lock()
+	s.flushPart(ts)
+	s.broadcastLocked()
+	s.mu.Unlock()
+
+	s.partStart = ts
+	s.partIndependent = keyFrame
+}
+
+func (s *Source) cutSegment(ts uint32) {
+	s.mu.Lock()
+	if s.cur != nil {
+		s.flushPart(ts)
+		s.segments = append(s.segments, s.cur)
+		if len(s.segments) > maxSegments {
+			s.segments = s.segments[len(s.segments)-maxSegments:]
+		}
+		s.cur = &Segment{MSN: s.cur.MSN + 1}
+	} else {
+		s.cur = &Segment{MSN: s.firstMSN, Discontinuity: s.discSeq != 0}
+	}
+	s.broadcastLocked()
+	s.mu.Unlock()
+
+	s.segStart = ts
+	s.partStart = ts
+	s.partIndependent = true
+	s.partBuf.Write(s.muxer.PAT())
+	s.partBuf.Write(s.muxer.PMT(av.SOUND_AAC, true))
+}
+
+func (s *Source) broadcastLocked() {
+	if s.closed {
+		return
+	}
+	close(s.notify)
+	s.notify = make(chan struct{})
+}
+
+// wait blocks until cond holds, the timeout expires or the source is closed.
+// cond is called with the read lock held.
+func (s *Source) wait(ctx context.Context, timeout time.Duration, cond func() bool) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		s.mu.RLock()
+		ok, closed, notify := cond(), s.closed, s.notify
+		s.mu.RUnlock()
+		if ok {
+			return nil
+		}
+		if closed {
+			return ErrClosed
+		}
+		select {
+		case <-notify:
+		case <-timer.C:
+			return ErrNotFound
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Source) segmentLocked(msn int64) *Segment {
+	for _, seg := range s.segments {
+		if seg.MSN == msn {
+			return seg
+		}
+	}
+	if s.cur != nil && s.cur.MSN == msn {
+		return s.cur
+	}
+	return nil
+}
+
+func (s *Source) firstMSNLocked() int64 {
+	if len(s.segments) != 0 {
+		return s.segments[0].MSN
+	}
+	return s.nextMSNLocked()
+}
+
+// nextMSNLocked is the media sequence number of the segment being built.
+func (s *Source) nextMSNLocked() int64 {
+	if s.cur == nil {
+		return s.firstMSN
+	}
+	return s.cur.MSN
+}
+
+func blockTimeout() time.Duration {
+	return 3 * SegmentTarget * time.Millisecond
+}
+
+// Get returns a full segment or a part by name, waiting for it when it is still
+// being produced so that preload hints can be requested ahead of time.
+func (s *Source) Get(ctx context.Context, name string) ([]byte, error) {
+	msn, part, err := ParseName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	tooOld := msn < s.firstMSNLocked()
+	tooNew := msn > s.nextMSNLocked()+1
+	s.mu.RUnlock()
+	switch {
+	case tooOld:
+		return nil, ErrNotFound
+	case tooNew:
+		return nil, ErrMSNRange
+	}
+
+	var data []byte
+	err = s.wait(ctx, blockTimeout(), func() bool {
+		seg := s.segmentLocked(msn)
+		if seg == nil {
+			return false
+		}
+		if part < 0 {
+			if seg == s.cur {
+				return false
+			}
+			data = seg.Data()
+			return true
+		}
+		if part >= len(seg.Parts) {
+			return false
+		}
+		data = seg.Parts[part].Data
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,258 @@
+package llhls
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/zijiren233/livelib/av"
+)
+
+type videoHeader bool
+
+func (h videoHeader) IsKeyFrame() bool       { return bool(h) }
+func (h videoHeader) IsSeq() bool            { return false }
+func (h videoHeader) CodecID() uint8         { return av.CODEC_AVC }
+func (h videoHeader) CompositionTime() int32 { return 0 }
+
+// stream describes a test stream in milliseconds: a video frame every frame with a key
+// frame every gop, an audio packet every audio when it is not zero, and no packet
+// during gap after gapAt.
+type stream struct {
+	duration uint32
+	frame    uint32
+	gop      uint32
+	audio    uint32
+	gapAt    uint32
+	gap      uint32
+}
+
+func (st stream) packets(start uint32) []*av.Packet {
+	var ps []*av.Packet
+	skip := func(ts uint32) bool {
+		return st.gap != 0 && ts >= st.gapAt && ts < st.gapAt+st.gap
+	}
+	for ts := uint32(0); ts < st.duration; ts += st.frame {
+		if skip(ts) {
+			continue
+		}
+		ps = append(ps, &av.Packet{
+			IsVideo:   true,
+			TimeStamp: start + ts,
+			Header:    videoHeader(ts%st.gop < st.frame),
+			Data:      []byte{0, 0, 0, 1, 0x09, 0xf0},
+		})
+	}
+	for ts := uint32(0); st.audio != 0 && ts < st.duration; ts += st.audio {
+		if skip(ts) {
+			continue
+		}
+		ps = append(ps, &av.Packet{
+			IsAudio:   true,
+			TimeStamp: start + ts,
+			Data:      []byte{0xff, 0xf1, 0x50, 0x80, 0x01, 0x7f, 0xfc},
+		})
+	}
+	slices.SortStableFunc(ps, func(a, b *av.Packet) int {
+		return int(a.TimeStamp) - int(b.TimeStamp)
+	})
+	return ps
+}
+
+func feed(t *testing.T, s *Source, st stream, start uint32) {
+	t.Helper()
+	for _, p := range st.packets(start) {
+		key := p.IsVideo && p.Header.(videoHeader).IsKeyFrame()
+		if err := s.mux(p, key); err != nil {
+			t.Fatalf("mux() error = %v", err)
+		}
+	}
+}
+
+func playlist(t *testing.T, s *Source) string {
+	t.Helper()
+	b, err := s.Playlist(context.Background(), -1, -1, func(name string) string {
+		return name
+	})
+	if err != nil {
+		t.Fatalf("Playlist() error = %v", err)
+	}
+	return string(b)
+}
+
+var partTargetRe = regexp.MustCompile(`PART-TARGET=([0-9.]+)`)
+
+func TestSourceParts(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream stream
+		// the longest part, over the part target only after a gap
+		wantLongest int64
+	}{
+		{
+			name:   "30fps with audio",
+			stream: stream{duration: 12000, frame: 33, gop: 2000, audio: 23},
+		},
+		{
+			name:   "25fps without audio",
+			stream: stream{duration: 12000, frame: 40, gop: 1000},
+		},
+		{
+			name:   "60fps long gop",
+			stream: stream{duration: 16000, frame: 16, gop: 4000, audio: 21},
+		},
+		{
+			name:        "gap in the stream",
+			stream:      stream{duration: 12000, frame: 40, gop: 2000, gapAt: 5000, gap: 800},
+			wantLongest: 840,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSource()
+			feed(t, s, tt.stream, 0)
+			if len(s.segments) == 0 {
+				t.Fatal("no segment was completed")
+			}
+
+			var longest int64
+			for _, seg := range s.segments {
+				var total int64
+				for i, p := range seg.Parts {
+					total += p.Duration
+					longest = max(longest, p.Duration)
+					if i == 0 && !p.Independent {
+						t.Errorf("segment %d part 0 is not independent", seg.MSN)
+					}
+				}
+				if total != seg.Duration {
+					t.Errorf("segment %d duration = %d, parts sum to %d", seg.MSN, seg.Duration, total)
+				}
+				if seg.Duration < SegmentTarget {
+					t.Errorf("segment %d duration = %d, want >= %d", seg.MSN, seg.Duration, SegmentTarget)
+				}
+			}
+			if tt.wantLongest == 0 && longest > PartTarget {
+				t.Errorf("longest part = %d, want <= %d", longest, PartTarget)
+			}
+			if tt.wantLongest != 0 && longest != tt.wantLongest {
+				t.Errorf("longest part = %d, want %d", longest, tt.wantLongest)
+			}
+
+			m := partTargetRe.FindStringSubmatch(playlist(t, s))
+			if m == nil {
+				t.Fatal("playlist has no PART-TARGET")
+			}
+			target, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the listed parts are those of the last segments
+			for _, seg := range s.segments[len(s.segments)-min(maxPartSegments, len(s.segments)):] {
+				for _, p := range seg.Parts {
+					if float64(p.Duration)/1000 > target {
+						t.Errorf("part of %d ms is longer than PART-TARGET=%s", p.Duration, m[1])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSourceAfter(t *testing.T) {
+	live := stream{duration: 6000, frame: 40, gop: 2000, audio: 23}
+	tests := []struct {
+		name string
+		// before is fed to the previous source, after to the source of the republish
+		before, after stream
+		wantFirstMSN  int64
+		wantLines     []string
+		wantNoLines   []string
+	}{
+		{
+			name:         "republish",
+			before:       live,
+			after:        live,
+			wantFirstMSN: 3,
+			wantLines:    []string{"#EXT-X-MEDIA-SEQUENCE:3", "#EXT-X-DISCONTINUITY"},
+			wantNoLines:  []string{"#EXT-X-DISCONTINUITY-SEQUENCE:1"},
+		},
+		{
+			name:         "discontinuity out of the playlist",
+			before:       live,
+			after:        stream{duration: 20000, frame: 40, gop: 2000, audio: 23},
+			wantFirstMSN: 3,
+			wantLines:    []string{"#EXT-X-DISCONTINUITY-SEQUENCE:1"},
+			wantNoLines:  []string{"#EXT-X-DISCONTINUITY"},
+		},
+		{
+			name:         "nothing published before",
+			after:        live,
+			wantFirstMSN: 0,
+			wantLines:    []string{"#EXT-X-MEDIA-SEQUENCE:0"},
+			wantNoLines:  []string{"#EXT-X-DISCONTINUITY", "#EXT-X-DISCONTINUITY-SEQUENCE:1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prev := NewSource()
+			feed(t, prev, tt.before, 0)
+			s := NewSourceAfter(prev)
+			// the timestamps of a new publish start again
+			feed(t, s, tt.after, 0)
+
+			first := s.segments[0]
+			if first.MSN < tt.wantFirstMSN {
+				t.Fatalf("first segment msn = %d, want >= %d", first.MSN, tt.wantFirstMSN)
+			}
+			lines := strings.Split(playlist(t, s), "\n")
+			for _, l := range tt.wantLines {
+				if !slices.Contains(lines, l) {
+					t.Errorf("playlist has no line %q:\n%s", l, strings.Join(lines, "\n"))
+				}
+			}
+			for _, l := range tt.wantNoLines {
+				if slices.Contains(lines, l) {
+					t.Errorf("playlist has line %q:\n%s", l, strings.Join(lines, "\n"))
+				}
+			}
+			if tt.wantFirstMSN == 0 {
+				return
+			}
+			name := SegmentName(tt.wantFirstMSN - 1)
+			if _, err := s.Get(context.Background(), name); err == nil {
+				t.Errorf("Get(%s) of the previous source succeeded", name)
+			}
+		})
+	}
+}
+
+func TestParseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantMSN  int64
+		wantPart int
+		wantErr  bool
+	}{
+		{name: "s0", wantMSN: 0, wantPart: -1},
+		{name: "s12p3", wantMSN: 12, wantPart: 3},
+		{name: "12", wantErr: true},
+		{name: "s-1", wantErr: true},
+		{name: "s1p-1", wantErr: true},
+		{name: "s1px", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msn, part, err := ParseName(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (msn != tt.wantMSN || part != tt.wantPart) {
+				t.Errorf("ParseName() = %d, %d, want %d, %d", msn, part, tt.wantMSN, tt.wantPart)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// ChatMessageSourceBilibili is the source of danmu mirrored from a bilibili live room.
+const ChatMessageSourceBilibili = "bilibili"
+
+// ChatMessage is a persisted message of the room chat, kept for the retention of the
+// chat_history_retention setting.
+type ChatMessage struct {
+	CreatedAt time.Time `gorm:"index:idx_chat_message_room_created,priority:2" json:"createdAt"`
+	ID        string    `gorm:"primaryKey;type:char(32)"                        json:"id"`
+	RoomID    string    `gorm:"not null;index:idx_chat_message_room_created,priority:1;type:char(32)" json:"-"`
+	// UserID is empty for messages mirrored from another platform
+	UserID   string `gorm:"type:char(32)"           json:"userId,omitempty"`
+	Username string `gorm:"not null;type:varchar(128)" json:"username"`
+	Content  string `gorm:"not null;type:text"      json:"content"`
+	// Source is the platform a mirrored message comes from, empty for room members
+	Source string `gorm:"type:varchar(32)" json:"source,omitempty"`
+	// Danmaku was shown over the video instead of in the chat
+	Danmaku bool `json:"danmaku,omitempty"`
+	// ReplyTo is the id of the message replied to
+	ReplyTo string `gorm:"type:char(32)" json:"replyTo,omitempty"`
+	// Mentions are the ids of the members mentioned
+	Mentions []string   `gorm:"serializer:fastjson" json:"mentions,omitempty"`
+	EditedAt *time.Time `json:"editedAt,omitempty"`
+	// Reactions are the ids of the users who reacted with each emoji
+	Reactions map[string][]string `gorm:"serializer:fastjson" json:"reactions,omitempty"`
+}
+
+func (m *ChatMessage) BeforeCreate(_ *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = utils.SortUUID()
+	}
+	return nil
+}
+
+type ChatFilterAction string
+
+const (
+	// ChatFilterActionReplace masks the matches and sends the message
+	ChatFilterActionReplace ChatFilterAction = "replace"
+	// ChatFilterActionBlock refuses the message
+	ChatFilterActionBlock ChatFilterAction = "block"
+)
+
+func (a ChatFilterAction) Valid() bool {
+	return a == ChatFilterActionReplace || a == ChatFilterActionBlock
+}
+
+// ChatFilter is a word or a regular expression checked against the chat messages of a
+// room.
+type ChatFilter struct {
+	CreatedAt time.Time        `json:"createdAt"`
+	ID        string           `gorm:"primaryKey;type:char(32)"            json:"id"`
+	RoomID    string           `gorm:"not null;index;type:char(32)"        json:"-"`
+	Pattern   string           `gorm:"not null;type:varchar(256)"          json:"pattern"`
+	Regex     bool             `json:"regex"`
+	Action    ChatFilterAction `gorm:"not null;type:varchar(16)"           json:"action"`
+	// Replacement replaces the matches, empty masks each character with *
+	Replacement string `gorm:"type:varchar(64)" json:"replacement"`
+}
+
+func (f *ChatFilter) BeforeCreate(_ *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = utils.SortUUID()
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// DirectMessage is a persisted private message between two members of a room, kept
+// when the direct_message_history setting is enabled.
+type DirectMessage struct {
+	CreatedAt   time.Time `gorm:"index:idx_direct_message_room_created,priority:2" json:"createdAt"`
+	ID          string    `gorm:"primaryKey;type:char(32)"                          json:"id"`
+	RoomID      string    `gorm:"not null;index:idx_direct_message_room_created,priority:1;type:char(32)" json:"-"`
+	SenderID    string    `gorm:"not null;type:char(32)"                            json:"senderId"`
+	RecipientID string    `gorm:"not null;type:char(32)"                            json:"recipientId"`
+	Content     string    `gorm:"not null;type:text"                                json:"content"`
+}
+
+func (m *DirectMessage) BeforeCreate(_ *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = utils.SortUUID()
+	}
+	return nil
+}
+
+// UserBlock keeps the direct messages of a user from another.
+type UserBlock struct {
+	CreatedAt     time.Time `json:"createdAt"`
+	UserID        string    `gorm:"primaryKey;type:char(32)" json:"-"`
+	BlockedUserID string    `gorm:"primaryKey;type:char(32)" json:"userId"`
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// LocalLibrary is a directory of the server configured by an admin, users browse the
+// index of its media files and never see the root.
+type LocalLibrary struct {
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	ScannedAt time.Time `json:"scannedAt"`
+	ID        string    `gorm:"primaryKey;type:char(32)"   json:"id"`
+	Name      string    `gorm:"not null;type:varchar(64)"  json:"name"`
+	Root      string    `gorm:"not null;type:text"         json:"root"`
+	ScanError string    `gorm:"type:text"                  json:"scanError"`
+	FileCount int64     `json:"fileCount"`
+	// Watch rescans the library when its directories change
+	Watch bool `gorm:"default:false" json:"watch"`
+}
+
+func (l *LocalLibrary) BeforeCreate(_ *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = utils.SortUUID()
+	}
+	return nil
+}
+
+type LocalSubtitle struct {
+	// Path is relative to the library root
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// LocalMediaFile is an indexed directory or media file of a local library.
+type LocalMediaFile struct {
+	ModTime   time.Time
+	ID        string `gorm:"primaryKey;type:char(32)"`
+	LibraryID string `gorm:"not null;index:idx_local_media_file_dir,priority:1;type:char(32)"`
+	// Dir is the parent directory relative to the library root, the root is empty
+	Dir string `gorm:"not null;index:idx_local_media_file_dir,priority:2;type:varchar(512)"`
+	// Path is relative to the library root
+	Path      string           `gorm:"not null;type:varchar(1024)"`
+	Name      string           `gorm:"not null;type:varchar(256)"`
+	Subtitles []*LocalSubtitle `gorm:"serializer:fastjson;type:text"`
+	Size      int64
+	IsDir     bool
+}
+
+func (f *LocalMediaFile) BeforeCreate(_ *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = utils.SortUUID()
+	}
+	return nil
+}
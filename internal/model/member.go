@@ -55,12 +55,16 @@ const (
 	PermissionSetCurrentStatus
 	PermissionSendChatMessage
 	PermissionWebRTC
+	PermissionRequestTrack
+	PermissionSendDirectMessage
 
 	AllPermissions     RoomMemberPermission = math.MaxUint32
 	NoPermission       RoomMemberPermission = 0
 	DefaultPermissions RoomMemberPermission = PermissionGetMovieList |
 		PermissionSendChatMessage |
-		PermissionWebRTC
+		PermissionWebRTC |
+		PermissionRequestTrack |
+		PermissionSendDirectMessage
 )
 
 func (p RoomMemberPermission) Has(permission RoomMemberPermission) bool {
@@ -118,6 +122,10 @@ const (
 	PermissionSetRoomSettings
 	PermissionSetRoomPassword
 	PermissionDeleteRoom
+	PermissionMuteRoomMember
+	// PermissionModerateChat sets the slow mode and the filters of the chat
+	PermissionModerateChat
+	PermissionDeleteChatMessage
 
 	AllAdminPermissions     RoomAdminPermission = math.MaxUint32
 	NoAdminPermission       RoomAdminPermission = 0
@@ -125,7 +133,10 @@ const (
 		PermissionBanRoomMember |
 		PermissionSetUserPermission |
 		PermissionSetRoomSettings |
-		PermissionSetRoomPassword
+		PermissionSetRoomPassword |
+		PermissionMuteRoomMember |
+		PermissionModerateChat |
+		PermissionDeleteChatMessage
 )
 
 func (p RoomAdminPermission) Has(permission RoomAdminPermission) bool {
@@ -149,6 +160,8 @@ type RoomMember struct {
 	AdminPermissions RoomAdminPermission
 	Status           RoomMemberStatus `gorm:"not null;default:2"`
 	Role             RoomMemberRole   `gorm:"not null;default:1"`
+	// MutedUntil is when the member can chat again, nil when not muted
+	MutedUntil *time.Time
 }
 
 var ErrNoPermission = errors.New("no permission")
@@ -166,6 +179,10 @@ func (r *RoomMember) HasPermission(permission RoomMemberPermission) bool {
 	return r.Permissions.Has(permission)
 }
 
+func (r *RoomMember) IsMuted() bool {
+	return r.MutedUntil != nil && time.Now().Before(*r.MutedUntil)
+}
+
 func (r *RoomMember) HasAdminPermission(permission RoomAdminPermission) bool {
 	if r.Role.IsCreator() {
 		return true
@@ -80,6 +80,17 @@ type MovieBase struct {
 	Proxy       bool                 `                                            json:"proxy"`
 	RtmpSource  bool                 `                                            json:"rtmpSource"`
 	IsFolder    bool                 `                                            json:"isFolder"`
+	// LiveTranscode serves the live stream through the transcoding ladder
+	LiveTranscode bool `json:"liveTranscode"`
+	// Duration in seconds, used to schedule the movie in channel mode rooms and to
+	// advance the tracks of music mode rooms
+	Duration float64 `json:"duration,omitempty"`
+	// TvgID is the id of a live channel in its EPG guide
+	TvgID string `json:"tvgId,omitempty"`
+	// EPGURL is the XMLTV guide of a live channel, the movie list shows the programme
+	// now playing and the next one
+	EPGURL string `gorm:"type:text" json:"epgUrl,omitempty"`
+	Logo   string `gorm:"type:text" json:"logo,omitempty"`
 }
 
 func (m *MovieBase) IsM3u8() bool {
@@ -107,18 +118,23 @@ func (m *MovieBase) Clone() *MovieBase {
 		}
 	}
 	return &MovieBase{
-		URL:         m.URL,
-		MoreSources: mss,
-		Name:        m.Name,
-		Live:        m.Live,
-		Proxy:       m.Proxy,
-		RtmpSource:  m.RtmpSource,
-		Type:        m.Type,
-		Headers:     hds,
-		Subtitles:   sbs,
-		VendorInfo:  m.VendorInfo,
-		IsFolder:    m.IsFolder,
-		ParentID:    m.ParentID,
+		URL:           m.URL,
+		MoreSources:   mss,
+		Name:          m.Name,
+		Live:          m.Live,
+		Proxy:         m.Proxy,
+		RtmpSource:    m.RtmpSource,
+		LiveTranscode: m.LiveTranscode,
+		Duration:      m.Duration,
+		TvgID:         m.TvgID,
+		EPGURL:        m.EPGURL,
+		Logo:          m.Logo,
+		Type:          m.Type,
+		Headers:       hds,
+		Subtitles:     sbs,
+		VendorInfo:    m.VendorInfo,
+		IsFolder:      m.IsFolder,
+		ParentID:      m.ParentID,
 	}
 }
 
@@ -168,22 +184,42 @@ const (
 	VendorBilibili VendorName = "bilibili"
 	VendorAlist    VendorName = "alist"
 	VendorEmby     VendorName = "emby"
+	VendorJellyfin VendorName = "jellyfin"
+	VendorPlex     VendorName = "plex"
+	VendorWebDAV   VendorName = "webdav"
+	VendorLocal    VendorName = "local"
+	VendorPlugin   VendorName = "plugin"
+	VendorSubsonic VendorName = "subsonic"
 )
 
 type VendorInfo struct {
 	Bilibili *BilibiliStreamingInfo `gorm:"embedded;embeddedPrefix:bilibili_" json:"bilibili,omitempty"`
 	Alist    *AlistStreamingInfo    `gorm:"embedded;embeddedPrefix:alist_"    json:"alist,omitempty"`
 	Emby     *EmbyStreamingInfo     `gorm:"embedded;embeddedPrefix:emby_"     json:"emby,omitempty"`
+	Jellyfin *JellyfinStreamingInfo `gorm:"embedded;embeddedPrefix:jellyfin_" json:"jellyfin,omitempty"`
+	Plex     *PlexStreamingInfo     `gorm:"embedded;embeddedPrefix:plex_"     json:"plex,omitempty"`
+	WebDAV   *WebDAVStreamingInfo   `gorm:"embedded;embeddedPrefix:webdav_"   json:"webdav,omitempty"`
+	Local    *LocalStreamingInfo    `gorm:"embedded;embeddedPrefix:local_"    json:"local,omitempty"`
+	Plugin   *PluginStreamingInfo   `gorm:"embedded;embeddedPrefix:plugin_"   json:"plugin,omitempty"`
+	Subsonic *SubsonicStreamingInfo `gorm:"embedded;embeddedPrefix:subsonic_" json:"subsonic,omitempty"`
 	Vendor   VendorName             `gorm:"type:varchar(32)"                  json:"vendor"`
 	Backend  string                 `gorm:"type:varchar(64)"                  json:"backend"`
 }
 
+const (
+	DanmuMirrorChat    = "chat"
+	DanmuMirrorDanmaku = "danmaku"
+)
+
 type BilibiliStreamingInfo struct {
 	Bvid    string `json:"bvid,omitempty"`
 	Cid     uint64 `json:"cid,omitempty"`
 	Epid    uint64 `json:"epid,omitempty"`
 	Quality uint64 `json:"quality,omitempty"`
 	Shared  bool   `json:"shared,omitempty"`
+	// DanmuMirror of a live room ingests its danmu on the server and broadcasts it to
+	// the room as chat or danmaku, empty disables it
+	DanmuMirror string `json:"danmuMirror,omitempty"`
 }
 
 func (b *BilibiliStreamingInfo) Validate() error {
@@ -198,6 +234,11 @@ func (b *BilibiliStreamingInfo) Validate() error {
 			return errors.New("cid is empty")
 		}
 	case b.Cid != 0: // live
+		switch b.DanmuMirror {
+		case "", DanmuMirrorChat, DanmuMirrorDanmaku:
+		default:
+			return fmt.Errorf("unknown danmu mirror: %s", b.DanmuMirror)
+		}
 		return nil
 	default:
 		return errors.New("bvid or epid is empty")
@@ -316,3 +357,205 @@ func (e *EmbyStreamingInfo) Validate() error {
 	}
 	return nil
 }
+
+type JellyfinStreamingInfo struct {
+	// {/}serverId/ItemId
+	Path      string `gorm:"type:varchar(72)" json:"path,omitempty"`
+	Transcode bool   `                        json:"transcode,omitempty"`
+}
+
+func GetJellyfinServerIDFromPath(path string) (serverID, itemID string, err error) {
+	return GetEmbyServerIDFromPath(path)
+}
+
+func FormatJellyfinPath(serverID, itemID string) string {
+	return FormatEmbyPath(serverID, itemID)
+}
+
+func (j *JellyfinStreamingInfo) SetServerIDAndFilePath(serverID, filePath string) {
+	j.Path = FormatJellyfinPath(serverID, filePath)
+}
+
+func (j *JellyfinStreamingInfo) ServerID() (string, error) {
+	serverID, _, err := GetJellyfinServerIDFromPath(j.Path)
+	return serverID, err
+}
+
+func (j *JellyfinStreamingInfo) FilePath() (string, error) {
+	_, filePath, err := GetJellyfinServerIDFromPath(j.Path)
+	return filePath, err
+}
+
+func (j *JellyfinStreamingInfo) ServerIDAndFilePath() (serverID, filePath string, err error) {
+	return GetJellyfinServerIDFromPath(j.Path)
+}
+
+func (j *JellyfinStreamingInfo) Validate() error {
+	if j.Path == "" {
+		return errors.New("path is empty")
+	}
+	return nil
+}
+
+type PlexStreamingInfo struct {
+	// {/}serverId/library/metadata/ratingKey, or library/sections/key for a section
+	Path      string `gorm:"type:varchar(128)" json:"path,omitempty"`
+	Transcode bool   `                         json:"transcode,omitempty"`
+}
+
+func GetPlexServerIDFromPath(path string) (serverID, itemPath string, err error) {
+	before, after, found := strings.Cut(strings.TrimLeft(path, "/"), "/")
+	if !found || before == "" {
+		return "", path, errors.New("path is invalid")
+	}
+	return before, after, nil
+}
+
+func FormatPlexPath(serverID, itemPath string) string {
+	return fmt.Sprintf("%s/%s", serverID, strings.Trim(itemPath, "/"))
+}
+
+func (p *PlexStreamingInfo) SetServerIDAndFilePath(serverID, itemPath string) {
+	p.Path = FormatPlexPath(serverID, itemPath)
+}
+
+func (p *PlexStreamingInfo) ServerID() (string, error) {
+	serverID, _, err := GetPlexServerIDFromPath(p.Path)
+	return serverID, err
+}
+
+func (p *PlexStreamingInfo) FilePath() (string, error) {
+	_, itemPath, err := GetPlexServerIDFromPath(p.Path)
+	return itemPath, err
+}
+
+func (p *PlexStreamingInfo) ServerIDAndFilePath() (serverID, itemPath string, err error) {
+	return GetPlexServerIDFromPath(p.Path)
+}
+
+func (p *PlexStreamingInfo) Validate() error {
+	if p.Path == "" {
+		return errors.New("path is empty")
+	}
+	_, _, err := GetPlexServerIDFromPath(p.Path)
+	return err
+}
+
+type WebDAVStreamingInfo struct {
+	// {/}serverId/Path
+	Path string `gorm:"type:text" json:"path,omitempty"`
+}
+
+func GetWebDAVServerIDFromPath(path string) (serverID, filePath string, err error) {
+	return GetAlistServerIDFromPath(path)
+}
+
+func FormatWebDAVPath(serverID, filePath string) string {
+	return FormatAlistPath(serverID, filePath)
+}
+
+func (w *WebDAVStreamingInfo) SetServerIDAndFilePath(serverID, filePath string) {
+	w.Path = FormatWebDAVPath(serverID, filePath)
+}
+
+func (w *WebDAVStreamingInfo) ServerID() (string, error) {
+	serverID, _, err := GetWebDAVServerIDFromPath(w.Path)
+	return serverID, err
+}
+
+func (w *WebDAVStreamingInfo) FilePath() (string, error) {
+	_, filePath, err := GetWebDAVServerIDFromPath(w.Path)
+	return filePath, err
+}
+
+func (w *WebDAVStreamingInfo) ServerIDAndFilePath() (serverID, filePath string, err error) {
+	return GetWebDAVServerIDFromPath(w.Path)
+}
+
+func (w *WebDAVStreamingInfo) Validate() error {
+	if w.Path == "" {
+		return errors.New("path is empty")
+	}
+	_, _, err := GetWebDAVServerIDFromPath(w.Path)
+	return err
+}
+
+type LocalStreamingInfo struct {
+	// {/}libraryId/Path, the path is relative to the library root
+	Path string `gorm:"type:text" json:"path,omitempty"`
+}
+
+func GetLocalLibraryIDFromPath(path string) (libraryID, filePath string, err error) {
+	return GetAlistServerIDFromPath(path)
+}
+
+func FormatLocalPath(libraryID, filePath string) string {
+	return FormatAlistPath(libraryID, filePath)
+}
+
+func (l *LocalStreamingInfo) LibraryIDAndFilePath() (libraryID, filePath string, err error) {
+	return GetLocalLibraryIDFromPath(l.Path)
+}
+
+func (l *LocalStreamingInfo) Validate() error {
+	if l.Path == "" {
+		return errors.New("path is empty")
+	}
+	_, _, err := GetLocalLibraryIDFromPath(l.Path)
+	return err
+}
+
+type PluginStreamingInfo struct {
+	// Name is the name of the vendor plugin
+	Name string `gorm:"type:varchar(32)" json:"name,omitempty"`
+	// {/}serverId/Path, the path is chosen by the plugin
+	Path string `gorm:"type:text" json:"path,omitempty"`
+}
+
+func GetPluginServerIDFromPath(path string) (serverID, filePath string, err error) {
+	return GetAlistServerIDFromPath(path)
+}
+
+func FormatPluginPath(serverID, filePath string) string {
+	return fmt.Sprintf("%s/%s", serverID, filePath)
+}
+
+func (p *PluginStreamingInfo) ServerIDAndFilePath() (serverID, filePath string, err error) {
+	return GetPluginServerIDFromPath(p.Path)
+}
+
+func (p *PluginStreamingInfo) Validate() error {
+	if p.Name == "" {
+		return errors.New("plugin name is empty")
+	}
+	if p.Path == "" {
+		return errors.New("path is empty")
+	}
+	_, _, err := GetPluginServerIDFromPath(p.Path)
+	return err
+}
+
+type SubsonicStreamingInfo struct {
+	// {/}serverId/kind/id, kind is artist, album or song
+	Path string `gorm:"type:text" json:"path,omitempty"`
+}
+
+func GetSubsonicServerIDFromPath(path string) (serverID, itemPath string, err error) {
+	return GetAlistServerIDFromPath(path)
+}
+
+func FormatSubsonicPath(serverID, itemPath string) string {
+	return FormatAlistPath(serverID, itemPath)
+}
+
+func (s *SubsonicStreamingInfo) ServerIDAndItemPath() (serverID, itemPath string, err error) {
+	return GetSubsonicServerIDFromPath(s.Path)
+}
+
+func (s *SubsonicStreamingInfo) Validate() error {
+	if s.Path == "" {
+		return errors.New("path is empty")
+	}
+	_, _, err := GetSubsonicServerIDFromPath(s.Path)
+	return err
+}
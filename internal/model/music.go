@@ -0,0 +1,27 @@
+package model
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+// MusicQueueItem is a track a member requested in a music mode room, the queue is
+// played in request order before the room falls back to its playlist.
+type MusicQueueItem struct {
+	CreatedAt time.Time `gorm:"index:idx_music_queue_room_created,priority:2" json:"createdAt"`
+	ID        string    `gorm:"primaryKey;type:char(32)"                      json:"id"`
+	RoomID    string    `gorm:"not null;index:idx_music_queue_room_created,priority:1;type:char(32)" json:"-"`
+	MovieID   string    `gorm:"not null;type:char(32)"                        json:"movieId"`
+	// SubPath is the track of a dynamic folder, e.g. a song of a subsonic album
+	SubPath string `gorm:"type:text"     json:"subPath,omitempty"`
+	UserID  string `gorm:"type:char(32)" json:"userId"`
+}
+
+func (m *MusicQueueItem) BeforeCreate(_ *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = utils.SortUUID()
+	}
+	return nil
+}
@@ -38,10 +38,15 @@ type Room struct {
 	Name           string        `gorm:"not null;uniqueIndex;type:varchar(32)"`
 	CreatorID      string        `gorm:"index;type:char(32)"`
 	HashedPassword []byte
-	RoomMembers    []*RoomMember `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Movies         []*Movie      `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Status         RoomStatus    `gorm:"not null;default:2"`
-	Current        *Current      `gorm:"serializer:fastjson"`
+	RoomMembers    []*RoomMember     `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Movies         []*Movie          `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ChatMessages   []*ChatMessage    `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ChatFilters    []*ChatFilter     `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	DirectMessages []*DirectMessage  `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	MusicQueue     []*MusicQueueItem `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	WatchParties   []*WatchParty     `gorm:"foreignKey:RoomID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Status         RoomStatus        `gorm:"not null;default:2"`
+	Current        *Current          `gorm:"serializer:fastjson"`
 }
 
 func (r *Room) BeforeCreate(_ *gorm.DB) error {
@@ -89,6 +94,15 @@ type RoomSettings struct {
 	CanSetCurrentMovie     bool                 `gorm:"default:true"             json:"can_set_current_movie"`
 	CanSetCurrentStatus    bool                 `gorm:"default:true"             json:"can_set_current_status"`
 	CanSendChatMessage     bool                 `gorm:"default:true"             json:"can_send_chat_message"`
+	CanSendDirectMessage   bool                 `gorm:"default:true"             json:"can_send_direct_message"`
+	LiveTranscode          bool                 `gorm:"default:false"            json:"live_transcode"`
+	LowLatencyHls          bool                 `gorm:"default:false"            json:"low_latency_hls"`
+	ChannelMode            bool                 `gorm:"default:false"            json:"channel_mode"`
+	// MusicMode plays tracks one after another, members request them into a queue
+	MusicMode bool `gorm:"default:false" json:"music_mode"`
+	// ChatSlowMode is the minimum interval in seconds between two messages of a member,
+	// 0 disables it
+	ChatSlowMode uint32 `gorm:"default:0" json:"chat_slow_mode"`
 }
 
 func DefaultRoomSettings() *RoomSettings {
@@ -100,12 +114,13 @@ func DefaultRoomSettings() *RoomSettings {
 		DisableGuest:           false,
 		GuestPermissions:       NoPermission,
 
-		CanGetMovieList:     true,
-		CanAddMovie:         true,
-		CanDeleteMovie:      true,
-		CanEditMovie:        true,
-		CanSetCurrentMovie:  true,
-		CanSetCurrentStatus: true,
-		CanSendChatMessage:  true,
+		CanGetMovieList:      true,
+		CanAddMovie:          true,
+		CanDeleteMovie:       true,
+		CanEditMovie:         true,
+		CanSetCurrentMovie:   true,
+		CanSetCurrentStatus:  true,
+		CanSendChatMessage:   true,
+		CanSendDirectMessage: true,
 	}
 }
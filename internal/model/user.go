@@ -42,19 +42,26 @@ type User struct {
 	ID                    string `gorm:"primaryKey;type:char(32)"                                           json:"id"`
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
-	Username              string          `gorm:"not null;uniqueIndex;type:varchar(32)"`
-	Email                 EmptyNullString `gorm:"type:varchar(64);uniqueIndex:,where:email IS NOT NULL"`
-	HashedPassword        []byte          `gorm:"not null"`
-	BilibiliVendor        *BilibiliVendor `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Movies                []*Movie        `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
-	UserProviders         []*UserProvider `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	RoomMembers           []*RoomMember   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Rooms                 []*Room         `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	AlistVendor           []*AlistVendor  `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	EmbyVendor            []*EmbyVendor   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Role                  Role            `gorm:"not null;default:2"`
-	RegisteredByProvider  bool            `gorm:"not null;default:false"`
-	RegisteredByEmail     bool            `gorm:"not null;default:false"`
+	Username              string            `gorm:"not null;uniqueIndex;type:varchar(32)"`
+	Email                 EmptyNullString   `gorm:"type:varchar(64);uniqueIndex:,where:email IS NOT NULL"`
+	HashedPassword        []byte            `gorm:"not null"`
+	BilibiliVendor        *BilibiliVendor   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Movies                []*Movie          `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	UserProviders         []*UserProvider   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	RoomMembers           []*RoomMember     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	WatchPartyRSVPs       []*WatchPartyRSVP `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	BlockedUsers          []*UserBlock      `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	BlockedBy             []*UserBlock      `gorm:"foreignKey:BlockedUserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Rooms                 []*Room           `gorm:"foreignKey:CreatorID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	AlistVendor           []*AlistVendor    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	EmbyVendor            []*EmbyVendor     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	JellyfinVendor        []*JellyfinVendor `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	PlexVendor            []*PlexVendor     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	WebDAVVendor          []*WebDAVVendor   `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	SubsonicVendor        []*SubsonicVendor `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Role                  Role              `gorm:"not null;default:2"`
+	RegisteredByProvider  bool              `gorm:"not null;default:false"`
+	RegisteredByEmail     bool              `gorm:"not null;default:false"`
 	autoAddUsernameSuffix bool
 }
 
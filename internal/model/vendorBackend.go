@@ -1,7 +1,10 @@
 package model
 
 import (
+	"crypto/tls"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/PeterChen1997/synctv/utils"
@@ -29,9 +32,28 @@ type Backend struct {
 	Endpoint  string `gorm:"primaryKey;type:varchar(512)"    json:"endpoint"`
 	Comment   string `gorm:"type:text"                       json:"comment"`
 	JwtSecret string `gorm:"type:varchar(256)"               json:"jwtSecret"`
-	CustomCa  string `gorm:"type:text"                       json:"customCa"`
-	TimeOut   string `gorm:"default:10s"                     json:"timeOut"`
-	TLS       bool   `gorm:"default:false"                   json:"tls"`
+	// JwtSecrets are tried in order after JwtSecret when the backend rejects a token,
+	// so a secret can be rotated on either side first
+	JwtSecrets []string `gorm:"serializer:fastjson;type:text" json:"jwtSecrets"`
+	CustomCa   string   `gorm:"type:text"                     json:"customCa"`
+	// ClientCert and ClientKey are the pem of the certificate presented to backends
+	// requiring mutual tls
+	ClientCert string `gorm:"type:text"     json:"clientCert"`
+	ClientKey  string `gorm:"type:text"     json:"clientKey"`
+	TimeOut    string `gorm:"default:10s"   json:"timeOut"`
+	TLS        bool   `gorm:"default:false" json:"tls"`
+}
+
+// AllJwtSecrets returns JwtSecret and then JwtSecrets without the empty and duplicated
+// ones.
+func (b *Backend) AllJwtSecrets() []string {
+	secrets := make([]string, 0, len(b.JwtSecrets)+1)
+	for _, s := range append([]string{b.JwtSecret}, b.JwtSecrets...) {
+		if s != "" && !slices.Contains(secrets, s) {
+			secrets = append(secrets, s)
+		}
+	}
+	return secrets
 }
 
 func (b *Backend) Validate() error {
@@ -46,6 +68,14 @@ func (b *Backend) Validate() error {
 			return err
 		}
 	}
+	if b.ClientCert != "" || b.ClientKey != "" {
+		if !b.TLS {
+			return errors.New("client certificate needs tls")
+		}
+		if _, err := tls.X509KeyPair([]byte(b.ClientCert), []byte(b.ClientKey)); err != nil {
+			return fmt.Errorf("invalid client certificate: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -60,10 +90,12 @@ type BackendUsedBy struct {
 	BilibiliBackendName string `gorm:"type:varchar(64)" json:"bilibiliBackendName"`
 	AlistBackendName    string `gorm:"type:varchar(64)" json:"alistBackendName"`
 	EmbyBackendName     string `gorm:"type:varchar(64)" json:"embyBackendName"`
+	JellyfinBackendName string `gorm:"type:varchar(64)" json:"jellyfinBackendName"`
 	Enabled             bool   `gorm:"default:false"    json:"enabled"`
 	Bilibili            bool   `gorm:"default:false"    json:"bilibili"`
 	Alist               bool   `gorm:"default:false"    json:"alist"`
 	Emby                bool   `gorm:"default:false"    json:"emby"`
+	Jellyfin            bool   `gorm:"default:false"    json:"jellyfin"`
 }
 
 func (v *VendorBackend) BeforeSave(_ *gorm.DB) error {
@@ -89,6 +121,25 @@ func (v *VendorBackend) BeforeSave(_ *gorm.DB) error {
 			return err
 		}
 	}
+	if v.Backend.ClientCert != "" {
+		if v.Backend.ClientCert, err = utils.CryptoToBase64([]byte(v.Backend.ClientCert), key); err != nil {
+			return err
+		}
+	}
+	if v.Backend.ClientKey != "" {
+		if v.Backend.ClientKey, err = utils.CryptoToBase64([]byte(v.Backend.ClientKey), key); err != nil {
+			return err
+		}
+	}
+	if len(v.Backend.JwtSecrets) != 0 {
+		secrets := make([]string, len(v.Backend.JwtSecrets))
+		for i, s := range v.Backend.JwtSecrets {
+			if secrets[i], err = utils.CryptoToBase64([]byte(s), key); err != nil {
+				return err
+			}
+		}
+		v.Backend.JwtSecrets = secrets
+	}
 	return nil
 }
 
@@ -122,6 +173,31 @@ func (v *VendorBackend) AfterSave(_ *gorm.DB) error {
 		}
 		v.Backend.CustomCa = stream.BytesToString(customCa)
 	}
+	if v.Backend.ClientCert != "" {
+		clientCert, err := utils.DecryptoFromBase64(v.Backend.ClientCert, key)
+		if err != nil {
+			return err
+		}
+		v.Backend.ClientCert = stream.BytesToString(clientCert)
+	}
+	if v.Backend.ClientKey != "" {
+		clientKey, err := utils.DecryptoFromBase64(v.Backend.ClientKey, key)
+		if err != nil {
+			return err
+		}
+		v.Backend.ClientKey = stream.BytesToString(clientKey)
+	}
+	if len(v.Backend.JwtSecrets) != 0 {
+		secrets := make([]string, len(v.Backend.JwtSecrets))
+		for i, s := range v.Backend.JwtSecrets {
+			secret, err := utils.DecryptoFromBase64(s, key)
+			if err != nil {
+				return err
+			}
+			secrets[i] = stream.BytesToString(secret)
+		}
+		v.Backend.JwtSecrets = secrets
+	}
 	return nil
 }
 
@@ -9,12 +9,25 @@ import (
 	"gorm.io/gorm"
 )
 
+// BindingStatus is the result of the periodic validation of the credentials of a
+// vendor binding, saving the binding again after a re-login resets it.
+type BindingStatus struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Error     string    `gorm:"type:text" json:"error,omitempty"`
+	// Fails is the number of consecutive failed checks
+	Fails   int  `json:"fails"`
+	Expired bool `json:"expired"`
+	// Notified is set once the user was asked to re-login
+	Notified bool `json:"-"`
+}
+
 type BilibiliVendor struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Cookies   map[string]string `gorm:"not null;serializer:fastjson;type:text"`
 	UserID    string            `gorm:"primaryKey;type:char(32)"`
 	Backend   string            `gorm:"type:varchar(64)"`
+	Status    BindingStatus     `gorm:"embedded;embeddedPrefix:status_"`
 }
 
 func (b *BilibiliVendor) BeforeSave(_ *gorm.DB) error {
@@ -54,6 +67,7 @@ type AlistVendor struct {
 	Host           string `gorm:"not null;type:varchar(256)"`
 	Username       string `gorm:"type:varchar(256)"`
 	HashedPassword []byte
+	Status         BindingStatus `gorm:"embedded;embeddedPrefix:status_"`
 }
 
 func GenAlistServerID(a *AlistVendor) {
@@ -110,6 +124,9 @@ type EmbyVendor struct {
 	Host       string `gorm:"not null;type:varchar(256)"`
 	APIKey     string `gorm:"not null;type:varchar(256)"`
 	EmbyUserID string `gorm:"type:varchar(32)"`
+	// ReportPlayback reports the playback of rooms the user watches to this server
+	ReportPlayback bool          `gorm:"default:false"`
+	Status         BindingStatus `gorm:"embedded;embeddedPrefix:status_"`
 }
 
 func (e *EmbyVendor) BeforeSave(_ *gorm.DB) error {
@@ -142,3 +159,238 @@ func (e *EmbyVendor) AfterSave(_ *gorm.DB) error {
 func (e *EmbyVendor) AfterFind(tx *gorm.DB) error {
 	return e.AfterSave(tx)
 }
+
+type JellyfinVendor struct {
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	UserID         string `gorm:"primaryKey;type:char(32)"`
+	Backend        string `gorm:"type:varchar(64)"`
+	ServerID       string `gorm:"primaryKey;type:char(32)"`
+	Host           string `gorm:"not null;type:varchar(256)"`
+	APIKey         string `gorm:"not null;type:varchar(256)"`
+	JellyfinUserID string `gorm:"type:varchar(32)"`
+}
+
+func (j *JellyfinVendor) BeforeSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(j.ServerID)
+	var err error
+	if j.Host, err = utils.CryptoToBase64(stream.StringToBytes(j.Host), key); err != nil {
+		return err
+	}
+	if j.APIKey, err = utils.CryptoToBase64(stream.StringToBytes(j.APIKey), key); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (j *JellyfinVendor) AfterSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(j.ServerID)
+	host, err := utils.DecryptoFromBase64(j.Host, key)
+	if err != nil {
+		return err
+	}
+	j.Host = stream.BytesToString(host)
+	apiKey, err := utils.DecryptoFromBase64(j.APIKey, key)
+	if err != nil {
+		return err
+	}
+	j.APIKey = stream.BytesToString(apiKey)
+	return nil
+}
+
+func (j *JellyfinVendor) AfterFind(tx *gorm.DB) error {
+	return j.AfterSave(tx)
+}
+
+type PlexVendor struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    string `gorm:"primaryKey;type:char(32)"`
+	// ServerID is the machine identifier of the plex server
+	ServerID string `gorm:"primaryKey;type:varchar(64)"`
+	Host     string `gorm:"not null;type:varchar(256)"`
+	Token    string `gorm:"not null;type:varchar(256)"`
+}
+
+func (p *PlexVendor) BeforeSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(p.ServerID)
+	var err error
+	if p.Host, err = utils.CryptoToBase64(stream.StringToBytes(p.Host), key); err != nil {
+		return err
+	}
+	if p.Token, err = utils.CryptoToBase64(stream.StringToBytes(p.Token), key); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *PlexVendor) AfterSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(p.ServerID)
+	host, err := utils.DecryptoFromBase64(p.Host, key)
+	if err != nil {
+		return err
+	}
+	p.Host = stream.BytesToString(host)
+	token, err := utils.DecryptoFromBase64(p.Token, key)
+	if err != nil {
+		return err
+	}
+	p.Token = stream.BytesToString(token)
+	return nil
+}
+
+func (p *PlexVendor) AfterFind(tx *gorm.DB) error {
+	return p.AfterSave(tx)
+}
+
+type WebDAVVendor struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    string `gorm:"primaryKey;type:char(32)"`
+	ServerID  string `gorm:"primaryKey;type:char(32)"`
+	Host      string `gorm:"not null;type:varchar(512)"`
+	Username  string `gorm:"type:varchar(256)"`
+	// Password is needed for every request, so it is stored encrypted instead of hashed
+	Password string `gorm:"type:varchar(512)"`
+}
+
+func GenWebDAVServerID(w *WebDAVVendor) {
+	if w.ServerID == "" {
+		w.ServerID = utils.SortUUIDWithUUID(
+			uuid.NewMD5(uuid.NameSpaceURL, []byte(w.Username+"@"+w.Host)),
+		)
+	}
+}
+
+func (w *WebDAVVendor) BeforeSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(w.UserID)
+	var err error
+	if w.Host, err = utils.CryptoToBase64(stream.StringToBytes(w.Host), key); err != nil {
+		return err
+	}
+	if w.Username, err = utils.CryptoToBase64(stream.StringToBytes(w.Username), key); err != nil {
+		return err
+	}
+	if w.Password, err = utils.CryptoToBase64(stream.StringToBytes(w.Password), key); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WebDAVVendor) AfterSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(w.UserID)
+	host, err := utils.DecryptoFromBase64(w.Host, key)
+	if err != nil {
+		return err
+	}
+	w.Host = stream.BytesToString(host)
+	username, err := utils.DecryptoFromBase64(w.Username, key)
+	if err != nil {
+		return err
+	}
+	w.Username = stream.BytesToString(username)
+	password, err := utils.DecryptoFromBase64(w.Password, key)
+	if err != nil {
+		return err
+	}
+	w.Password = stream.BytesToString(password)
+	return nil
+}
+
+func (w *WebDAVVendor) AfterFind(tx *gorm.DB) error {
+	return w.AfterSave(tx)
+}
+
+type SubsonicVendor struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	UserID    string `gorm:"primaryKey;type:char(32)"`
+	ServerID  string `gorm:"primaryKey;type:char(32)"`
+	Host      string `gorm:"not null;type:varchar(512)"`
+	Username  string `gorm:"not null;type:varchar(256)"`
+	// Password is needed to sign every request, so it is stored encrypted instead of hashed
+	Password string `gorm:"type:varchar(512)"`
+}
+
+func GenSubsonicServerID(s *SubsonicVendor) {
+	if s.ServerID == "" {
+		s.ServerID = utils.SortUUIDWithUUID(
+			uuid.NewMD5(uuid.NameSpaceURL, []byte(s.Username+"@"+s.Host)),
+		)
+	}
+}
+
+func (s *SubsonicVendor) BeforeSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(s.UserID)
+	var err error
+	if s.Host, err = utils.CryptoToBase64(stream.StringToBytes(s.Host), key); err != nil {
+		return err
+	}
+	if s.Username, err = utils.CryptoToBase64(stream.StringToBytes(s.Username), key); err != nil {
+		return err
+	}
+	if s.Password, err = utils.CryptoToBase64(stream.StringToBytes(s.Password), key); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *SubsonicVendor) AfterSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(s.UserID)
+	host, err := utils.DecryptoFromBase64(s.Host, key)
+	if err != nil {
+		return err
+	}
+	s.Host = stream.BytesToString(host)
+	username, err := utils.DecryptoFromBase64(s.Username, key)
+	if err != nil {
+		return err
+	}
+	s.Username = stream.BytesToString(username)
+	password, err := utils.DecryptoFromBase64(s.Password, key)
+	if err != nil {
+		return err
+	}
+	s.Password = stream.BytesToString(password)
+	return nil
+}
+
+func (s *SubsonicVendor) AfterFind(tx *gorm.DB) error {
+	return s.AfterSave(tx)
+}
+
+// PluginVendor is an account of a vendor plugin, ServerID is chosen by the plugin.
+type PluginVendor struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpireAt  time.Time
+	UserID    string `gorm:"primaryKey;type:char(32)"`
+	Plugin    string `gorm:"primaryKey;type:varchar(32)"`
+	ServerID  string `gorm:"primaryKey;type:varchar(64)"`
+	Name      string `gorm:"type:varchar(256)"`
+	// Credential is opaque to synctv and passed back to the plugin
+	Credential string `gorm:"type:text"`
+}
+
+func (p *PluginVendor) BeforeSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(p.UserID)
+	var err error
+	if p.Credential, err = utils.CryptoToBase64(stream.StringToBytes(p.Credential), key); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *PluginVendor) AfterSave(_ *gorm.DB) error {
+	key := utils.GenCryptoKey(p.UserID)
+	credential, err := utils.DecryptoFromBase64(p.Credential, key)
+	if err != nil {
+		return err
+	}
+	p.Credential = stream.BytesToString(credential)
+	return nil
+}
+
+func (p *PluginVendor) AfterFind(tx *gorm.DB) error {
+	return p.AfterSave(tx)
+}
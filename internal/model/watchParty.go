@@ -0,0 +1,90 @@
+package model
+
+import (
+	"time"
+
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+type WatchPartyStatus uint8
+
+const (
+	WatchPartyStatusScheduled WatchPartyStatus = 1
+	WatchPartyStatusStarted   WatchPartyStatus = 2
+	// WatchPartyStatusMissed is a party that could not start, the server was down at
+	// its start or its movie was deleted
+	WatchPartyStatusMissed WatchPartyStatus = 3
+)
+
+func (s WatchPartyStatus) String() string {
+	switch s {
+	case WatchPartyStatusScheduled:
+		return "scheduled"
+	case WatchPartyStatusStarted:
+		return "started"
+	case WatchPartyStatusMissed:
+		return "missed"
+	default:
+		return "unknown"
+	}
+}
+
+// WatchParty is a showing of a movie scheduled in a room, the movie becomes the current
+// movie and starts playing at StartAt.
+type WatchParty struct {
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	StartAt     time.Time         `gorm:"not null;index"`
+	ID          string            `gorm:"primaryKey;type:char(32)"`
+	RoomID      string            `gorm:"not null;index;type:char(32)"`
+	CreatorID   string            `gorm:"type:char(32)"`
+	MovieID     string            `gorm:"not null;type:char(32)"`
+	Description string            `gorm:"type:text"`
+	RSVPs       []*WatchPartyRSVP `gorm:"foreignKey:PartyID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Status      WatchPartyStatus  `gorm:"not null;default:1;index"`
+	// Reminded is set once the reminder emails went out
+	Reminded bool `gorm:"not null;default:false"`
+}
+
+func (p *WatchParty) BeforeCreate(_ *gorm.DB) error {
+	if p.ID == "" {
+		p.ID = utils.SortUUID()
+	}
+	return nil
+}
+
+type RSVPResponse uint8
+
+const (
+	RSVPGoing    RSVPResponse = 1
+	RSVPMaybe    RSVPResponse = 2
+	RSVPNotGoing RSVPResponse = 3
+)
+
+func (r RSVPResponse) String() string {
+	switch r {
+	case RSVPGoing:
+		return "going"
+	case RSVPMaybe:
+		return "maybe"
+	case RSVPNotGoing:
+		return "not_going"
+	default:
+		return "unknown"
+	}
+}
+
+func (r RSVPResponse) Valid() bool {
+	return r >= RSVPGoing && r <= RSVPNotGoing
+}
+
+// WatchPartyRSVP is the answer of a member to a watch party, the members going or
+// maybe going are reminded before the start.
+type WatchPartyRSVP struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	PartyID   string       `gorm:"primaryKey;type:char(32)"`
+	UserID    string       `gorm:"primaryKey;type:char(32)"`
+	Response  RSVPResponse `gorm:"not null"`
+}
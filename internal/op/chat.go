@@ -0,0 +1,169 @@
+package op
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/settings"
+)
+
+const (
+	// messages waiting to be written, a full buffer drops messages instead of slowing
+	// down the chat
+	chatRecordBuffer         = 1024
+	chatRecordBatchSize      = 100
+	chatRecordFlushInterval  = time.Second
+	chatHistoryCleanInterval = time.Hour
+)
+
+type chatRecordKind uint8
+
+const (
+	chatRecordCreate chatRecordKind = iota
+	chatRecordUpdate
+	chatRecordDelete
+	chatRecordDirect
+)
+
+// chatRecord is a message to write, update or remove from the history, all of them go
+// through the same queue so a change follows the write of its message. dm is set
+// instead of msg for the direct messages.
+type chatRecord struct {
+	msg  *model.ChatMessage
+	dm   *model.DirectMessage
+	kind chatRecordKind
+}
+
+var chatRecords = make(chan chatRecord, chatRecordBuffer)
+
+// RecordChatMessage queues a message for the chat history, it is a no-op when the
+// history is disabled.
+func RecordChatMessage(msg *model.ChatMessage) {
+	if msg.CreatedAt.IsZero() {
+		msg.CreatedAt = time.Now()
+	}
+	queueChatRecord(msg, chatRecordCreate)
+}
+
+// RecordChatMessageUpdated queues the new content, edit time and reactions of a message.
+func RecordChatMessageUpdated(msg *model.ChatMessage) {
+	queueChatRecord(msg, chatRecordUpdate)
+}
+
+// RecordChatMessageDeleted queues the removal of a message from the chat history.
+func RecordChatMessageDeleted(roomID, id string) {
+	queueChatRecord(&model.ChatMessage{ID: id, RoomID: roomID}, chatRecordDelete)
+}
+
+// RecordDirectMessage queues a direct message for the history, it is a no-op unless
+// the direct messages are kept.
+func RecordDirectMessage(dm *model.DirectMessage) {
+	if !directMessageHistoryEnabled() {
+		return
+	}
+	select {
+	case chatRecords <- chatRecord{dm: dm, kind: chatRecordDirect}:
+	default:
+		log.Warnf("chat history buffer is full, direct message of room %s dropped", dm.RoomID)
+	}
+}
+
+func directMessageHistoryEnabled() bool {
+	return settings.ChatHistoryRetention.Get() != 0 && settings.DirectMessageHistory.Get()
+}
+
+func queueChatRecord(msg *model.ChatMessage, kind chatRecordKind) {
+	if settings.ChatHistoryRetention.Get() == 0 {
+		return
+	}
+	select {
+	case chatRecords <- chatRecord{msg: msg, kind: kind}:
+	default:
+		log.Warnf("chat history buffer is full, message %s of room %s dropped", msg.ID, msg.RoomID)
+	}
+}
+
+// RecordChatHistory writes the queued messages in batches until ctx is done and
+// removes the messages older than the retention.
+func RecordChatHistory(ctx context.Context) {
+	flush := time.NewTicker(chatRecordFlushInterval)
+	defer flush.Stop()
+	clean := time.NewTicker(chatHistoryCleanInterval)
+	defer clean.Stop()
+
+	batch := make([]*model.ChatMessage, 0, chatRecordBatchSize)
+	write := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := db.CreateChatMessages(batch); err != nil {
+			log.Errorf("write chat history error: %v", err)
+		}
+		batch = make([]*model.ChatMessage, 0, chatRecordBatchSize)
+	}
+
+	cleanChatHistory()
+	for {
+		select {
+		case <-ctx.Done():
+			write()
+			return
+		case record := <-chatRecords:
+			switch record.kind {
+			case chatRecordCreate:
+				batch = append(batch, record.msg)
+				if len(batch) >= chatRecordBatchSize {
+					write()
+				}
+			case chatRecordUpdate:
+				write()
+				if err := db.UpdateChatMessage(record.msg); err != nil {
+					log.Errorf("update chat message %s error: %v", record.msg.ID, err)
+				}
+			case chatRecordDelete:
+				write()
+				if err := db.DeleteChatMessage(record.msg.RoomID, record.msg.ID); err != nil {
+					log.Errorf("delete chat message %s error: %v", record.msg.ID, err)
+				}
+			case chatRecordDirect:
+				if err := db.CreateDirectMessage(record.dm); err != nil {
+					log.Errorf("write direct message error: %v", err)
+				}
+			}
+		case <-flush.C:
+			write()
+		case <-clean.C:
+			cleanChatHistory()
+		}
+	}
+}
+
+// cleanChatHistory removes the messages older than the retention, all of them when the
+// history was disabled.
+func cleanChatHistory() {
+	days := settings.ChatHistoryRetention.Get()
+	before := time.Now().AddDate(0, 0, -int(days))
+	n, err := db.DeleteChatMessagesBefore(before)
+	if err != nil {
+		log.Errorf("clean chat history error: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Infof("cleaned %d expired chat messages", n)
+	}
+	if !settings.DirectMessageHistory.Get() {
+		// the direct messages kept before it was disabled
+		before = time.Now()
+	}
+	n, err = db.DeleteDirectMessagesBefore(before)
+	if err != nil {
+		log.Errorf("clean direct message history error: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Infof("cleaned %d expired direct messages", n)
+	}
+}
@@ -0,0 +1,370 @@
+package op
+
+import (
+	"errors"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/settings"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// ChatEditWindow is how long after sending a message its sender can edit it
+	ChatEditWindow = 15 * time.Minute
+	// the last messages of each room are kept for the replies, reactions and edits
+	chatRecentSize      = 256
+	maxChatMentions     = 10
+	maxChatReactions    = 20
+	maxChatReactionSize = 32
+)
+
+var (
+	ErrChatMessageNotFound = errors.New("chat message not found")
+	ErrChatEditExpired     = errors.New("the message can no longer be edited")
+	ErrInvalidReaction     = errors.New("a reaction must be an emoji")
+
+	chatMentionReg = regexp.MustCompile(`@(\S+)`)
+)
+
+type ChatMessageConfig func(m *model.ChatMessage)
+
+func WithChatReplyTo(id string) ChatMessageConfig {
+	return func(m *model.ChatMessage) {
+		m.ReplyTo = id
+	}
+}
+
+// WithChatMentions sets the members mentioned, see Room.ChatMentions.
+func WithChatMentions(userIDs []string) ChatMessageConfig {
+	return func(m *model.ChatMessage) {
+		m.Mentions = userIDs
+	}
+}
+
+// ChatMentions returns the ids of the members mentioned as @username in a message, only
+// the first mentions are looked up and the names are resolved in one query.
+func (r *Room) ChatMentions(message string) []string {
+	matches := chatMentionReg.FindAllStringSubmatch(message, maxChatMentions)
+	if len(matches) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(matches)*2)
+	for _, match := range matches {
+		// the mention may be followed by a punctuation
+		for _, name := range []string{match[1], strings.TrimRightFunc(match[1], unicode.IsPunct)} {
+			if name != "" && !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	users, err := db.GetUsers(db.WhereUsernameIn(names))
+	if err != nil {
+		log.Errorf("get mentioned users error: %v", err)
+		return nil
+	}
+	byName := make(map[string]string, len(users))
+	for _, u := range users {
+		byName[u.Username] = u.ID
+	}
+	var ids []string
+	for _, match := range matches {
+		id, ok := byName[match[1]]
+		if !ok {
+			id, ok = byName[strings.TrimRightFunc(match[1], unicode.IsPunct)]
+			if !ok {
+				continue
+			}
+		}
+		if slices.Contains(ids, id) || r.IsGuest(id) {
+			continue
+		}
+		if member, err := r.LoadMember(id); err != nil || member.Status.IsNotActive() {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// rememberChatMessage keeps a message among the recent ones of the room, the caller
+// holds chatLock.
+func (r *Room) rememberChatMessage(m *model.ChatMessage) {
+	if r.chatRecent == nil {
+		r.chatRecent = make(map[string]*model.ChatMessage, chatRecentSize)
+	}
+	if _, ok := r.chatRecent[m.ID]; ok {
+		return
+	}
+	if len(r.chatRecentIDs) >= chatRecentSize {
+		delete(r.chatRecent, r.chatRecentIDs[0])
+		r.chatRecentIDs = r.chatRecentIDs[1:]
+	}
+	r.chatRecent[m.ID] = m
+	r.chatRecentIDs = append(r.chatRecentIDs, m.ID)
+}
+
+func (r *Room) forgetChatMessage(id string) {
+	r.chatLock.Lock()
+	defer r.chatLock.Unlock()
+	if _, ok := r.chatRecent[id]; !ok {
+		return
+	}
+	delete(r.chatRecent, id)
+	r.chatRecentIDs = slices.DeleteFunc(r.chatRecentIDs, func(s string) bool {
+		return s == id
+	})
+}
+
+// loadChatMessage returns a recent message of the room, or an older one from the
+// history, the caller holds chatLock.
+func (r *Room) loadChatMessage(id string) (*model.ChatMessage, error) {
+	if m, ok := r.chatRecent[id]; ok {
+		return m, nil
+	}
+	if settings.ChatHistoryRetention.Get() == 0 {
+		return nil, ErrChatMessageNotFound
+	}
+	m, err := db.GetChatMessage(r.ID, id)
+	if err != nil {
+		return nil, ErrChatMessageNotFound
+	}
+	r.rememberChatMessage(m)
+	return m, nil
+}
+
+func (r *Room) hasChatMessage(id string) bool {
+	r.chatLock.Lock()
+	defer r.chatLock.Unlock()
+	_, err := r.loadChatMessage(id)
+	return err == nil
+}
+
+// chatMeta returns the meta of a message for the clients, nil when it has none.
+func chatMeta(m *model.ChatMessage) *pb.ChatMeta {
+	if m.ReplyTo == "" && len(m.Mentions) == 0 && m.EditedAt == nil && len(m.Reactions) == 0 {
+		return nil
+	}
+	meta := &pb.ChatMeta{
+		ReplyTo:  m.ReplyTo,
+		Mentions: make([]*pb.Mention, len(m.Mentions)),
+	}
+	for i, id := range m.Mentions {
+		meta.Mentions[i] = &pb.Mention{
+			UserId:   id,
+			Username: GetUserName(id),
+		}
+	}
+	if m.EditedAt != nil {
+		meta.EditedAt = m.EditedAt.UnixMilli()
+	}
+	for _, emoji := range slices.Sorted(maps.Keys(m.Reactions)) {
+		meta.Reactions = append(meta.Reactions, &pb.Reaction{
+			Emoji:   emoji,
+			Count:   int64(len(m.Reactions[emoji])),
+			UserIds: slices.Clone(m.Reactions[emoji]),
+		})
+	}
+	return meta
+}
+
+// cloneChatMessage copies a message for the history writer, the recent messages keep
+// changing.
+func cloneChatMessage(m *model.ChatMessage) *model.ChatMessage {
+	c := *m
+	c.Mentions = slices.Clone(m.Mentions)
+	if m.Reactions != nil {
+		c.Reactions = make(map[string][]string, len(m.Reactions))
+		for emoji, ids := range m.Reactions {
+			c.Reactions[emoji] = slices.Clone(ids)
+		}
+	}
+	return &c
+}
+
+// notifyChatMention tells the mentioned users through their connections in every room,
+// so a mention reaches them while they watch another room.
+func notifyChatMention(roomID string, userIDs []string, msg *pb.Message) {
+	if len(userIDs) == 0 {
+		return
+	}
+	mention := &pb.Message{
+		Type:      pb.MessageType_MENTION,
+		Id:        msg.GetId(),
+		Timestamp: msg.GetTimestamp(),
+		Sender:    msg.GetSender(),
+		// the meta is shared with the broadcast of the message
+		ChatMeta: proto.CloneOf(msg.GetChatMeta()),
+		Payload: &pb.Message_ChatContent{
+			ChatContent: msg.GetChatContent(),
+		},
+	}
+	if mention.ChatMeta == nil {
+		mention.ChatMeta = &pb.ChatMeta{}
+	}
+	mention.ChatMeta.RoomId = roomID
+	RangeRoomCache(func(_ string, e *RoomEntry) bool {
+		r := e.Value()
+		if r.HubIsNotInited() {
+			return true
+		}
+		for _, id := range userIDs {
+			if r.UserOnlineCount(id) == 0 {
+				continue
+			}
+			if err := r.SendToUserWithID(id, mention); err != nil {
+				log.Debugf("send mention to user %s error: %v", id, err)
+			}
+		}
+		return true
+	})
+}
+
+// CheckCanChat fails when the user is not allowed to chat or is muted.
+func (c *Client) CheckCanChat() error {
+	if !c.u.HasRoomPermission(c.r, model.PermissionSendChatMessage) {
+		return model.ErrNoPermission
+	}
+	return c.r.checkChatMuted(c.u.ID)
+}
+
+// EditChatMessage replaces the content of a message of the user sent within the edit
+// window, mentions are the members mentioned in the new content.
+func (c *Client) EditChatMessage(id, message string, mentions []string) error {
+	if err := c.CheckCanChat(); err != nil {
+		return err
+	}
+	c.r.chatLock.Lock()
+	m, err := c.r.loadChatMessage(id)
+	if err != nil {
+		c.r.chatLock.Unlock()
+		return err
+	}
+	if m.UserID != c.u.ID || m.Source != "" {
+		c.r.chatLock.Unlock()
+		return model.ErrNoPermission
+	}
+	now := time.Now()
+	if now.Sub(m.CreatedAt) > ChatEditWindow {
+		c.r.chatLock.Unlock()
+		return ErrChatEditExpired
+	}
+	var added []string
+	for _, userID := range mentions {
+		if userID != c.u.ID && !slices.Contains(m.Mentions, userID) {
+			added = append(added, userID)
+		}
+	}
+	m.Content = message
+	m.Mentions = mentions
+	m.EditedAt = &now
+	msg := &pb.Message{
+		Type:      pb.MessageType_CHAT_EDIT,
+		Id:        m.ID,
+		Timestamp: now.UnixMilli(),
+		Sender: &pb.Sender{
+			UserId:   m.UserID,
+			Username: m.Username,
+		},
+		ChatMeta: chatMeta(m),
+		Payload: &pb.Message_ChatContent{
+			ChatContent: message,
+		},
+	}
+	record := cloneChatMessage(m)
+	c.r.chatLock.Unlock()
+
+	if err := c.Broadcast(msg); err != nil {
+		return err
+	}
+	RecordChatMessageUpdated(record)
+	notifyChatMention(c.r.ID, added, msg)
+	return nil
+}
+
+func validReaction(emoji string) bool {
+	if emoji == "" || len(emoji) > maxChatReactionSize || !utf8.ValidString(emoji) {
+		return false
+	}
+	for _, r := range emoji {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) ||
+			unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ReactChatMessage adds or removes a reaction of the user to a message.
+func (c *Client) ReactChatMessage(id, emoji string, add bool) error {
+	if err := c.CheckCanChat(); err != nil {
+		return err
+	}
+	if !validReaction(emoji) {
+		return ErrInvalidReaction
+	}
+	c.r.chatLock.Lock()
+	m, err := c.r.loadChatMessage(id)
+	if err != nil {
+		c.r.chatLock.Unlock()
+		return err
+	}
+	users := m.Reactions[emoji]
+	if add == slices.Contains(users, c.u.ID) {
+		c.r.chatLock.Unlock()
+		return nil
+	}
+	if add {
+		if len(users) == 0 && len(m.Reactions) >= maxChatReactions {
+			c.r.chatLock.Unlock()
+			return errors.New("too many different reactions")
+		}
+		if m.Reactions == nil {
+			m.Reactions = make(map[string][]string)
+		}
+		m.Reactions[emoji] = append(users, c.u.ID)
+	} else {
+		users = slices.DeleteFunc(slices.Clone(users), func(s string) bool {
+			return s == c.u.ID
+		})
+		if len(users) == 0 {
+			delete(m.Reactions, emoji)
+		} else {
+			m.Reactions[emoji] = users
+		}
+	}
+	count := int64(len(m.Reactions[emoji]))
+	record := cloneChatMessage(m)
+	c.r.chatLock.Unlock()
+
+	err = c.Broadcast(&pb.Message{
+		Type:      pb.MessageType_CHAT_REACTION,
+		Timestamp: time.Now().UnixMilli(),
+		Sender: &pb.Sender{
+			UserId:   c.u.ID,
+			Username: c.u.Username,
+		},
+		Payload: &pb.Message_ChatReaction{
+			ChatReaction: &pb.ChatReaction{
+				MessageId: id,
+				Emoji:     emoji,
+				Add:       add,
+				Count:     count,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	RecordChatMessageUpdated(record)
+	return nil
+}
@@ -0,0 +1,280 @@
+package op
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+)
+
+const (
+	MaxChatSlowMode    = 3600
+	MaxChatMuteSeconds = 30 * 24 * 60 * 60
+	maxChatFilters     = 200
+	// the last send times are pruned once this many members chatted
+	chatLastSentPrune = 1024
+)
+
+var (
+	ErrChatMuted          = errors.New("you are muted in this room")
+	ErrChatSlowMode       = errors.New("slow mode is enabled")
+	ErrChatMessageBlocked = errors.New("message blocked by the room filter")
+)
+
+type chatFilter struct {
+	re *regexp.Regexp
+	model.ChatFilter
+}
+
+func compileChatFilter(f *model.ChatFilter) (*chatFilter, error) {
+	expr := f.Pattern
+	if !f.Regex {
+		expr = "(?i)" + regexp.QuoteMeta(f.Pattern)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter pattern: %w", err)
+	}
+	return &chatFilter{re: re, ChatFilter: *f}, nil
+}
+
+func (f *chatFilter) replace(s string) string {
+	if f.Replacement != "" {
+		return f.re.ReplaceAllLiteralString(s, f.Replacement)
+	}
+	return f.re.ReplaceAllStringFunc(s, func(m string) string {
+		return strings.Repeat("*", utf8.RuneCountInString(m))
+	})
+}
+
+// loadChatFilters returns the compiled filters of the room, they are loaded once and
+// reset when they change.
+func (r *Room) loadChatFilters() ([]*chatFilter, error) {
+	if fs := r.chatFilters.Load(); fs != nil {
+		return *fs, nil
+	}
+	filters, err := db.GetChatFilters(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	fs := make([]*chatFilter, 0, len(filters))
+	for _, f := range filters {
+		cf, err := compileChatFilter(f)
+		if err != nil {
+			log.Warnf("skip chat filter %s of room %s: %v", f.ID, r.ID, err)
+			continue
+		}
+		fs = append(fs, cf)
+	}
+	r.chatFilters.Store(&fs)
+	return fs, nil
+}
+
+func (r *Room) ChatFilters() ([]*model.ChatFilter, error) {
+	return db.GetChatFilters(r.ID)
+}
+
+// FilterChatMessage applies the filters of the room to a message, the admins are not
+// filtered.
+func (r *Room) FilterChatMessage(userID, message string) (string, error) {
+	if r.IsAdmin(userID) {
+		return message, nil
+	}
+	fs, err := r.loadChatFilters()
+	if err != nil {
+		return "", err
+	}
+	for _, f := range fs {
+		if !f.re.MatchString(message) {
+			continue
+		}
+		if f.Action == model.ChatFilterActionBlock {
+			return "", ErrChatMessageBlocked
+		}
+		message = f.replace(message)
+	}
+	return message, nil
+}
+
+func (r *Room) AddChatFilter(f *model.ChatFilter) error {
+	if !f.Action.Valid() {
+		return errors.New("invalid filter action")
+	}
+	if _, err := compileChatFilter(f); err != nil {
+		return err
+	}
+	fs, err := r.loadChatFilters()
+	if err != nil {
+		return err
+	}
+	if len(fs) >= maxChatFilters {
+		return fmt.Errorf("a room has at most %d chat filters", maxChatFilters)
+	}
+	f.RoomID = r.ID
+	defer r.chatFilters.Store(nil)
+	return db.CreateChatFilter(f)
+}
+
+func (r *Room) DeleteChatFilter(id string) error {
+	defer r.chatFilters.Store(nil)
+	return db.DeleteChatFilter(r.ID, id)
+}
+
+// reserveChatSlowMode records a message of the member and fails when the last one was
+// sent less than the slow mode interval ago, the admins are not limited. The check and
+// the record are one step so the connections of a member cannot send in parallel, the
+// returned func restores the previous record when the message is not sent.
+func (r *Room) reserveChatSlowMode(userID string) (func(), error) {
+	interval := time.Duration(r.Settings.ChatSlowMode) * time.Second
+	if interval == 0 || r.IsAdmin(userID) {
+		return func() {}, nil
+	}
+	now := time.Now()
+	r.chatLock.Lock()
+	defer r.chatLock.Unlock()
+	if r.chatLastSent == nil {
+		r.chatLastSent = make(map[string]time.Time)
+	}
+	last, ok := r.chatLastSent[userID]
+	if ok {
+		if wait := interval - now.Sub(last); wait > 0 {
+			return nil, fmt.Errorf(
+				"%w, wait %d seconds",
+				ErrChatSlowMode,
+				int64((wait+time.Second-1)/time.Second),
+			)
+		}
+	}
+	if len(r.chatLastSent) >= chatLastSentPrune {
+		for id, t := range r.chatLastSent {
+			if now.Sub(t) >= interval {
+				delete(r.chatLastSent, id)
+			}
+		}
+	}
+	r.chatLastSent[userID] = now
+	return func() {
+		r.chatLock.Lock()
+		defer r.chatLock.Unlock()
+		// a later message of the member keeps its record
+		if t, exist := r.chatLastSent[userID]; !exist || !t.Equal(now) {
+			return
+		}
+		if ok {
+			r.chatLastSent[userID] = last
+		} else {
+			delete(r.chatLastSent, userID)
+		}
+	}, nil
+}
+
+func (r *Room) checkChatMuted(userID string) error {
+	member, err := r.LoadMember(userID)
+	if err != nil {
+		return err
+	}
+	if member.IsMuted() {
+		return fmt.Errorf(
+			"%w until %s",
+			ErrChatMuted,
+			member.MutedUntil.Format(time.RFC3339),
+		)
+	}
+	return nil
+}
+
+func (r *Room) MuteMember(userID string, until time.Time) error {
+	if r.IsCreator(userID) {
+		return errors.New("creator cannot be muted")
+	}
+	if r.IsGuest(userID) {
+		return errors.New("please set whether guests can chat in the guest permissions")
+	}
+	defer r.members.Delete(userID)
+	return db.SetRoomMemberMutedUntil(r.ID, userID, &until)
+}
+
+func (r *Room) UnmuteMember(userID string) error {
+	defer r.members.Delete(userID)
+	return db.SetRoomMemberMutedUntil(r.ID, userID, nil)
+}
+
+// DeleteChatMessage removes a message from the chats of the members and the history.
+func (r *Room) DeleteChatMessage(deleter *User, id string) error {
+	r.forgetChatMessage(id)
+	RecordChatMessageDeleted(r.ID, id)
+	return r.Broadcast(&pb.Message{
+		Type:      pb.MessageType_CHAT_DELETED,
+		Timestamp: time.Now().UnixMilli(),
+		Sender: &pb.Sender{
+			UserId:   deleter.ID,
+			Username: deleter.Username,
+		},
+		Payload: &pb.Message_DeletedMessageId{
+			DeletedMessageId: id,
+		},
+	})
+}
+
+func (u *User) MuteRoomMember(room *Room, userID string, duration time.Duration) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionMuteRoomMember) {
+		return model.ErrNoPermission
+	}
+	if u.ID == userID {
+		return errors.New("cannot mute yourself")
+	}
+	if room.IsAdmin(userID) && !u.IsRoomCreator(room) {
+		return errors.New("cannot mute admin")
+	}
+	if duration <= 0 || duration > MaxChatMuteSeconds*time.Second {
+		return fmt.Errorf("mute duration must be between 1 and %d seconds", MaxChatMuteSeconds)
+	}
+	return room.MuteMember(userID, time.Now().Add(duration))
+}
+
+func (u *User) UnmuteRoomMember(room *Room, userID string) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionMuteRoomMember) {
+		return model.ErrNoPermission
+	}
+	return room.UnmuteMember(userID)
+}
+
+func (u *User) SetRoomChatSlowMode(room *Room, seconds uint32) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionModerateChat) {
+		return model.ErrNoPermission
+	}
+	if seconds > MaxChatSlowMode {
+		return fmt.Errorf("slow mode must be at most %d seconds", MaxChatSlowMode)
+	}
+	return room.UpdateSettings(map[string]any{
+		"chat_slow_mode": seconds,
+	})
+}
+
+func (u *User) AddRoomChatFilter(room *Room, f *model.ChatFilter) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionModerateChat) {
+		return model.ErrNoPermission
+	}
+	return room.AddChatFilter(f)
+}
+
+func (u *User) DeleteRoomChatFilter(room *Room, id string) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionModerateChat) {
+		return model.ErrNoPermission
+	}
+	return room.DeleteChatFilter(id)
+}
+
+func (u *User) DeleteRoomChatMessage(room *Room, id string) error {
+	if !u.HasRoomAdminPermission(room, model.PermissionDeleteChatMessage) {
+		return model.ErrNoPermission
+	}
+	return room.DeleteChatMessage(u, id)
+}
@@ -0,0 +1,197 @@
+package op
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+const testRoomCreatorID = "creator"
+
+// newTestRoom returns a room whose members are already loaded, so no database is
+// needed.
+func newTestRoom(rs *model.RoomSettings, members ...*model.RoomMember) *Room {
+	r := &Room{
+		Room: model.Room{
+			ID:        "room",
+			CreatorID: testRoomCreatorID,
+			Settings:  rs,
+		},
+	}
+	for _, m := range members {
+		m.RoomID = r.ID
+		r.members.Store(m.UserID, m)
+	}
+	return r
+}
+
+func newTestMember(userID string, role model.RoomMemberRole) *model.RoomMember {
+	return &model.RoomMember{
+		UserID:      userID,
+		Role:        role,
+		Status:      model.RoomMemberStatusActive,
+		Permissions: model.DefaultPermissions,
+	}
+}
+
+func newTestClient(r *Room, userID string) *Client {
+	u := &User{User: model.User{ID: userID, Username: userID, Role: model.RoleUser}}
+	return newClient(u, r, newHub(r.ID), nil)
+}
+
+func TestSendChatMessageModeration(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	tests := []struct {
+		name     string
+		slowMode uint32
+		member   *model.RoomMember
+		// the errors of the messages sent one after the other
+		want []error
+	}{
+		{
+			name:   "no slow mode",
+			member: newTestMember("u", model.RoomMemberRoleMember),
+			want:   []error{nil, nil, nil},
+		},
+		{
+			name:     "slow mode",
+			slowMode: 30,
+			member:   newTestMember("u", model.RoomMemberRoleMember),
+			want:     []error{nil, ErrChatSlowMode, ErrChatSlowMode},
+		},
+		{
+			name:     "admin is not limited",
+			slowMode: 30,
+			member:   newTestMember("u", model.RoomMemberRoleAdmin),
+			want:     []error{nil, nil},
+		},
+		{
+			name:     "creator is not limited",
+			slowMode: 30,
+			member:   newTestMember(testRoomCreatorID, model.RoomMemberRoleCreator),
+			want:     []error{nil, nil},
+		},
+		{
+			name: "muted",
+			member: func() *model.RoomMember {
+				m := newTestMember("u", model.RoomMemberRoleMember)
+				m.MutedUntil = &future
+				return m
+			}(),
+			want: []error{ErrChatMuted},
+		},
+		{
+			name:     "mute expired",
+			slowMode: 30,
+			member: func() *model.RoomMember {
+				m := newTestMember("u", model.RoomMemberRoleMember)
+				m.MutedUntil = &past
+				return m
+			}(),
+			want: []error{nil, ErrChatSlowMode},
+		},
+		{
+			name: "no chat permission",
+			member: func() *model.RoomMember {
+				m := newTestMember("u", model.RoomMemberRoleMember)
+				m.Permissions = m.Permissions.Remove(model.PermissionSendChatMessage)
+				return m
+			}(),
+			want: []error{model.ErrNoPermission},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := model.DefaultRoomSettings()
+			rs.ChatSlowMode = tt.slowMode
+			r := newTestRoom(rs, tt.member)
+			c := newTestClient(r, tt.member.UserID)
+			for i, want := range tt.want {
+				err := c.SendChatMessage("hello")
+				if !errors.Is(err, want) || (want == nil && err != nil) {
+					t.Fatalf("message %d: SendChatMessage() error = %v, want %v", i, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestSendChatMessageSlowModeParallel(t *testing.T) {
+	rs := model.DefaultRoomSettings()
+	rs.ChatSlowMode = 30
+	r := newTestRoom(rs, newTestMember("u", model.RoomMemberRoleMember))
+
+	// the broadcasts block for a while, as with a busy hub, so every connection checks
+	// the slow mode before the first message is out
+	h := newHub(r.ID)
+	h.broadcast = make(chan *broadcastMessage)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		for {
+			select {
+			case <-h.broadcast:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	// the connections of a member, e.g. two tabs
+	const clients = 8
+	var (
+		start = make(chan struct{})
+		wg    sync.WaitGroup
+		errs  = make([]error, clients)
+	)
+	for i := range clients {
+		c := newTestClient(r, "u")
+		c.h = h
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			errs[i] = c.SendChatMessage("hello")
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	sent := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			sent++
+		case !errors.Is(err, ErrChatSlowMode):
+			t.Fatalf("SendChatMessage() error = %v", err)
+		}
+	}
+	if sent != 1 {
+		t.Fatalf("%d messages sent in parallel, want 1", sent)
+	}
+}
+
+func TestSendChatMessageSlowModeRestore(t *testing.T) {
+	rs := model.DefaultRoomSettings()
+	rs.ChatSlowMode = 30
+	r := newTestRoom(rs, newTestMember("u", model.RoomMemberRoleMember))
+
+	closed := newTestClient(r, "u")
+	_ = closed.h.Close()
+	if err := closed.SendChatMessage("hello"); !errors.Is(err, ErrAlreadyClosed) {
+		t.Fatalf("SendChatMessage() error = %v, want %v", err, ErrAlreadyClosed)
+	}
+	// the message was not sent, so it does not count for the slow mode
+	c := newTestClient(r, "u")
+	if err := c.SendChatMessage("hello"); err != nil {
+		t.Fatalf("SendChatMessage() after a failed broadcast error = %v", err)
+	}
+	if err := c.SendChatMessage("hello"); !errors.Is(err, ErrChatSlowMode) {
+		t.Fatalf("SendChatMessage() error = %v, want %v", err, ErrChatSlowMode)
+	}
+}
@@ -2,6 +2,7 @@ package op
 
 import (
 	"io"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/PeterChen1997/synctv/internal/model"
 	pb "github.com/PeterChen1997/synctv/proto/message"
+	"github.com/PeterChen1997/synctv/utils"
 )
 
 type Client struct {
@@ -61,21 +63,55 @@ func (c *Client) Broadcast(msg Message, conf ...BroadcastConf) error {
 	return c.h.Broadcast(msg, conf...)
 }
 
-func (c *Client) SendChatMessage(message string) error {
-	if !c.u.HasRoomPermission(c.r, model.PermissionSendChatMessage) {
-		return model.ErrNoPermission
+func (c *Client) SendChatMessage(message string, conf ...ChatMessageConfig) error {
+	if err := c.CheckCanChat(); err != nil {
+		return err
 	}
-	return c.Broadcast(&pb.Message{
+	now := time.Now()
+	m := &model.ChatMessage{
+		CreatedAt: now,
+		ID:        utils.SortUUID(),
+		RoomID:    c.r.ID,
+		UserID:    c.u.ID,
+		Username:  c.u.Username,
+		Content:   message,
+	}
+	for _, f := range conf {
+		f(m)
+	}
+	if m.ReplyTo != "" && !c.r.hasChatMessage(m.ReplyTo) {
+		return ErrChatMessageNotFound
+	}
+	restore, err := c.r.reserveChatSlowMode(c.u.ID)
+	if err != nil {
+		return err
+	}
+	msg := &pb.Message{
 		Type:      pb.MessageType_CHAT,
-		Timestamp: time.Now().UnixMilli(),
+		Id:        m.ID,
+		Timestamp: now.UnixMilli(),
 		Sender: &pb.Sender{
 			UserId:   c.u.ID,
 			Username: c.u.Username,
 		},
+		ChatMeta: chatMeta(m),
 		Payload: &pb.Message_ChatContent{
 			ChatContent: message,
 		},
-	})
+	}
+	if err := c.Broadcast(msg); err != nil {
+		restore()
+		return err
+	}
+	c.r.chatLock.Lock()
+	c.r.rememberChatMessage(m)
+	record := cloneChatMessage(m)
+	c.r.chatLock.Unlock()
+	RecordChatMessage(record)
+	notifyChatMention(c.r.ID, slices.DeleteFunc(slices.Clone(m.Mentions), func(id string) bool {
+		return id == c.u.ID
+	}), msg)
+	return nil
 }
 
 func (c *Client) Send(msg Message) error {
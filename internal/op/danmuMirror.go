@@ -0,0 +1,154 @@
+package op
+
+import (
+	"context"
+	"html/template"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	bilibiliWeb "github.com/PeterChen1997/synctv/internal/vendors/bilibili"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/PeterChen1997/vendors/api/bilibili"
+)
+
+const (
+	danmuMirrorRetryInterval    = 5 * time.Second
+	danmuMirrorMaxRetryInterval = 2 * time.Minute
+)
+
+// danmuMirror ingests the danmu of the bilibili live room of the current movie, once
+// per room however many members are watching.
+type danmuMirror struct {
+	cancel  context.CancelFunc
+	movieID string
+	mode    string
+	liveID  uint64
+}
+
+// danmuMirrorOf returns the mirror the movie wants, nil when it is not a bilibili live
+// or the mirror is disabled.
+func danmuMirrorOf(m *Movie) *danmuMirror {
+	b := m.VendorInfo.Bilibili
+	if m.VendorInfo.Vendor != model.VendorBilibili || b == nil ||
+		b.DanmuMirror == "" || b.Cid == 0 || b.Bvid != "" || b.Epid != 0 {
+		return nil
+	}
+	return &danmuMirror{
+		movieID: m.ID,
+		mode:    b.DanmuMirror,
+		liveID:  b.Cid,
+	}
+}
+
+// syncDanmuMirror starts or stops the danmu mirror to match the current movie and the
+// viewers of the room, it is called whenever one of them changes.
+func (r *Room) syncDanmuMirror() {
+	r.danmuMirrorLock.Lock()
+	defer r.danmuMirrorLock.Unlock()
+
+	var want *danmuMirror
+	if r.ViewerCount() != 0 {
+		if m, err := r.LoadCurrentMovie(); err == nil {
+			want = danmuMirrorOf(m)
+		}
+	}
+
+	running := r.danmuMirror
+	if running != nil && want != nil &&
+		running.movieID == want.movieID &&
+		running.mode == want.mode &&
+		running.liveID == want.liveID {
+		return
+	}
+	if running != nil {
+		running.cancel()
+		r.danmuMirror = nil
+	}
+	if want == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	want.cancel = cancel
+	r.danmuMirror = want
+	go r.runDanmuMirror(ctx, want)
+}
+
+func (r *Room) stopDanmuMirror() {
+	r.danmuMirrorLock.Lock()
+	defer r.danmuMirrorLock.Unlock()
+	if r.danmuMirror != nil {
+		r.danmuMirror.cancel()
+		r.danmuMirror = nil
+	}
+}
+
+// runDanmuMirror reconnects with a growing delay until ctx is done, the danmu server
+// drops idle connections and a live room may go offline for a while.
+func (r *Room) runDanmuMirror(ctx context.Context, m *danmuMirror) {
+	retry := danmuMirrorRetryInterval
+	for {
+		start := time.Now()
+		err := r.streamDanmuMirror(ctx, m)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warnf("room %s: danmu mirror of live %d error: %v", r.ID, m.liveID, err)
+		if time.Since(start) > danmuMirrorMaxRetryInterval {
+			retry = danmuMirrorRetryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+		retry = min(retry*2, danmuMirrorMaxRetryInterval)
+	}
+}
+
+func (r *Room) streamDanmuMirror(ctx context.Context, m *danmuMirror) error {
+	info, err := vendor.LoadBilibiliClient("").GetLiveDanmuInfo(ctx, &bilibili.GetLiveDanmuInfoReq{
+		RoomID: m.liveID,
+	})
+	if err != nil {
+		return err
+	}
+	msgType := pb.MessageType_CHAT
+	if m.mode == model.DanmuMirrorDanmaku {
+		msgType = pb.MessageType_DANMU
+	}
+	return bilibiliWeb.StreamLiveDanmu(ctx, info, m.liveID, func(danmu *bilibiliWeb.Danmu) error {
+		content := template.HTMLEscapeString(danmu.Content)
+		sender := template.HTMLEscapeString(danmu.Sender)
+		id := utils.SortUUID()
+		now := time.Now()
+		err := r.Broadcast(&pb.Message{
+			Type:      msgType,
+			Id:        id,
+			Timestamp: now.UnixMilli(),
+			Sender: &pb.Sender{
+				Username: sender,
+				Source:   model.ChatMessageSourceBilibili,
+			},
+			Payload: &pb.Message_ChatContent{
+				ChatContent: content,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		RecordChatMessage(&model.ChatMessage{
+			CreatedAt: now,
+			ID:        id,
+			RoomID:    r.ID,
+			Username:  sender,
+			Content:   content,
+			Source:    model.ChatMessageSourceBilibili,
+			Danmaku:   m.mode == model.DanmuMirrorDanmaku,
+		})
+		return nil
+	})
+}
@@ -0,0 +1,115 @@
+package op
+
+import (
+	"errors"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+var (
+	ErrDirectMessageBlocked   = errors.New("the user does not accept your messages")
+	ErrDirectMessageRecipient = errors.New("the recipient is not a member of the room")
+	ErrDirectMessageOffline   = errors.New("the recipient is offline")
+)
+
+// SendDirectMessage sends a private message to all the connections of a member of the
+// room and echoes it to the other connections of the user.
+func (c *Client) SendDirectMessage(toUserID, message string) error {
+	if !c.u.HasRoomPermission(c.r, model.PermissionSendDirectMessage) {
+		return model.ErrNoPermission
+	}
+	if err := c.r.checkChatMuted(c.u.ID); err != nil {
+		return err
+	}
+	if toUserID == c.u.ID {
+		return errors.New("cannot send a direct message to yourself")
+	}
+	if c.r.IsGuest(toUserID) {
+		return ErrDirectMessageRecipient
+	}
+	member, err := c.r.LoadMember(toUserID)
+	if err != nil || member.Status.IsNotActive() {
+		return ErrDirectMessageRecipient
+	}
+	blocked, err := db.IsBlockedBetween(c.u.ID, toUserID)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return ErrDirectMessageBlocked
+	}
+	// an offline recipient reads the message in the history only
+	if !c.h.IsOnline(toUserID) && !directMessageHistoryEnabled() {
+		return ErrDirectMessageOffline
+	}
+
+	now := time.Now()
+	dm := &model.DirectMessage{
+		CreatedAt:   now,
+		ID:          utils.SortUUID(),
+		RoomID:      c.r.ID,
+		SenderID:    c.u.ID,
+		RecipientID: toUserID,
+		Content:     message,
+	}
+	msg := &pb.Message{
+		Type:      pb.MessageType_DIRECT_MESSAGE,
+		Id:        dm.ID,
+		Timestamp: now.UnixMilli(),
+		Sender: &pb.Sender{
+			UserId:   c.u.ID,
+			Username: c.u.Username,
+		},
+		Payload: &pb.Message_DirectMessage{
+			DirectMessage: &pb.DirectMessage{
+				ToUserId:   toUserID,
+				ToUsername: GetUserName(toUserID),
+				Content:    message,
+			},
+		},
+	}
+	if err := c.h.SendToUser(toUserID, msg); err != nil {
+		return err
+	}
+	if err := c.h.SendToUser(c.u.ID, msg, c.connID); err != nil {
+		return err
+	}
+	RecordDirectMessage(dm)
+	return nil
+}
+
+// DirectMessages returns up to limit messages between the user and another member of
+// the room sent before the time, newest first.
+func (u *User) DirectMessages(
+	room *Room,
+	otherID string,
+	before time.Time,
+	limit int,
+) ([]*model.DirectMessage, error) {
+	return db.GetDirectMessages(room.ID, u.ID, otherID, before, limit)
+}
+
+func (u *User) BlockUser(userID string) error {
+	if u.IsGuest() {
+		return errors.New("guest cannot block users")
+	}
+	if u.ID == userID {
+		return errors.New("cannot block yourself")
+	}
+	if _, err := db.GetUserByID(userID); err != nil {
+		return err
+	}
+	return db.BlockUser(u.ID, userID)
+}
+
+func (u *User) UnblockUser(userID string) error {
+	return db.UnblockUser(u.ID, userID)
+}
+
+func (u *User) BlockedUsers() ([]*model.UserBlock, error) {
+	return db.GetBlockedUsers(u.ID)
+}
@@ -0,0 +1,218 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	"github.com/PeterChen1997/synctv/proto/vendors/emby"
+)
+
+const (
+	embyReportTimeout = 10 * time.Second
+	// emby counts time in ticks of 100ns
+	embyTicksPerSecond = 10_000_000
+)
+
+type embySession struct {
+	itemID        string
+	mediaSourceID string
+	playSessionID string
+}
+
+// embyReporter tracks the members whose emby server has been told the movie is
+// playing, every member reports with their own binding to the server of the movie.
+// The reports of a movie run one at a time in the order of the status events, so a
+// progress can not reach the servers after the stop that followed it.
+type embyReporter struct {
+	lock sync.Mutex
+	// user id -> session
+	started map[string]embySession
+
+	queueLock sync.Mutex
+	queue     []func()
+	draining  bool
+}
+
+// enqueue runs the report after the ones queued before it, a goroutine drains the
+// queue while it is not empty.
+func (r *embyReporter) enqueue(report func()) {
+	r.queueLock.Lock()
+	defer r.queueLock.Unlock()
+	r.queue = append(r.queue, report)
+	if !r.draining {
+		r.draining = true
+		go r.drain()
+	}
+}
+
+func (r *embyReporter) drain() {
+	for {
+		r.queueLock.Lock()
+		if len(r.queue) == 0 {
+			r.queue = nil
+			r.draining = false
+			r.queueLock.Unlock()
+			return
+		}
+		report := r.queue[0]
+		r.queue[0] = nil
+		r.queue = r.queue[1:]
+		r.queueLock.Unlock()
+		report()
+	}
+}
+
+// transition records the event for a user and returns the session and event to
+// report, false when nothing has to be reported. A stop always ends the started
+// session, even when the sources of the movie changed since.
+func (r *embyReporter) transition(
+	userID string,
+	session embySession,
+	event emby.PlaybackEvent,
+) (embySession, emby.PlaybackEvent, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	started, isStarted := r.started[userID]
+	if event == emby.PlaybackEvent_PLAYBACK_STOP {
+		if !isStarted {
+			return started, event, false
+		}
+		delete(r.started, userID)
+		return started, event, true
+	}
+	if isStarted && started == session {
+		return session, event, true
+	}
+	if r.started == nil {
+		r.started = make(map[string]embySession)
+	}
+	r.started[userID] = session
+	return session, emby.PlaybackEvent_PLAYBACK_START, true
+}
+
+func (r *embyReporter) forget(userID string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.started, userID)
+}
+
+// startedUserIDs returns the members with a started session that are not in keep.
+func (r *embyReporter) startedUserIDs(keep []string) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var ids []string
+	for id := range r.started {
+		if !slices.Contains(keep, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// reportEmbyPlayback reports the room status of an emby movie to the bound server of
+// every online member who opted in, members who left get a stop. It is queued in the
+// background, the room status must not wait for the emby servers. The cache is read
+// before returning so a stop can still be reported when the cache is cleared right
+// after.
+func (m *Movie) reportEmbyPlayback(event emby.PlaybackEvent, status model.Status) {
+	if m.VendorInfo.Vendor != model.VendorEmby || m.Live {
+		return
+	}
+	data, _ := m.EmbyCache().Raw()
+	if data == nil || len(data.Sources) == 0 {
+		return
+	}
+	serverID, err := m.VendorInfo.Emby.ServerID()
+	if err != nil {
+		return
+	}
+	var online []string
+	if event != emby.PlaybackEvent_PLAYBACK_STOP {
+		online = m.room.OnlineUserIDs()
+	}
+	left := m.emby.startedUserIDs(online)
+	m.emby.enqueue(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), embyReportTimeout)
+		defer cancel()
+		for _, id := range left {
+			m.reportEmbyMemberPlayback(
+				ctx, id, serverID, data, emby.PlaybackEvent_PLAYBACK_STOP, status,
+			)
+		}
+		for _, id := range online {
+			m.reportEmbyMemberPlayback(ctx, id, serverID, data, event, status)
+		}
+	})
+}
+
+func (m *Movie) reportEmbyMemberPlayback(
+	ctx context.Context,
+	userID, serverID string,
+	data *cache.EmbyMovieCacheData,
+	event emby.PlaybackEvent,
+	status model.Status,
+) {
+	u, err := LoadOrInitUserByID(userID)
+	if err != nil {
+		m.emby.forget(userID)
+		return
+	}
+	aucd, err := u.Value().EmbyCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		// the binding is gone, there is no token left to report with
+		m.emby.forget(userID)
+		if !errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			log.Warnf("movie %s: load emby binding of user %s error: %v", m.ID, userID, err)
+		}
+		return
+	}
+	if !aucd.ReportPlayback {
+		// stop the session of a member who opted out while playing
+		event = emby.PlaybackEvent_PLAYBACK_STOP
+	}
+
+	session := embySession{
+		itemID:        data.ItemID,
+		mediaSourceID: data.Sources[0].MediaSourceID,
+	}
+	// the transcode session belongs to the binding of the creator
+	if userID == m.CreatorID {
+		session.playSessionID = data.TranscodeSessionID
+	}
+	session, event, ok := m.emby.transition(userID, session, event)
+	if !ok {
+		return
+	}
+
+	cli := vendor.LoadEmbyClient(aucd.Backend)
+	_, err = cli.ReportPlayback(ctx, &emby.ReportPlaybackReq{
+		Host:          aucd.Host,
+		Token:         aucd.APIKey,
+		ItemId:        session.itemID,
+		MediaSourceId: session.mediaSourceID,
+		PlaySessionId: session.playSessionID,
+		Event:         event,
+		PositionTicks: uint64(max(status.CurrentTime, 0) * embyTicksPerSecond),
+		IsPaused:      !status.IsPlaying,
+		PlaybackRate:  status.PlaybackRate,
+	})
+	if err != nil {
+		log.Warnf("movie %s: report emby playback of user %s error: %v", m.ID, userID, err)
+	}
+}
+
+func (r *Room) reportCurrentEmbyPlayback(event emby.PlaybackEvent) {
+	m, err := r.LoadCurrentMovie()
+	if err != nil {
+		return
+	}
+	m.reportEmbyPlayback(event, r.current.Status())
+}
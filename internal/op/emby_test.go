@@ -0,0 +1,116 @@
+package op
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/PeterChen1997/synctv/proto/vendors/emby"
+)
+
+func TestEmbyReporterTransition(t *testing.T) {
+	const (
+		start    = emby.PlaybackEvent_PLAYBACK_START
+		progress = emby.PlaybackEvent_PLAYBACK_PROGRESS
+		stop     = emby.PlaybackEvent_PLAYBACK_STOP
+	)
+	a := embySession{itemID: "a", mediaSourceID: "a1"}
+	b := embySession{itemID: "b", mediaSourceID: "b1"}
+
+	type step struct {
+		user    string
+		session embySession
+		event   emby.PlaybackEvent
+		// expected report
+		wantSession embySession
+		wantEvent   emby.PlaybackEvent
+		wantOK      bool
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "start progress stop progress",
+			steps: []step{
+				{"u", a, start, a, start, true},
+				{"u", a, progress, a, progress, true},
+				{"u", a, stop, a, stop, true},
+				// a progress after the stop starts a new session
+				{"u", a, progress, a, start, true},
+			},
+		},
+		{
+			name: "first progress starts",
+			steps: []step{
+				{"u", a, progress, a, start, true},
+				{"u", a, progress, a, progress, true},
+			},
+		},
+		{
+			name: "stop without start",
+			steps: []step{
+				{"u", a, stop, embySession{}, stop, false},
+				{"u", a, stop, embySession{}, stop, false},
+			},
+		},
+		{
+			name: "changed source restarts",
+			steps: []step{
+				{"u", a, start, a, start, true},
+				{"u", b, progress, b, start, true},
+				// the stop ends the started session, not the one it was sent with
+				{"u", a, stop, b, stop, true},
+			},
+		},
+		{
+			name: "users are independent",
+			steps: []step{
+				{"u", a, start, a, start, true},
+				{"v", a, progress, a, start, true},
+				{"u", a, stop, a, stop, true},
+				{"v", a, progress, a, progress, true},
+				{"u", a, stop, embySession{}, stop, false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var r embyReporter
+			for i, s := range tt.steps {
+				session, event, ok := r.transition(s.user, s.session, s.event)
+				if ok != s.wantOK || (ok && (session != s.wantSession || event != s.wantEvent)) {
+					t.Fatalf(
+						"step %d: transition(%s, %v, %s) = %v, %s, %v, want %v, %s, %v",
+						i, s.user, s.session, s.event, session, event, ok,
+						s.wantSession, s.wantEvent, s.wantOK,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestEmbyReporterEnqueueOrder(t *testing.T) {
+	var (
+		r   embyReporter
+		mu  sync.Mutex
+		got []int
+		wg  sync.WaitGroup
+	)
+	const n = 100
+	wg.Add(n)
+	for i := range n {
+		r.enqueue(func() {
+			defer wg.Done()
+			mu.Lock()
+			got = append(got, i)
+			mu.Unlock()
+		})
+	}
+	wg.Wait()
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("report %d ran at %d, want in order: %v", v, i, got)
+		}
+	}
+}
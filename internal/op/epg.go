@@ -0,0 +1,77 @@
+package op
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/iptv"
+)
+
+const (
+	epgRefreshInterval = time.Hour
+	// a guide nobody listed for this long is dropped instead of refreshed
+	epgIdleTimeout = 24 * time.Hour
+)
+
+var (
+	// the guides are refreshed in the background, the max age only matters for the
+	// guides loaded between two refreshes
+	epgGuides = cache.NewEPGCache(iptv.GuideWindow)
+	// epgUsed is the last time each guide was loaded
+	epgUsed sync.Map
+)
+
+// LoadEPGGuide returns the guide of the url, downloading it the first time. The
+// download goes on in the background when ctx is done first, a big guide takes longer
+// than a request should wait.
+func LoadEPGGuide(ctx context.Context, url string) (*iptv.Guide, error) {
+	// the guides of the movies added before the urls were checked
+	if err := CheckRemoteURL(url, "http", "https"); err != nil {
+		return nil, err
+	}
+	epgUsed.Store(url, time.Now())
+	type result struct {
+		guide *iptv.Guide
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		guide, err := epgGuides.LoadOrStore(context.Background(), url)
+		ch <- result{guide: guide, err: err}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-ch:
+		return r.guide, r.err
+	}
+}
+
+// RefreshEPGGuides refreshes the loaded guides every epgRefreshInterval until ctx is
+// done.
+func RefreshEPGGuides(ctx context.Context) {
+	ticker := time.NewTicker(epgRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		epgUsed.Range(func(key, value any) bool {
+			url := key.(string)
+			if time.Since(value.(time.Time)) > epgIdleTimeout {
+				epgUsed.Delete(url)
+				epgGuides.Delete(url)
+				return true
+			}
+			if _, err := epgGuides.StoreOrRefresh(ctx, url); err != nil {
+				log.Warnf("refresh epg guide %s error: %v", url, err)
+			}
+			return ctx.Err() == nil
+		})
+	}
+}
@@ -237,7 +237,8 @@ func (h *Hub) ClientNum() int64 {
 	return h.clients.Len()
 }
 
-func (h *Hub) SendToUser(userID string, data Message) (err error) {
+// SendToUser sends to every connection of the user but the ignored ones.
+func (h *Hub) SendToUser(userID string, data Message, ignoreConnID ...string) (err error) {
 	if h.Closed() {
 		return ErrAlreadyClosed
 	}
@@ -248,6 +249,9 @@ func (h *Hub) SendToUser(userID string, data Message) (err error) {
 	cli.lock.RLock()
 	defer cli.lock.RUnlock()
 	for _, c := range cli.m {
+		if utils.In(ignoreConnID, c.ConnID()) {
+			continue
+		}
 		if err = c.Send(data); err != nil {
 			c.Close()
 		}
@@ -277,6 +281,16 @@ func (h *Hub) IsOnline(userID string) bool {
 	return ok
 }
 
+// OnlineUserIDs returns the ids of the users with at least one connection.
+func (h *Hub) OnlineUserIDs() []string {
+	ids := make([]string, 0, h.clients.Len())
+	h.clients.Range(func(id string, _ *clients) bool {
+		ids = append(ids, id)
+		return true
+	})
+	return ids
+}
+
 func (h *Hub) OnlineCount(userID string) int {
 	c, ok := h.clients.Load(userID)
 	if !ok {
@@ -0,0 +1,135 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	"github.com/PeterChen1997/synctv/proto/vendors/jellyfin"
+)
+
+const (
+	jellyfinReportTimeout = 10 * time.Second
+	// jellyfin counts time in ticks of 100ns
+	jellyfinTicksPerSecond = 10_000_000
+)
+
+// jellyfinSession is the play session reported to the jellyfin server, playback has
+// to be started before progress and stop events are accepted.
+type jellyfinSession struct {
+	itemID        string
+	mediaSourceID string
+	playSessionID string
+}
+
+type jellyfinReporter struct {
+	started atomic.Pointer[jellyfinSession]
+}
+
+// ReportJellyfinPlayback reports the room status of a jellyfin movie to the server of
+// the movie creator. Nothing is reported until the movie has been resolved for playback.
+func (m *Movie) ReportJellyfinPlayback(
+	ctx context.Context,
+	event jellyfin.PlaybackEvent,
+	status model.Status,
+) error {
+	if m.VendorInfo.Vendor != model.VendorJellyfin || m.Live {
+		return nil
+	}
+	data, _ := m.JellyfinCache().Raw()
+	return m.reportJellyfinPlaybackData(ctx, data, event, status)
+}
+
+func (m *Movie) reportJellyfinPlaybackData(
+	ctx context.Context,
+	data *cache.JellyfinMovieCacheData,
+	event jellyfin.PlaybackEvent,
+	status model.Status,
+) error {
+	if data == nil || len(data.Sources) == 0 {
+		return nil
+	}
+	serverID, err := m.VendorInfo.Jellyfin.ServerID()
+	if err != nil {
+		return err
+	}
+	u, err := LoadOrInitUserByID(m.CreatorID)
+	if err != nil {
+		return err
+	}
+	aucd, err := u.Value().JellyfinCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		return err
+	}
+	if aucd.Host == "" || aucd.APIKey == "" {
+		return errors.New("not bind jellyfin vendor")
+	}
+
+	session := &jellyfinSession{
+		itemID:        data.ItemID,
+		mediaSourceID: data.Sources[0].MediaSourceID,
+		playSessionID: data.PlaySessionID,
+	}
+	started := m.jellyfin.started.Load()
+	isStarted := started != nil && *started == *session
+	switch event {
+	case jellyfin.PlaybackEvent_PLAYBACK_STOP:
+		if !isStarted || !m.jellyfin.started.CompareAndSwap(started, nil) {
+			return nil
+		}
+	default:
+		if !isStarted {
+			if !m.jellyfin.started.CompareAndSwap(started, session) {
+				return nil
+			}
+			event = jellyfin.PlaybackEvent_PLAYBACK_START
+		}
+	}
+
+	cli := vendor.LoadJellyfinClient(aucd.Backend)
+	_, err = cli.ReportPlayback(ctx, &jellyfin.ReportPlaybackReq{
+		Host:          aucd.Host,
+		Token:         aucd.APIKey,
+		ItemId:        session.itemID,
+		MediaSourceId: session.mediaSourceID,
+		PlaySessionId: session.playSessionID,
+		Event:         event,
+		PositionTicks: uint64(max(status.CurrentTime, 0) * jellyfinTicksPerSecond),
+		IsPaused:      !status.IsPlaying,
+		PlaybackRate:  status.PlaybackRate,
+	})
+	return err
+}
+
+// reportJellyfinPlayback reports in the background, the room status must not wait
+// for the jellyfin server. The cache is read before returning so a stop can still be
+// reported when the cache is cleared right after.
+func (m *Movie) reportJellyfinPlayback(event jellyfin.PlaybackEvent, status model.Status) {
+	if m.VendorInfo.Vendor != model.VendorJellyfin || m.Live {
+		return
+	}
+	data, _ := m.JellyfinCache().Raw()
+	if data == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), jellyfinReportTimeout)
+		defer cancel()
+		if err := m.reportJellyfinPlaybackData(ctx, data, event, status); err != nil {
+			log.Warnf("movie %s: report jellyfin playback error: %v", m.ID, err)
+		}
+	}()
+}
+
+func (r *Room) reportCurrentJellyfinPlayback(event jellyfin.PlaybackEvent) {
+	m, err := r.LoadCurrentMovie()
+	if err != nil {
+		return
+	}
+	m.reportJellyfinPlayback(event, r.current.Status())
+}
@@ -0,0 +1,129 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/local"
+)
+
+// localLibraryWatchInterval is how often watched libraries are checked for changes,
+// there is no portable file system notification so directories are polled.
+const localLibraryWatchInterval = time.Minute
+
+var ErrLocalLibraryScanning = errors.New("local library is already being scanned")
+
+var (
+	localLibraryScans sync.Map // library id -> *sync.Mutex
+	// fingerprints of the watched libraries at their last check
+	localLibraryFingerprints sync.Map // library id -> string
+)
+
+// ScanLocalLibrary rebuilds the index of a library, only one scan of a library runs at
+// a time.
+func ScanLocalLibrary(ctx context.Context, id string) error {
+	mu, _ := localLibraryScans.LoadOrStore(id, new(sync.Mutex))
+	if !mu.(*sync.Mutex).TryLock() {
+		return ErrLocalLibraryScanning
+	}
+	defer mu.(*sync.Mutex).Unlock()
+
+	library, err := db.GetLocalLibrary(id)
+	if err != nil {
+		return err
+	}
+	files, err := local.Scan(ctx, library.Root)
+	if err != nil {
+		if serr := db.SetLocalLibraryScanError(id, err); serr != nil {
+			log.Errorf("local library %s: save scan error: %v", id, serr)
+		}
+		return err
+	}
+	mfs := make([]*model.LocalMediaFile, len(files))
+	for i, f := range files {
+		mf := &model.LocalMediaFile{
+			ModTime:   f.ModTime,
+			LibraryID: id,
+			Dir:       f.Dir(),
+			Path:      f.Path,
+			Name:      f.Name,
+			Size:      f.Size,
+			IsDir:     f.IsDir,
+		}
+		for _, s := range f.Subtitles {
+			mf.Subtitles = append(mf.Subtitles, &model.LocalSubtitle{
+				Path: s.Path,
+				Name: s.Name,
+				Type: s.Type,
+			})
+		}
+		mfs[i] = mf
+	}
+	if err := db.ReplaceLocalMediaFiles(id, mfs); err != nil {
+		return err
+	}
+	log.Infof("local library %s: indexed %d entries", id, len(mfs))
+	return nil
+}
+
+// ScanLocalLibraryAsync scans in the background, e.g. right after a library was added.
+func ScanLocalLibraryAsync(id string) {
+	go func() {
+		err := ScanLocalLibrary(context.Background(), id)
+		if err != nil && !errors.Is(err, ErrLocalLibraryScanning) {
+			log.Errorf("local library %s: scan error: %v", id, err)
+		}
+	}()
+}
+
+func ForgetLocalLibrary(id string) {
+	localLibraryFingerprints.Delete(id)
+	localLibraryScans.Delete(id)
+}
+
+// WatchLocalLibraries polls the watched libraries until ctx is done and rescans those
+// whose directories changed. A watched library is scanned once at startup, since
+// changes made while the server was down are not noticed otherwise.
+func WatchLocalLibraries(ctx context.Context) {
+	ticker := time.NewTicker(localLibraryWatchInterval)
+	defer ticker.Stop()
+	for {
+		checkLocalLibraries(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func checkLocalLibraries(ctx context.Context) {
+	libraries, err := db.GetLocalLibraries(db.WhereLocalLibraryWatched)
+	if err != nil {
+		log.Errorf("get watched local libraries error: %v", err)
+		return
+	}
+	for _, l := range libraries {
+		fp, err := local.Fingerprint(ctx, l.Root)
+		if err != nil {
+			log.Warnf("local library %s: watch error: %v", l.ID, err)
+			continue
+		}
+		if old, ok := localLibraryFingerprints.Load(l.ID); ok && old.(string) == fp {
+			continue
+		}
+		err = ScanLocalLibrary(ctx, l.ID)
+		switch {
+		case err == nil:
+			localLibraryFingerprints.Store(l.ID, fp)
+		case errors.Is(err, ErrLocalLibraryScanning):
+		default:
+			log.Errorf("local library %s: scan error: %v", l.ID, err)
+		}
+	}
+}
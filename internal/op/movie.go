@@ -5,23 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"hash/crc32"
-	"net/http"
 	"net/url"
+	"slices"
 	"sync/atomic"
 	"time"
 
-	log "github.com/sirupsen/logrus"
 	"github.com/PeterChen1997/synctv/internal/cache"
 	"github.com/PeterChen1997/synctv/internal/conf"
+	"github.com/PeterChen1997/synctv/internal/llhls"
 	"github.com/PeterChen1997/synctv/internal/model"
 	"github.com/PeterChen1997/synctv/internal/settings"
+	"github.com/PeterChen1997/synctv/internal/transcode"
 	"github.com/PeterChen1997/synctv/utils"
-	"github.com/zijiren233/go-uhc"
-	"github.com/zijiren233/livelib/av"
-	"github.com/zijiren233/livelib/container/flv"
 	"github.com/zijiren233/livelib/protocol/hls"
-	rtmpProto "github.com/zijiren233/livelib/protocol/rtmp"
-	"github.com/zijiren233/livelib/protocol/rtmp/core"
 	rtmps "github.com/zijiren233/livelib/server"
 )
 
@@ -32,6 +28,20 @@ type Movie struct {
 	alistCache    atomic.Pointer[cache.AlistMovieCache]
 	bilibiliCache atomic.Pointer[cache.BilibiliMovieCache]
 	embyCache     atomic.Pointer[cache.EmbyMovieCache]
+	jellyfinCache atomic.Pointer[cache.JellyfinMovieCache]
+	plexCache     atomic.Pointer[cache.PlexMovieCache]
+	webdavCache   atomic.Pointer[cache.WebDAVMovieCache]
+	pluginCache   atomic.Pointer[cache.PluginMovieCache]
+	subsonicCache atomic.Pointer[cache.SubsonicMovieCache]
+	jellyfin      jellyfinReporter
+	emby          embyReporter
+	transcoder    atomic.Pointer[transcode.Transcoder]
+	llhls         atomic.Pointer[llhlsPlayer]
+}
+
+type llhlsPlayer struct {
+	channel *rtmps.Channel
+	source  atomic.Pointer[llhls.Source]
 }
 
 func (m *Movie) SubPath() string {
@@ -77,6 +87,9 @@ func (m *Movie) CheckExpired(ctx context.Context, expireID uint64) (bool, error)
 
 func (m *Movie) ClearCache() error {
 	m.alistCache.Store(nil)
+	m.webdavCache.Store(nil)
+	m.pluginCache.Store(nil)
+	m.subsonicCache.Store(nil)
 
 	bmc := m.bilibiliCache.Swap(nil)
 	if bmc != nil {
@@ -95,6 +108,30 @@ func (m *Movie) ClearCache() error {
 		}
 	}
 
+	jmc := m.jellyfinCache.Swap(nil)
+	if jmc != nil {
+		u, err := LoadOrInitUserByID(m.CreatorID)
+		if err != nil {
+			return err
+		}
+		err = jmc.Clear(context.Background(), u.Value().JellyfinCache())
+		if err != nil {
+			return err
+		}
+	}
+
+	pmc := m.plexCache.Swap(nil)
+	if pmc != nil {
+		u, err := LoadOrInitUserByID(m.CreatorID)
+		if err != nil {
+			return err
+		}
+		err = pmc.Clear(context.Background(), u.Value().PlexCache())
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -131,6 +168,61 @@ func (m *Movie) EmbyCache() *cache.EmbyMovieCache {
 	return c
 }
 
+func (m *Movie) JellyfinCache() *cache.JellyfinMovieCache {
+	c := m.jellyfinCache.Load()
+	if c == nil {
+		c = cache.NewJellyfinMovieCache(m.Movie, m.SubPath())
+		if !m.jellyfinCache.CompareAndSwap(nil, c) {
+			return m.JellyfinCache()
+		}
+	}
+	return c
+}
+
+func (m *Movie) PlexCache() *cache.PlexMovieCache {
+	c := m.plexCache.Load()
+	if c == nil {
+		c = cache.NewPlexMovieCache(m.Movie, m.SubPath())
+		if !m.plexCache.CompareAndSwap(nil, c) {
+			return m.PlexCache()
+		}
+	}
+	return c
+}
+
+func (m *Movie) SubsonicCache() *cache.SubsonicMovieCache {
+	c := m.subsonicCache.Load()
+	if c == nil {
+		c = cache.NewSubsonicMovieCache(m.Movie, m.SubPath())
+		if !m.subsonicCache.CompareAndSwap(nil, c) {
+			return m.SubsonicCache()
+		}
+	}
+	return c
+}
+
+func (m *Movie) WebDAVCache() *cache.WebDAVMovieCache {
+	c := m.webdavCache.Load()
+	if c == nil {
+		c = cache.NewWebDAVMovieCache(m.Movie, m.SubPath())
+		if !m.webdavCache.CompareAndSwap(nil, c) {
+			return m.WebDAVCache()
+		}
+	}
+	return c
+}
+
+func (m *Movie) PluginCache() *cache.PluginMovieCache {
+	c := m.pluginCache.Load()
+	if c == nil {
+		c = cache.NewPluginMovieCache(m.Movie, m.SubPath())
+		if !m.pluginCache.CompareAndSwap(nil, c) {
+			return m.PluginCache()
+		}
+	}
+	return c
+}
+
 func (m *Movie) Channel() (*rtmps.Channel, error) {
 	if m.IsFolder {
 		return nil, errors.New("this is a folder")
@@ -205,30 +297,10 @@ func (m *Movie) initRtmpProxyChannel() (*rtmps.Channel, error) {
 		return nil, fmt.Errorf("init rtmp hls player error: %w", err)
 	}
 
-	go m.handleRtmpProxy(c)
+	go m.relay(c)
 	return c, nil
 }
 
-func (m *Movie) handleRtmpProxy(c *rtmps.Channel) {
-	for {
-		if c.Closed() {
-			return
-		}
-		cli := core.NewConnClient()
-		if err := cli.Start(m.URL, av.PLAY); err != nil {
-			log.Errorf("push live error: %v", err)
-			cli.Close()
-			time.Sleep(time.Second)
-			continue
-		}
-		if err := c.PushStart(rtmpProto.NewReader(cli)); err != nil {
-			log.Errorf("push live error: %v", err)
-			cli.Close()
-			time.Sleep(time.Second)
-		}
-	}
-}
-
 func (m *Movie) initHTTPProxyChannel() (*rtmps.Channel, error) {
 	if utils.IsM3u8Url(m.URL) {
 		return nil, errors.New("m3u8 url not support")
@@ -243,39 +315,110 @@ func (m *Movie) initHTTPProxyChannel() (*rtmps.Channel, error) {
 		return nil, fmt.Errorf("init http hls player error: %w", err)
 	}
 
-	go m.handleHTTPProxy(c)
+	go m.relay(c)
 	return c, nil
 }
 
-func (m *Movie) handleHTTPProxy(c *rtmps.Channel) {
+func (m *Movie) TranscodeEnabled() bool {
+	return conf.Conf.Transcode.Enable &&
+		m.Live && (m.RtmpSource || m.Proxy) &&
+		(m.LiveTranscode || m.room.Settings.LiveTranscode)
+}
+
+// Transcoder returns the running transcoder of the live channel, starting a new one
+// when there is none or the previous ffmpeg worker has exited.
+func (m *Movie) Transcoder() (*transcode.Transcoder, error) {
+	if !m.TranscodeEnabled() {
+		return nil, transcode.ErrNotEnabled
+	}
+	old := m.transcoder.Load()
+	if old != nil && !old.Closed() {
+		return old, nil
+	}
+	c, err := m.Channel()
+	if err != nil {
+		return nil, err
+	}
+	t, err := transcode.New()
+	if err != nil {
+		return nil, err
+	}
+	if !m.transcoder.CompareAndSwap(old, t) {
+		t.Close()
+		return m.Transcoder()
+	}
+	if err := c.AddPlayer(t); err != nil {
+		m.transcoder.CompareAndSwap(t, nil)
+		t.Close()
+		return nil, err
+	}
+	go m.closeIdleTranscoder(t)
+	return t, nil
+}
+
+// closeIdleTranscoder stops the ffmpeg worker once nobody watches the transcoded
+// channel, the next request starts a new one.
+func (m *Movie) closeIdleTranscoder(t *transcode.Transcoder) {
+	ticker := time.NewTicker(transcode.IdleTimeout / 4)
+	defer ticker.Stop()
 	for {
-		if c.Closed() {
+		select {
+		case <-t.Done():
+			return
+		case <-ticker.C:
+			if t.Idle() < transcode.IdleTimeout {
+				continue
+			}
+			m.transcoder.CompareAndSwap(t, nil)
+			t.Close()
 			return
 		}
-		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, m.URL, nil)
-		if err != nil {
-			log.Errorf("get live error: %v", err)
-			time.Sleep(time.Second)
-			continue
-		}
-		for k, v := range m.Headers {
-			req.Header.Set(k, v)
-		}
-		if req.Header.Get("User-Agent") == "" {
-			req.Header.Set("User-Agent", utils.UA)
+	}
+}
+
+func (m *Movie) LowLatencyHlsEnabled() bool {
+	return m.Live && (m.RtmpSource || m.Proxy) && m.room.Settings.LowLatencyHls
+}
+
+// LowLatencyHls returns the low latency hls source of the live channel, it is attached
+// to the channel on first use and follows it across publish sessions.
+func (m *Movie) LowLatencyHls() (*llhls.Source, error) {
+	c, err := m.Channel()
+	if err != nil {
+		return nil, err
+	}
+	old := m.llhls.Load()
+	if old != nil && old.channel == c {
+		return old.source.Load(), nil
+	}
+	p := &llhlsPlayer{channel: c}
+	p.source.Store(llhls.NewSource())
+	if !m.llhls.CompareAndSwap(old, p) {
+		return m.LowLatencyHls()
+	}
+	go m.runLowLatencyHls(p)
+	return p.source.Load(), nil
+}
+
+func (m *Movie) runLowLatencyHls(p *llhlsPlayer) {
+	src := p.source.Load()
+	for {
+		if m.channel.Load() != p.channel || m.llhls.Load() != p {
+			src.Close()
+			return
 		}
-		resp, err := uhc.Do(req)
-		if err != nil {
-			log.Errorf("get live error: %v", err)
-			resp.Body.Close()
+		if err := p.channel.AddPlayer(src); err != nil {
+			if errors.Is(err, rtmps.ErrClosed) {
+				src.Close()
+				return
+			}
 			time.Sleep(time.Second)
 			continue
 		}
-		if err := c.PushStart(flv.NewReader(resp.Body)); err != nil {
-			log.Errorf("push live error: %v", err)
-			resp.Body.Close()
-			time.Sleep(time.Second)
-		}
+		_ = src.SendPacket(context.Background())
+		src.Close()
+		src = llhls.NewSourceAfter(src)
+		p.source.Store(src)
 	}
 }
 
@@ -290,6 +433,13 @@ func (m *Movie) Validate() error {
 		return nil
 	}
 
+	// The guide is fetched by the server
+	if m.EPGURL != "" {
+		if err := CheckRemoteURL(m.EPGURL, "http", "https"); err != nil {
+			return fmt.Errorf("invalid epg url: %w", err)
+		}
+	}
+
 	// Validate RTMP source settings
 	if err := m.validateRTMPSource(); err != nil {
 		return err
@@ -299,6 +449,22 @@ func (m *Movie) Validate() error {
 	return m.validateURLAndProxy()
 }
 
+// CheckRemoteURL fails when the server must not fetch a url, because of its scheme or
+// because it points to the local network while proxying to it is not allowed.
+func CheckRemoteURL(raw string, schemes ...string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(schemes, u.Scheme) {
+		return fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	if !settings.AllowProxyToLocal.Get() && utils.IsLocalIP(u.Host) {
+		return errors.New("local ip is not allowed")
+	}
+	return nil
+}
+
 func (m *Movie) validateRTMPSource() error {
 	switch {
 	case m.RtmpSource && m.Proxy:
@@ -340,10 +506,16 @@ func (m *Movie) validateLiveProxy(u *url.URL) error {
 	}
 	switch u.Scheme {
 	case "rtmp", "http", "https":
-		return nil
 	default:
 		return fmt.Errorf("unsupported scheme: %s", u.Scheme)
 	}
+	// the more sources are pulled by the server on failover
+	for _, ms := range m.MoreSources {
+		if err := CheckRemoteURL(ms.URL, "rtmp", "http", "https"); err != nil {
+			return fmt.Errorf("invalid source %s: %w", ms.Name, err)
+		}
+	}
+	return nil
 }
 
 func (m *Movie) validateMovieProxy(u *url.URL) error {
@@ -380,6 +552,24 @@ func (m *Movie) validateVendorMovie() error {
 	case model.VendorEmby:
 		return m.VendorInfo.Emby.Validate()
 
+	case model.VendorJellyfin:
+		return m.VendorInfo.Jellyfin.Validate()
+
+	case model.VendorPlex:
+		return m.VendorInfo.Plex.Validate()
+
+	case model.VendorWebDAV:
+		return m.VendorInfo.WebDAV.Validate()
+
+	case model.VendorLocal:
+		return m.VendorInfo.Local.Validate()
+
+	case model.VendorPlugin:
+		return m.VendorInfo.Plugin.Validate()
+
+	case model.VendorSubsonic:
+		return m.VendorInfo.Subsonic.Validate()
+
 	default:
 		return errors.New("vendor not implement validate")
 	}
@@ -389,6 +579,12 @@ func (m *Movie) Terminate() error {
 	if m.IsFolder {
 		return nil
 	}
+	if t := m.transcoder.Swap(nil); t != nil {
+		t.Close()
+	}
+	if p := m.llhls.Swap(nil); p != nil {
+		p.source.Load().Close()
+	}
 	c := m.channel.Swap(nil)
 	if c != nil {
 		err := c.Close()
@@ -20,7 +20,10 @@ type movies struct {
 
 //nolint:gosec
 func (m *movies) AddMovie(mo *model.Movie) error {
-	mo.Position = uint(time.Now().UnixMilli())
+	// an imported movie keeps the position of its archive
+	if mo.Position == 0 {
+		mo.Position = uint(time.Now().UnixMilli())
+	}
 	movie := &Movie{
 		room:  m.room,
 		Movie: mo,
@@ -47,7 +50,9 @@ func (m *movies) AddMovie(mo *model.Movie) error {
 func (m *movies) AddMovies(mos []*model.Movie) error {
 	inited := make([]*Movie, 0, len(mos))
 	for _, mo := range mos {
-		mo.Position = uint(time.Now().UnixMilli())
+		if mo.Position == 0 {
+			mo.Position = uint(time.Now().UnixMilli())
+		}
 		movie := &Movie{
 			room:  m.room,
 			Movie: mo,
@@ -93,6 +98,10 @@ func (m *movies) Update(movieID string, movie *model.MovieBase) error {
 		return err
 	}
 	mv.MovieBase = *movie
+	err = (&Movie{room: m.room, Movie: mv}).Validate()
+	if err != nil {
+		return err
+	}
 	err = db.SaveMovie(mv)
 	if err != nil {
 		return err
@@ -0,0 +1,277 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+)
+
+// maxMusicQueue bounds the requests waiting in the queue of a room.
+const maxMusicQueue = 200
+
+var (
+	ErrNotMusicMode   = errors.New("room is not in music mode")
+	ErrMusicQueueFull = errors.New("music queue is full")
+)
+
+// Track is what a music mode room shows of its current movie.
+type Track struct {
+	Title  string
+	Artist string
+	Album  string
+	// Duration in seconds, 0 when unknown
+	Duration float64
+	// HasCover is set when the vendor serves a cover art of the track
+	HasCover bool
+	// NextSubPath is the track after this one in the same dynamic folder
+	NextSubPath string
+}
+
+// Track returns the metadata of the movie as a track, vendors without metadata only
+// know the name and duration of the movie.
+func (m *Movie) Track(ctx context.Context) (*Track, error) {
+	switch m.VendorInfo.Vendor {
+	case model.VendorSubsonic:
+		u, err := LoadOrInitUserByID(m.CreatorID)
+		if err != nil {
+			return nil, err
+		}
+		data, err := m.SubsonicCache().Get(ctx, u.Value().SubsonicCache())
+		if err != nil {
+			return nil, err
+		}
+		t := &Track{
+			Title:    data.Song.Title,
+			Artist:   data.Song.Artist,
+			Album:    data.Song.Album,
+			Duration: float64(data.Song.Duration),
+			HasCover: data.CoverURL != "",
+		}
+		if data.NextSongID != "" {
+			t.NextSubPath = "song/" + data.NextSongID
+		}
+		return t, nil
+	default:
+		return &Track{
+			Title:    m.Name,
+			Duration: m.Duration,
+		}, nil
+	}
+}
+
+func (r *Room) IsMusicMode() bool {
+	return r.Settings.MusicMode && !r.Settings.ChannelMode
+}
+
+// syncMusicAdvance waits for the end of the current track and moves to the next one,
+// it is called whenever the current movie, its status or the viewers change.
+func (r *Room) syncMusicAdvance() {
+	ctx, cancel := context.WithCancel(context.Background())
+	if old := r.musicCancel.Swap(&cancel); old != nil {
+		(*old)()
+	}
+	if !r.IsMusicMode() || r.ViewerCount() == 0 {
+		cancel()
+		return
+	}
+	go r.waitTrackEnd(ctx)
+}
+
+func (r *Room) stopMusicAdvance() {
+	if cancel := r.musicCancel.Swap(nil); cancel != nil {
+		(*cancel)()
+	}
+}
+
+func (r *Room) waitTrackEnd(ctx context.Context) {
+	c := r.current.Current()
+	if c.Movie.ID == "" || !c.Status.IsPlaying {
+		return
+	}
+	m, err := r.GetMovieByID(c.Movie.ID)
+	if err != nil {
+		return
+	}
+	track, err := m.Track(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Warnf("room %s: get current track error: %v", r.ID, err)
+		}
+		return
+	}
+	// a track of unknown duration plays until someone skips it
+	if track.Duration <= 0 {
+		return
+	}
+	rate := c.Status.PlaybackRate
+	if rate <= 0 {
+		rate = 1
+	}
+	remaining := time.Duration((track.Duration - c.Status.CurrentTime) / rate * float64(time.Second))
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(remaining):
+	}
+	if err := r.NextTrack(c.Movie.ID); err != nil {
+		log.Errorf("room %s: advance to next track error: %v", r.ID, err)
+	}
+}
+
+// NextTrack moves to the first requested track, or to the track after the current one
+// when nobody requested any. from is the movie that ended, nothing happens when the
+// current movie changed meanwhile, an empty from always advances.
+func (r *Room) NextTrack(from string) error {
+	r.musicLock.Lock()
+	defer r.musicLock.Unlock()
+
+	cur := r.current.CurrentMovie()
+	if from != "" && cur.ID != from {
+		return nil
+	}
+	movieID, subPath, err := r.nextTrack(cur)
+	if err != nil {
+		return err
+	}
+	if err := r.SetCurrentMovie(movieID, subPath, movieID != ""); err != nil {
+		return err
+	}
+	if err := r.Broadcast(&pb.Message{
+		Type:      pb.MessageType_CURRENT,
+		Timestamp: time.Now().UnixMilli(),
+	}); err != nil {
+		log.Debugf("room %s: broadcast next track error: %v", r.ID, err)
+	}
+	return nil
+}
+
+func (r *Room) nextTrack(cur model.CurrentMovie) (movieID, subPath string, err error) {
+	items, err := db.GetMusicQueue(r.ID)
+	if err != nil {
+		return "", "", err
+	}
+	if len(items) != 0 {
+		defer r.broadcastMusicQueue()
+	}
+	for _, item := range items {
+		if err := db.DeleteMusicQueueItem(r.ID, item.ID); err != nil {
+			return "", "", err
+		}
+		// the movie may have been deleted since it was requested
+		m, err := r.GetMovieByID(item.MovieID)
+		if err != nil || (m.IsFolder && !m.IsDynamicFolder()) {
+			continue
+		}
+		return item.MovieID, item.SubPath, nil
+	}
+
+	if cur.ID == "" {
+		return "", "", nil
+	}
+	m, err := r.GetMovieByID(cur.ID)
+	if err != nil {
+		return "", "", nil
+	}
+	if m.IsFolder {
+		track, err := m.Track(context.Background())
+		if err == nil && track.NextSubPath != "" {
+			return m.ID, track.NextSubPath, nil
+		}
+	}
+	movies, err := db.GetMoviesByRoomID(r.ID, db.WithParentMovieID(string(m.ParentID)))
+	if err != nil {
+		return "", "", err
+	}
+	i := slices.IndexFunc(movies, func(mv *model.Movie) bool {
+		return mv.ID == m.ID
+	})
+	for _, mv := range movies[i+1:] {
+		if !mv.IsFolder && !mv.Live {
+			return mv.ID, "", nil
+		}
+	}
+	return "", "", nil
+}
+
+func (r *Room) broadcastMusicQueue() {
+	if err := r.Broadcast(&pb.Message{
+		Type:      pb.MessageType_MUSIC_QUEUE,
+		Timestamp: time.Now().UnixMilli(),
+	}); err != nil {
+		log.Debugf("room %s: broadcast music queue error: %v", r.ID, err)
+	}
+}
+
+func (r *Room) MusicQueue() ([]*model.MusicQueueItem, error) {
+	return db.GetMusicQueue(r.ID)
+}
+
+// RequestTrack appends a track to the queue, it starts playing at once when nothing
+// is playing.
+func (r *Room) RequestTrack(userID, movieID, subPath string) (*model.MusicQueueItem, error) {
+	if !r.IsMusicMode() {
+		return nil, ErrNotMusicMode
+	}
+	m, err := r.GetMovieByID(movieID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case m.IsDynamicFolder():
+		if subPath == "" {
+			return nil, errors.New("sub path is empty")
+		}
+	case m.IsFolder:
+		return nil, errors.New("cannot request a static folder")
+	case m.Live:
+		return nil, errors.New("cannot request a live")
+	default:
+		subPath = ""
+	}
+
+	r.musicLock.Lock()
+	count, err := db.GetMusicQueueCount(r.ID)
+	if err != nil {
+		r.musicLock.Unlock()
+		return nil, err
+	}
+	if count >= maxMusicQueue {
+		r.musicLock.Unlock()
+		return nil, ErrMusicQueueFull
+	}
+	item := &model.MusicQueueItem{
+		RoomID:  r.ID,
+		MovieID: movieID,
+		SubPath: subPath,
+		UserID:  userID,
+	}
+	err = db.CreateMusicQueueItem(item)
+	r.musicLock.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	r.broadcastMusicQueue()
+
+	if r.current.CurrentMovie().ID == "" {
+		if err := r.NextTrack(""); err != nil {
+			return nil, fmt.Errorf("play requested track error: %w", err)
+		}
+	}
+	return item, nil
+}
+
+func (r *Room) DeleteTrackRequest(id string) error {
+	err := db.DeleteMusicQueueItem(r.ID, id)
+	if err != nil {
+		return err
+	}
+	r.broadcastMusicQueue()
+	return nil
+}
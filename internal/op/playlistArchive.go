@@ -0,0 +1,216 @@
+package op
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/archive"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+)
+
+// ExportPlaylist archives the whole movie tree of the room.
+func (r *Room) ExportPlaylist() (*archive.Playlist, error) {
+	movies, err := db.GetMoviesByRoomID(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	return archive.NewPlaylist(r.ID, movies), nil
+}
+
+// ImportRoomPlaylist adds the movies of an archive to the folder parentID of the room,
+// the root when it is empty. The vendor movies the user can not play, because the
+// backend or the binding of the user is missing, are skipped.
+func (u *User) ImportRoomPlaylist(
+	room *Room,
+	p *archive.Playlist,
+	parentID string,
+) ([]*archive.ImportResult, error) {
+	if !u.HasRoomPermission(room, model.PermissionAddMovie) {
+		return nil, model.ErrNoPermission
+	}
+	if parentID != "" {
+		parent, err := room.GetMovieByID(parentID)
+		if err != nil {
+			return nil, err
+		}
+		if !parent.IsFolder || parent.IsDynamicFolder() {
+			return nil, errors.New("parent is not a static folder")
+		}
+	}
+	results := importPlaylist(p, parentID, func(m *archive.PlaylistMovie) (string, error) {
+		return u.ID, checkMovieVendor(u.ID, &m.MovieBase)
+	}, room.AddMovie)
+	return results, room.Broadcast(&pb.Message{
+		Type: pb.MessageType_MOVIES,
+		Sender: &pb.Sender{
+			Username: u.Username,
+			UserId:   u.ID,
+		},
+	})
+}
+
+// importPlaylist adds the movies of an archive folders first with add, creator returns
+// the creator of a movie or why it is skipped. The movies keep the order of the archive
+// and get new ids, a movie whose folder is not imported is not either.
+func importPlaylist(
+	p *archive.Playlist,
+	parentID string,
+	creator func(*archive.PlaylistMovie) (string, error),
+	add func(*model.Movie) error,
+) []*archive.ImportResult {
+	movies, depths := p.Tree()
+
+	base := uint(time.Now().UnixMilli()) //nolint:gosec
+	newIDs := make(map[string]string, len(movies))
+	results := make([]*archive.ImportResult, 0, len(movies))
+	for i, m := range movies {
+		result := &archive.ImportResult{
+			ID:   m.ID,
+			Name: m.Name,
+		}
+		results = append(results, result)
+
+		mb := m.MovieBase
+		mb.ParentID = model.EmptyNullString(parentID)
+		if depths[m.ID] != 0 {
+			newParentID, ok := newIDs[m.ParentID.String()]
+			if !ok {
+				result.Status = archive.ImportStatusSkipped
+				result.Error = "folder is not imported"
+				continue
+			}
+			mb.ParentID = model.EmptyNullString(newParentID)
+		}
+		creatorID, err := creator(m)
+		if err != nil {
+			result.Status = archive.ImportStatusSkipped
+			result.Error = err.Error()
+			continue
+		}
+		mo := &model.Movie{
+			MovieBase: mb,
+			CreatorID: creatorID,
+			Position:  base + uint(i), //nolint:gosec
+		}
+		if err := add(mo); err != nil {
+			result.Status = archive.ImportStatusFailed
+			result.Error = err.Error()
+			continue
+		}
+		newIDs[m.ID] = mo.ID
+		result.NewID = mo.ID
+		result.Status = archive.ImportStatusImported
+	}
+	return results
+}
+
+// checkMovieVendor reports why the user can not play a vendor movie on this instance.
+func checkMovieVendor(userID string, m *model.MovieBase) error {
+	vi := &m.VendorInfo
+	if vi.Vendor == "" {
+		return nil
+	}
+	if vi.Backend != "" {
+		var ok bool
+		clients := vendor.LoadClients()
+		switch vi.Vendor {
+		case model.VendorBilibili:
+			_, ok = clients.BilibiliClients()[vi.Backend]
+		case model.VendorAlist:
+			_, ok = clients.AlistClients()[vi.Backend]
+		case model.VendorEmby:
+			_, ok = clients.EmbyClients()[vi.Backend]
+		case model.VendorJellyfin:
+			_, ok = clients.JellyfinClients()[vi.Backend]
+		default:
+			ok = true
+		}
+		if !ok {
+			return fmt.Errorf("vendor backend %s not found", vi.Backend)
+		}
+	}
+
+	var err error
+	switch vi.Vendor {
+	case model.VendorBilibili:
+		// bilibili plays without a binding
+		if vi.Bilibili == nil {
+			return errors.New("bilibili payload is nil")
+		}
+		return nil
+	case model.VendorAlist:
+		if vi.Alist == nil {
+			return errors.New("alist payload is nil")
+		}
+		var serverID string
+		if serverID, err = vi.Alist.ServerID(); err == nil {
+			_, err = db.GetAlistVendor(userID, serverID)
+		}
+	case model.VendorEmby:
+		if vi.Emby == nil {
+			return errors.New("emby payload is nil")
+		}
+		var serverID string
+		if serverID, err = vi.Emby.ServerID(); err == nil {
+			_, err = db.GetEmbyVendor(userID, serverID)
+		}
+	case model.VendorJellyfin:
+		if vi.Jellyfin == nil {
+			return errors.New("jellyfin payload is nil")
+		}
+		var serverID string
+		if serverID, err = vi.Jellyfin.ServerID(); err == nil {
+			_, err = db.GetJellyfinVendor(userID, serverID)
+		}
+	case model.VendorPlex:
+		if vi.Plex == nil {
+			return errors.New("plex payload is nil")
+		}
+		var serverID string
+		if serverID, err = vi.Plex.ServerID(); err == nil {
+			_, err = db.GetPlexVendor(userID, serverID)
+		}
+	case model.VendorWebDAV:
+		if vi.WebDAV == nil {
+			return errors.New("webdav payload is nil")
+		}
+		var serverID string
+		if serverID, err = vi.WebDAV.ServerID(); err == nil {
+			_, err = db.GetWebDAVVendor(userID, serverID)
+		}
+	case model.VendorSubsonic:
+		if vi.Subsonic == nil {
+			return errors.New("subsonic payload is nil")
+		}
+		var serverID string
+		if serverID, _, err = vi.Subsonic.ServerIDAndItemPath(); err == nil {
+			_, err = db.GetSubsonicVendor(userID, serverID)
+		}
+	case model.VendorPlugin:
+		if vi.Plugin == nil {
+			return errors.New("plugin payload is nil")
+		}
+		var serverID string
+		if serverID, _, err = vi.Plugin.ServerIDAndFilePath(); err == nil {
+			_, err = db.GetPluginVendor(userID, vi.Plugin.Name, serverID)
+		}
+	case model.VendorLocal:
+		if vi.Local == nil {
+			return errors.New("local payload is nil")
+		}
+		var libraryID string
+		if libraryID, _, err = vi.Local.LibraryIDAndFilePath(); err == nil {
+			_, err = db.GetLocalLibrary(libraryID)
+		}
+	default:
+		return fmt.Errorf("unknown vendor: %s", vi.Vendor)
+	}
+	if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+		return fmt.Errorf("%s server is not bound", vi.Vendor)
+	}
+	return err
+}
@@ -0,0 +1,178 @@
+package op
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PeterChen1997/synctv/internal/archive"
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+// testArchivePlaylist is the tree
+//
+//	f1/
+//	  m1
+//	  f2/
+//	    m2
+//	m3
+//	m4 (its folder is not in the archive)
+func testArchivePlaylist() *archive.Playlist {
+	movie := func(id, parentID string, folder bool, position uint) *archive.PlaylistMovie {
+		return &archive.PlaylistMovie{
+			ID: id,
+			MovieBase: model.MovieBase{
+				Name:     id,
+				ParentID: model.EmptyNullString(parentID),
+				IsFolder: folder,
+			},
+			Position: position,
+		}
+	}
+	return &archive.Playlist{
+		Movies: []*archive.PlaylistMovie{
+			movie("m2", "f2", false, 5),
+			movie("m4", "gone", false, 6),
+			movie("m1", "f1", false, 2),
+			movie("f2", "f1", true, 3),
+			movie("f1", "", true, 1),
+			movie("m3", "", false, 4),
+		},
+	}
+}
+
+func TestImportPlaylist(t *testing.T) {
+	type want struct {
+		status string
+		// parent id of the added movie
+		parentID string
+	}
+	tests := []struct {
+		name     string
+		parentID string
+		// archive ids the creator func skips
+		skip []string
+		// archive ids add fails on
+		fail []string
+		want map[string]want
+	}{
+		{
+			name: "folders are remapped",
+			want: map[string]want{
+				"f1": {archive.ImportStatusImported, ""},
+				"m1": {archive.ImportStatusImported, "new-f1"},
+				"f2": {archive.ImportStatusImported, "new-f1"},
+				"m2": {archive.ImportStatusImported, "new-f2"},
+				"m3": {archive.ImportStatusImported, ""},
+				"m4": {archive.ImportStatusImported, ""},
+			},
+		},
+		{
+			name:     "roots go to the parent",
+			parentID: "dest",
+			want: map[string]want{
+				"f1": {archive.ImportStatusImported, "dest"},
+				"m1": {archive.ImportStatusImported, "new-f1"},
+				"f2": {archive.ImportStatusImported, "new-f1"},
+				"m2": {archive.ImportStatusImported, "new-f2"},
+				"m3": {archive.ImportStatusImported, "dest"},
+				"m4": {archive.ImportStatusImported, "dest"},
+			},
+		},
+		{
+			name: "children of a skipped folder are skipped",
+			skip: []string{"f1"},
+			want: map[string]want{
+				"f1": {archive.ImportStatusSkipped, ""},
+				"m1": {archive.ImportStatusSkipped, ""},
+				"f2": {archive.ImportStatusSkipped, ""},
+				"m2": {archive.ImportStatusSkipped, ""},
+				"m3": {archive.ImportStatusImported, ""},
+				"m4": {archive.ImportStatusImported, ""},
+			},
+		},
+		{
+			name: "children of a failed folder are skipped",
+			fail: []string{"f2"},
+			want: map[string]want{
+				"f1": {archive.ImportStatusImported, ""},
+				"m1": {archive.ImportStatusImported, "new-f1"},
+				"f2": {archive.ImportStatusFailed, ""},
+				"m2": {archive.ImportStatusSkipped, ""},
+				"m3": {archive.ImportStatusImported, ""},
+				"m4": {archive.ImportStatusImported, ""},
+			},
+		},
+		{
+			name: "skipped movie keeps its siblings",
+			skip: []string{"m1"},
+			want: map[string]want{
+				"f1": {archive.ImportStatusImported, ""},
+				"m1": {archive.ImportStatusSkipped, ""},
+				"f2": {archive.ImportStatusImported, "new-f1"},
+				"m2": {archive.ImportStatusImported, "new-f2"},
+				"m3": {archive.ImportStatusImported, ""},
+				"m4": {archive.ImportStatusImported, ""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added := map[string]*model.Movie{}
+			results := importPlaylist(
+				testArchivePlaylist(),
+				tt.parentID,
+				func(m *archive.PlaylistMovie) (string, error) {
+					for _, id := range tt.skip {
+						if m.ID == id {
+							return "", errors.New("skipped")
+						}
+					}
+					return "u", nil
+				},
+				func(m *model.Movie) error {
+					for _, id := range tt.fail {
+						if m.Name == id {
+							return errors.New("failed")
+						}
+					}
+					parentID := m.ParentID.String()
+					if parentID != tt.parentID && added[parentID] == nil {
+						t.Errorf("%s added before its folder %s", m.Name, parentID)
+					}
+					// the names are the archive ids
+					m.ID = "new-" + m.Name
+					added[m.ID] = m
+					return nil
+				},
+			)
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.want))
+			}
+			for _, r := range results {
+				w, ok := tt.want[r.ID]
+				if !ok {
+					t.Fatalf("unexpected result %s", r.ID)
+				}
+				if r.Status != w.status {
+					t.Errorf("%s: status %s (%s), want %s", r.ID, r.Status, r.Error, w.status)
+				}
+				if r.Status != archive.ImportStatusImported {
+					if r.NewID != "" {
+						t.Errorf("%s: new id %s of a movie not imported", r.ID, r.NewID)
+					}
+					continue
+				}
+				m := added[r.NewID]
+				if m == nil {
+					t.Fatalf("%s: new id %s was not added", r.ID, r.NewID)
+				}
+				if got := m.ParentID.String(); got != w.parentID {
+					t.Errorf("%s: parent %q, want %q", r.ID, got, w.parentID)
+				}
+				if m.CreatorID != "u" {
+					t.Errorf("%s: creator %q, want u", r.ID, m.CreatorID)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/settings"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/go-uhc"
+	"github.com/zijiren233/livelib/av"
+	"github.com/zijiren233/livelib/container/flv"
+	rtmpProto "github.com/zijiren233/livelib/protocol/rtmp"
+	"github.com/zijiren233/livelib/protocol/rtmp/core"
+	rtmps "github.com/zijiren233/livelib/server"
+)
+
+const (
+	relayMinBackoff = time.Second
+	// a stream that stayed live this long before it dropped is retried without backoff
+	relayStableAfter = time.Minute
+)
+
+type relaySource struct {
+	Name string
+	URL  string
+}
+
+// relaySources returns the movie url followed by every more source that can be pulled
+// into a channel, in the order they are tried on failover.
+func (m *Movie) relaySources() []relaySource {
+	sources := []relaySource{{Name: "primary", URL: m.URL}}
+	for i, ms := range m.MoreSources {
+		if !canRelay(ms.URL) {
+			continue
+		}
+		name := ms.Name
+		if name == "" {
+			name = strconv.Itoa(i + 1)
+		}
+		sources = append(sources, relaySource{Name: name, URL: ms.URL})
+	}
+	return sources
+}
+
+func canRelay(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "rtmp":
+		return true
+	case "http", "https":
+		return !utils.IsM3u8Url(rawURL)
+	default:
+		return false
+	}
+}
+
+func (m *Movie) relayAlive(c *rtmps.Channel) bool {
+	return !c.Closed() && m.channel.Load() == c
+}
+
+// relay keeps pulling the remote stream into the channel until the channel is terminated.
+// Failed attempts back off exponentially and fail over to the next source, a stream that
+// was live and then dropped is retried on the same source, backing off when it keeps
+// dropping.
+func (m *Movie) relay(c *rtmps.Channel) {
+	sources := m.relaySources()
+	var (
+		idx      int
+		attempts uint32
+		drops    uint32
+	)
+	for m.relayAlive(c) {
+		src := sources[idx]
+		attempts++
+		m.broadcastRelayStatus(pb.RelayState_RELAY_CONNECTING, attempts, src.Name, nil)
+
+		start := time.Now()
+		live, err := m.pull(c, src, attempts)
+		if !m.relayAlive(c) {
+			return
+		}
+		if live {
+			log.Warnf("relay %s: source %s stalled: %v", m.ID, src.Name, err)
+			m.broadcastRelayStatus(pb.RelayState_RELAY_STALLED, attempts, src.Name, err)
+			attempts = 0
+			if time.Since(start) >= relayStableAfter {
+				drops = 0
+				continue
+			}
+			drops++
+			time.Sleep(relayBackoff(drops))
+			continue
+		}
+
+		log.Errorf("relay %s: pull source %s error: %v", m.ID, src.Name, err)
+		budget := settings.LiveProxyRetryBudget.Get()
+		if budget > 0 && int64(attempts) >= budget {
+			m.broadcastRelayStatus(pb.RelayState_RELAY_FAILED, attempts, src.Name, err)
+			// drop the channel so the next viewer or re-select starts a fresh relay
+			if m.channel.CompareAndSwap(c, nil) {
+				c.Close()
+			}
+			return
+		}
+		idx = (idx + 1) % len(sources)
+		time.Sleep(relayBackoff(attempts))
+	}
+}
+
+func relayBackoff(attempts uint32) time.Duration {
+	maxBackoff := time.Duration(settings.LiveProxyMaxBackoff.Get()) * time.Second
+	backoff := relayMinBackoff
+	for i := uint32(1); i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxBackoff)
+}
+
+// pull pushes one connection to src into the channel and reports whether it went live.
+func (m *Movie) pull(c *rtmps.Channel, src relaySource, attempts uint32) (bool, error) {
+	var (
+		reader av.Reader
+		closer io.Closer
+	)
+	// the sources are checked again, the settings may have changed since they were added
+	if err := CheckRemoteURL(src.URL, "rtmp", "http", "https"); err != nil {
+		return false, err
+	}
+	u, err := url.Parse(src.URL)
+	if err != nil {
+		return false, err
+	}
+	switch u.Scheme {
+	case "rtmp":
+		cli := core.NewConnClient()
+		if err := cli.Start(src.URL, av.PLAY); err != nil {
+			cli.Close()
+			return false, err
+		}
+		reader, closer = rtmpProto.NewReader(cli), cli
+	case "http", "https":
+		body, err := m.openHTTPSource(src.URL)
+		if err != nil {
+			return false, err
+		}
+		reader, closer = flv.NewReader(body), body
+	default:
+		return false, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	defer closer.Close()
+
+	rr := newRelayReader(
+		reader,
+		closer,
+		time.Duration(settings.LiveProxyStallTimeout.Get())*time.Second,
+		func() {
+			m.broadcastRelayStatus(pb.RelayState_RELAY_LIVE, attempts, src.Name, nil)
+		},
+	)
+	defer rr.Stop()
+
+	err = c.PushStart(rr)
+	if err == nil {
+		err = errors.New("stream ended")
+	}
+	return rr.Live(), err
+}
+
+func (m *Movie) openHTTPSource(u string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range m.Headers {
+		req.Header.Set(k, v)
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", utils.UA)
+	}
+	resp, err := uhc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+func (m *Movie) broadcastRelayStatus(
+	state pb.RelayState,
+	attempt uint32,
+	source string,
+	err error,
+) {
+	status := &pb.RelayStatus{
+		MovieId: m.ID,
+		State:   state,
+		Attempt: attempt,
+		Source:  source,
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+	if err := m.room.Broadcast(&pb.Message{
+		Type:      pb.MessageType_RELAY_STATUS,
+		Timestamp: time.Now().UnixMilli(),
+		Payload: &pb.Message_RelayStatus{
+			RelayStatus: status,
+		},
+	}); err != nil {
+		log.Debugf("relay %s: broadcast status error: %v", m.ID, err)
+	}
+}
+
+// relayReader reports the first packet of a connection and closes it when no packet
+// arrives within the stall timeout, so a silent upstream does not block the relay forever.
+type relayReader struct {
+	r      av.Reader
+	watch  *time.Timer
+	onLive func()
+	stall  time.Duration
+	live   bool
+}
+
+func newRelayReader(r av.Reader, c io.Closer, stall time.Duration, onLive func()) *relayReader {
+	return &relayReader{
+		r:      r,
+		stall:  stall,
+		onLive: onLive,
+		watch: time.AfterFunc(stall, func() {
+			c.Close()
+		}),
+	}
+}
+
+func (r *relayReader) Read() (*av.Packet, error) {
+	p, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	r.watch.Reset(r.stall)
+	if !r.live {
+		r.live = true
+		r.onLive()
+	}
+	return p, nil
+}
+
+func (r *relayReader) Live() bool {
+	return r.live
+}
+
+func (r *relayReader) Stop() {
+	r.watch.Stop()
+}
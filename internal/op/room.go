@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 	"github.com/PeterChen1997/synctv/internal/db"
 	"github.com/PeterChen1997/synctv/internal/model"
 	"github.com/PeterChen1997/synctv/internal/settings"
+	"github.com/PeterChen1997/synctv/proto/vendors/emby"
+	"github.com/PeterChen1997/synctv/proto/vendors/jellyfin"
 	"github.com/zijiren233/gencontainer/rwmap"
 	rtmps "github.com/zijiren233/livelib/server"
 	"github.com/zijiren233/stream"
@@ -23,6 +27,22 @@ type Room struct {
 	movies  *movies
 	members rwmap.RWMap[string, *model.RoomMember]
 	model.Room
+
+	scheduleLock   sync.Mutex
+	scheduleCancel atomic.Pointer[context.CancelFunc]
+	schedule       atomic.Pointer[channelSchedule]
+
+	danmuMirrorLock sync.Mutex
+	danmuMirror     *danmuMirror
+
+	musicLock   sync.Mutex
+	musicCancel atomic.Pointer[context.CancelFunc]
+
+	chatLock      sync.Mutex
+	chatLastSent  map[string]time.Time
+	chatRecent    map[string]*model.ChatMessage
+	chatRecentIDs []string
+	chatFilters   atomic.Pointer[[]*chatFilter]
 }
 
 func (r *Room) lazyInitHub() *Hub {
@@ -33,6 +53,7 @@ func (r *Room) lazyInitHub() *Hub {
 			h.Close()
 			return r.lazyInitHub()
 		}
+		r.startChannelSchedule()
 	}
 	return h
 }
@@ -85,6 +106,9 @@ func (r *Room) GetChannel(channelName string) (*rtmps.Channel, error) {
 }
 
 func (r *Room) close() {
+	r.stopChannelSchedule()
+	r.stopDanmuMirror()
+	r.stopMusicAdvance()
 	if h := r.hub.Load(); h != nil {
 		if r.hub.CompareAndSwap(h, nil) {
 			h.Close()
@@ -99,7 +123,12 @@ func (r *Room) UpdateMovie(movieID string, movie *model.MovieBase) error {
 	if err != nil {
 		return err
 	}
-	return r.movies.Update(movieID, movie)
+	err = r.movies.Update(movieID, movie)
+	if err != nil {
+		return err
+	}
+	r.syncDanmuMirror()
+	return nil
 }
 
 func (r *Room) AddMovie(m *model.Movie) error {
@@ -163,7 +192,8 @@ func (r *Room) HasPermission(userID string, permission model.RoomMemberPermissio
 		permission.Has(model.PermissionEditMovie) && !r.Settings.CanEditMovie,
 		permission.Has(model.PermissionSetCurrentMovie) && !r.Settings.CanSetCurrentMovie,
 		permission.Has(model.PermissionSetCurrentStatus) && !r.Settings.CanSetCurrentStatus,
-		permission.Has(model.PermissionSendChatMessage) && !r.Settings.CanSendChatMessage:
+		permission.Has(model.PermissionSendChatMessage) && !r.Settings.CanSendChatMessage,
+		permission.Has(model.PermissionSendDirectMessage) && !r.Settings.CanSendDirectMessage:
 		return false
 	default:
 		return rur.Permissions.Has(permission)
@@ -421,6 +451,9 @@ func (r *Room) GetMovieByID(id string) (*Movie, error) {
 }
 
 func (r *Room) Current() *model.Current {
+	if r.IsChannelMode() {
+		return r.channelCurrent()
+	}
 	c := r.current.Current()
 	return &c
 }
@@ -448,12 +481,19 @@ func (r *Room) CheckCurrentExpired(ctx context.Context, expireID uint64) (bool,
 }
 
 func (r *Room) SetCurrentMovie(movieID, subPath string, play bool) error {
+	defer r.syncMusicAdvance()
+	defer r.syncDanmuMirror()
 	currentMovie, err := r.LoadCurrentMovie()
 	if err != nil {
 		if !errors.Is(err, ErrNoCurrentMovie) {
 			return err
 		}
 	} else {
+		currentMovie.reportJellyfinPlayback(
+			jellyfin.PlaybackEvent_PLAYBACK_STOP,
+			r.current.Status(),
+		)
+		currentMovie.reportEmbyPlayback(emby.PlaybackEvent_PLAYBACK_STOP, r.current.Status())
 		if currentMovie.Proxy {
 			err = currentMovie.Close()
 		} else {
@@ -509,31 +549,62 @@ func (r *Room) NewClient(user *User, conn *websocket.Conn) (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	r.syncDanmuMirror()
+	r.syncMusicAdvance()
 	return cli, nil
 }
 
 func (r *Room) RegClient(cli *Client) error {
-	return r.lazyInitHub().RegClient(cli)
+	err := r.lazyInitHub().RegClient(cli)
+	if err != nil {
+		return err
+	}
+	r.syncDanmuMirror()
+	r.syncMusicAdvance()
+	return nil
 }
 
+// UnregisterClient stops the danmu mirror and the music mode advance when the last
+// viewer leaves.
 func (r *Room) UnregisterClient(cli *Client) error {
-	return r.lazyInitHub().UnRegClient(cli)
+	err := r.lazyInitHub().UnRegClient(cli)
+	if err != nil {
+		return err
+	}
+	r.syncDanmuMirror()
+	r.syncMusicAdvance()
+	return nil
 }
 
 func (r *Room) UserIsOnline(userID string) bool {
 	return r.lazyInitHub().IsOnline(userID)
 }
 
+func (r *Room) OnlineUserIDs() []string {
+	if r.HubIsNotInited() {
+		return nil
+	}
+	return r.lazyInitHub().OnlineUserIDs()
+}
+
 func (r *Room) UserOnlineCount(userID string) int {
 	return r.lazyInitHub().OnlineCount(userID)
 }
 
 func (r *Room) SetCurrentStatus(playing bool, seek, rate, timeDiff float64) *model.Status {
-	return r.current.SetStatus(playing, seek, rate, timeDiff)
+	s := r.current.SetStatus(playing, seek, rate, timeDiff)
+	r.reportCurrentJellyfinPlayback(jellyfin.PlaybackEvent_PLAYBACK_PROGRESS)
+	r.reportCurrentEmbyPlayback(emby.PlaybackEvent_PLAYBACK_PROGRESS)
+	r.syncMusicAdvance()
+	return s
 }
 
 func (r *Room) SetCurrentSeekRate(seek, rate, timeDiff float64) *model.Status {
-	return r.current.SetSeekRate(seek, rate, timeDiff)
+	s := r.current.SetSeekRate(seek, rate, timeDiff)
+	r.reportCurrentJellyfinPlayback(jellyfin.PlaybackEvent_PLAYBACK_PROGRESS)
+	r.reportCurrentEmbyPlayback(emby.PlaybackEvent_PLAYBACK_PROGRESS)
+	r.syncMusicAdvance()
+	return s
 }
 
 func (r *Room) SetSettings(settings *model.RoomSettings) error {
@@ -557,6 +628,12 @@ func (r *Room) afterUpdateSettings(rs *model.RoomSettings) error {
 		r.members.Delete(db.GuestUserID)
 	}
 	r.Settings = rs
+	if !rs.ChannelMode {
+		r.stopChannelSchedule()
+	} else if !r.HubIsNotInited() {
+		r.startChannelSchedule()
+	}
+	r.syncMusicAdvance()
 	if rs.DisableGuest {
 		return r.KickUser(db.GuestUserID)
 	}
@@ -0,0 +1,190 @@
+package op
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/archive"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+// ExportRoom archives the settings, members and movie tree of the room.
+func (r *Room) ExportRoom() (*archive.Room, error) {
+	return exportRoom(&r.Room)
+}
+
+// ExportRoomByID archives a room without loading it, so the rooms of banned creators
+// can be backed up too.
+func ExportRoomByID(id string) (*archive.Room, error) {
+	room, err := db.GetRoomByID(id)
+	if err != nil {
+		return nil, err
+	}
+	room.Settings, err = db.CreateOrLoadRoomSettings(room.ID)
+	if err != nil {
+		return nil, err
+	}
+	return exportRoom(room)
+}
+
+func exportRoom(room *model.Room) (*archive.Room, error) {
+	members, err := db.GetRoomMembers(room.ID)
+	if err != nil {
+		return nil, err
+	}
+	movies, err := db.GetMoviesByRoomID(room.ID)
+	if err != nil {
+		return nil, err
+	}
+	return archive.NewRoom(room, members, movies), nil
+}
+
+// CloneRoom creates a room of the user with the settings, members and movie tree of the
+// room. An empty password keeps the password of the room.
+func (u *User) CloneRoom(room *Room, name, password string) (*RoomEntry, error) {
+	a, err := room.ExportRoom()
+	if err != nil {
+		return nil, err
+	}
+	conf, err := roomArchiveConfigs(a, u.ID)
+	if err != nil {
+		return nil, err
+	}
+	if password != "" {
+		return u.CreateRoom(name, password, conf...)
+	}
+	// an empty password keeps the one of the room
+	return u.createRoom(
+		name, "", len(a.HashedPassword) != 0,
+		append(conf, db.WithHashedPassword(a.HashedPassword))...,
+	)
+}
+
+// RestoreRoom creates a room from an archive, name and creatorID replace the ones of the
+// archive when they are not empty. The room is not loaded, so the server can be down.
+func RestoreRoom(a *archive.Room, name, creatorID string) (*model.Room, error) {
+	if name == "" {
+		name = a.Name
+	}
+	if creatorID == "" {
+		creatorID = a.CreatorID
+	}
+	creator, err := db.GetUserByID(creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("load room creator error: %w", err)
+	}
+	conf, err := roomArchiveConfigs(a, creator.ID)
+	if err != nil {
+		return nil, err
+	}
+	status := a.Status
+	if status == 0 {
+		status = model.RoomStatusActive
+	}
+	return db.CreateRoom(name, "", 0, append(conf,
+		db.WithCreator(creator),
+		db.WithStatus(status),
+		db.WithHashedPassword(a.HashedPassword),
+	)...)
+}
+
+// roomArchiveConfigs turns an archive into the configs creating the room of creatorID.
+// The members that are not users of this instance are dropped and their movies go to
+// the creator, the former creator stays as an admin.
+func roomArchiveConfigs(a *archive.Room, creatorID string) ([]db.CreateRoomConfig, error) {
+	var movies []*archive.PlaylistMovie
+	if a.Playlist != nil {
+		movies = a.Playlist.Movies
+	}
+	ids := make([]string, 0, len(a.Members)+len(movies))
+	for _, m := range a.Members {
+		ids = append(ids, m.UserID)
+	}
+	for _, m := range movies {
+		ids = append(ids, m.CreatorID)
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+	users, err := db.GetUsers(db.WhereIDIn(ids))
+	if err != nil {
+		return nil, fmt.Errorf("load room members error: %w", err)
+	}
+	exists := make(map[string]struct{}, len(users))
+	for _, u := range users {
+		exists[u.ID] = struct{}{}
+	}
+
+	settings := model.DefaultRoomSettings()
+	if a.Settings != nil {
+		s := *a.Settings
+		s.ID = ""
+		settings = &s
+	}
+
+	members := make([]*model.RoomMember, 0, len(a.Members))
+	for _, m := range a.Members {
+		if _, ok := exists[m.UserID]; !ok || m.UserID == creatorID {
+			continue
+		}
+		member := &model.RoomMember{
+			UserID:           m.UserID,
+			Status:           m.Status,
+			Role:             m.Role,
+			Permissions:      m.Permissions,
+			AdminPermissions: m.AdminPermissions,
+		}
+		if member.Role.IsCreator() {
+			member.Role = model.RoomMemberRoleAdmin
+			member.AdminPermissions = model.AllAdminPermissions
+		}
+		members = append(members, member)
+	}
+
+	return []db.CreateRoomConfig{
+		db.WithSetting(settings),
+		db.WithRelations(members),
+		db.WithMovies(roomArchiveMovies(a.Playlist, creatorID, exists)),
+	}, nil
+}
+
+// roomArchiveMovies gives the movies of the archive new ids, folders first and in the
+// order of the archive.
+func roomArchiveMovies(
+	p *archive.Playlist,
+	creatorID string,
+	exists map[string]struct{},
+) []*model.Movie {
+	if p == nil {
+		return nil
+	}
+	tree, depths := p.Tree()
+	base := uint(time.Now().UnixMilli()) //nolint:gosec
+	newIDs := make(map[string]string, len(tree))
+	movies := make([]*model.Movie, 0, len(tree))
+	for i, m := range tree {
+		mb := m.MovieBase
+		mb.ParentID = ""
+		if depths[m.ID] != 0 {
+			parentID, ok := newIDs[m.ParentID.String()]
+			if !ok {
+				continue
+			}
+			mb.ParentID = model.EmptyNullString(parentID)
+		}
+		mo := &model.Movie{
+			ID:        utils.SortUUID(),
+			MovieBase: mb,
+			CreatorID: creatorID,
+			Position:  base + uint(i), //nolint:gosec
+		}
+		if _, ok := exists[m.CreatorID]; ok {
+			mo.CreatorID = m.CreatorID
+		}
+		newIDs[m.ID] = mo.ID
+		movies = append(movies, mo)
+	}
+	return movies
+}
@@ -0,0 +1,186 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/model"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+)
+
+// scheduleResyncInterval bounds how long a playlist change can go unnoticed by the
+// channel schedule loop.
+const scheduleResyncInterval = 30 * time.Second
+
+var ErrChannelMode = errors.New("room is in channel mode, current movie follows the schedule")
+
+type ScheduleItem struct {
+	Movie *model.Movie
+	Start time.Time
+	End   time.Time
+}
+
+// channelSchedule is the playlist the channel loops over.
+type channelSchedule struct {
+	movies []*model.Movie
+	total  time.Duration
+}
+
+// loadChannelSchedule returns the movies that take part in the channel schedule: root
+// level, not folders, not live and with a known duration, in playlist order.
+func (r *Room) loadChannelSchedule() (*channelSchedule, error) {
+	movies, err := db.GetMoviesByRoomID(r.ID, db.WithParentMovieID(""))
+	if err != nil {
+		return nil, err
+	}
+	cs := &channelSchedule{
+		movies: movies[:0],
+	}
+	for _, m := range movies {
+		if m.IsFolder || m.Live || m.Duration <= 0 {
+			continue
+		}
+		cs.movies = append(cs.movies, m)
+		cs.total += time.Duration(m.Duration * float64(time.Second))
+	}
+	return cs, nil
+}
+
+// items returns up to count items starting with the item playing at the given time, the
+// schedule loops over the playlist from the creation of the room.
+func (cs *channelSchedule) items(createdAt, at time.Time, count int) []*ScheduleItem {
+	if len(cs.movies) == 0 || cs.total <= 0 || count <= 0 {
+		return []*ScheduleItem{}
+	}
+
+	elapsed := at.Sub(createdAt)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	start := at.Add(-(elapsed % cs.total))
+
+	items := make([]*ScheduleItem, 0, count)
+	for i := 0; len(items) < count; i = (i + 1) % len(cs.movies) {
+		m := cs.movies[i]
+		end := start.Add(time.Duration(m.Duration * float64(time.Second)))
+		if end.After(at) {
+			items = append(items, &ScheduleItem{
+				Movie: m,
+				Start: start,
+				End:   end,
+			})
+		}
+		start = end
+	}
+	return items
+}
+
+// Schedule returns up to count items of the channel schedule, starting with the item
+// playing at the given time. The schedule loops over the playlist from the room creation.
+func (r *Room) Schedule(at time.Time, count int) ([]*ScheduleItem, error) {
+	cs, err := r.loadChannelSchedule()
+	if err != nil {
+		return nil, err
+	}
+	return cs.items(r.CreatedAt, at, count), nil
+}
+
+func (r *Room) IsChannelMode() bool {
+	return r.Settings.ChannelMode
+}
+
+// syncChannelCurrent reloads the schedule, moves the current movie to the scheduled
+// item and broadcasts the change, it returns the item that is playing now.
+func (r *Room) syncChannelCurrent() (*ScheduleItem, error) {
+	r.scheduleLock.Lock()
+	defer r.scheduleLock.Unlock()
+
+	cs, err := r.loadChannelSchedule()
+	if err != nil {
+		return nil, err
+	}
+	r.schedule.Store(cs)
+	var (
+		item *ScheduleItem
+		id   string
+	)
+	if items := cs.items(r.CreatedAt, time.Now(), 1); len(items) != 0 {
+		item = items[0]
+		id = item.Movie.ID
+	}
+	if r.current.CurrentMovie().ID == id {
+		return item, nil
+	}
+	if err := r.SetCurrentMovie(id, "", id != ""); err != nil {
+		return nil, err
+	}
+	if err := r.Broadcast(&pb.Message{
+		Type:      pb.MessageType_CURRENT,
+		Timestamp: time.Now().UnixMilli(),
+	}); err != nil {
+		log.Debugf("room %s: broadcast channel current error: %v", r.ID, err)
+	}
+	return item, nil
+}
+
+// channelCurrent returns the current movie with its position in the schedule cached by
+// the schedule loop, the loop moves the current movie.
+func (r *Room) channelCurrent() *model.Current {
+	c := r.current.Current()
+	cs := r.schedule.Load()
+	if cs == nil {
+		return &c
+	}
+	now := time.Now()
+	items := cs.items(r.CreatedAt, now, 1)
+	if len(items) == 0 || items[0].Movie.ID != c.Movie.ID {
+		return &c
+	}
+	c.Status = model.Status{
+		IsPlaying:    true,
+		PlaybackRate: 1,
+		CurrentTime:  now.Sub(items[0].Start).Seconds(),
+		LastUpdate:   now,
+	}
+	return &c
+}
+
+// startChannelSchedule keeps the current movie on schedule while the room has a hub,
+// so connected viewers are moved to the next item without anyone asking.
+func (r *Room) startChannelSchedule() {
+	if !r.IsChannelMode() {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if old := r.scheduleCancel.Swap(&cancel); old != nil {
+		(*old)()
+	}
+	go r.runChannelSchedule(ctx)
+}
+
+func (r *Room) stopChannelSchedule() {
+	if cancel := r.scheduleCancel.Swap(nil); cancel != nil {
+		(*cancel)()
+	}
+	r.schedule.Store(nil)
+}
+
+func (r *Room) runChannelSchedule(ctx context.Context) {
+	for r.IsChannelMode() {
+		wait := scheduleResyncInterval
+		item, err := r.syncChannelCurrent()
+		if err != nil {
+			log.Errorf("room %s: sync channel schedule error: %v", r.ID, err)
+		} else if item != nil {
+			wait = min(wait, time.Until(item.End))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
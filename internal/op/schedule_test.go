@@ -0,0 +1,190 @@
+package op
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+// newTestSchedule loops over a (60s), b (30s) and c (90s), 180s in total.
+func newTestSchedule() *channelSchedule {
+	cs := &channelSchedule{}
+	for _, m := range []struct {
+		id       string
+		duration float64
+	}{
+		{"a", 60},
+		{"b", 30},
+		{"c", 90},
+	} {
+		cs.movies = append(cs.movies, &model.Movie{
+			ID:        m.id,
+			MovieBase: model.MovieBase{Duration: m.duration},
+		})
+		cs.total += time.Duration(m.duration * float64(time.Second))
+	}
+	return cs
+}
+
+func TestChannelScheduleItems(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sec := func(s int) time.Time {
+		return createdAt.Add(time.Duration(s) * time.Second)
+	}
+	type item struct {
+		id         string
+		start, end int
+	}
+	tests := []struct {
+		name     string
+		schedule *channelSchedule
+		at       int
+		count    int
+		want     []item
+	}{
+		{
+			name:     "at creation",
+			schedule: newTestSchedule(),
+			at:       0,
+			count:    2,
+			want:     []item{{"a", 0, 60}, {"b", 60, 90}},
+		},
+		{
+			name:     "inside an item",
+			schedule: newTestSchedule(),
+			at:       75,
+			count:    3,
+			want:     []item{{"b", 60, 90}, {"c", 90, 180}, {"a", 180, 240}},
+		},
+		{
+			name:     "end of an item is the start of the next",
+			schedule: newTestSchedule(),
+			at:       60,
+			count:    1,
+			want:     []item{{"b", 60, 90}},
+		},
+		{
+			name:     "second loop",
+			schedule: newTestSchedule(),
+			at:       185,
+			count:    1,
+			want:     []item{{"a", 180, 240}},
+		},
+		{
+			name:     "many loops",
+			schedule: newTestSchedule(),
+			at:       10*180 + 100,
+			count:    2,
+			want:     []item{{"c", 10*180 + 90, 10*180 + 180}, {"a", 11 * 180, 11*180 + 60}},
+		},
+		{
+			name:     "before creation starts the loop",
+			schedule: newTestSchedule(),
+			at:       -10,
+			count:    1,
+			want:     []item{{"a", -10, 50}},
+		},
+		{
+			name:     "no count",
+			schedule: newTestSchedule(),
+			at:       75,
+			count:    0,
+		},
+		{
+			name:     "empty playlist",
+			schedule: &channelSchedule{},
+			at:       75,
+			count:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.schedule.items(createdAt, sec(tt.at), tt.count)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d items, want %d", len(got), len(tt.want))
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Movie.ID != w.id || !g.Start.Equal(sec(w.start)) || !g.End.Equal(sec(w.end)) {
+					t.Errorf(
+						"item %d: %s %s-%s, want %s %s-%s", i,
+						g.Movie.ID, g.Start.Sub(createdAt), g.End.Sub(createdAt),
+						w.id, sec(w.start).Sub(createdAt), sec(w.end).Sub(createdAt),
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestChannelCurrent(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule *channelSchedule
+		// time since the room creation
+		elapsed time.Duration
+		current string
+		// want the schedule position, the stored status otherwise
+		wantScheduled bool
+		wantTime      float64
+	}{
+		{
+			name:          "position in the item",
+			schedule:      newTestSchedule(),
+			elapsed:       75 * time.Second,
+			current:       "b",
+			wantScheduled: true,
+			wantTime:      15,
+		},
+		{
+			name:          "position in a later loop",
+			schedule:      newTestSchedule(),
+			elapsed:       3*180*time.Second + 100*time.Second,
+			current:       "c",
+			wantScheduled: true,
+			wantTime:      10,
+		},
+		{
+			name:     "current not moved yet",
+			schedule: newTestSchedule(),
+			elapsed:  75 * time.Second,
+			current:  "a",
+		},
+		{
+			name:    "schedule not loaded",
+			elapsed: 75 * time.Second,
+			current: "b",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRoom(&model.RoomSettings{ChannelMode: true})
+			r.CreatedAt = time.Now().Add(-tt.elapsed)
+			r.current = newCurrent(r.ID, &model.Current{
+				Movie:  model.CurrentMovie{ID: tt.current},
+				Status: model.NewStatus(),
+			})
+			if tt.schedule != nil {
+				r.schedule.Store(tt.schedule)
+			}
+			c := r.channelCurrent()
+			if c.Movie.ID != tt.current {
+				t.Fatalf("movie %s, want %s", c.Movie.ID, tt.current)
+			}
+			if !tt.wantScheduled {
+				if c.Status.IsPlaying || c.Status.CurrentTime != 0 {
+					t.Fatalf("status %+v, want the stored one", c.Status)
+				}
+				return
+			}
+			if !c.Status.IsPlaying || c.Status.PlaybackRate != 1 {
+				t.Fatalf("status %+v, want playing at rate 1", c.Status)
+			}
+			if math.Abs(c.Status.CurrentTime-tt.wantTime) > 1 {
+				t.Fatalf("current time %v, want %v", c.Status.CurrentTime, tt.wantTime)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package op
 
 import (
 	"errors"
+	"fmt"
 	"hash/crc32"
 	"sync/atomic"
 
@@ -20,6 +21,11 @@ type User struct {
 	alistCache    atomic.Pointer[cache.AlistUserCache]
 	bilibiliCache atomic.Pointer[cache.BilibiliUserCache]
 	embyCache     atomic.Pointer[cache.EmbyUserCache]
+	jellyfinCache atomic.Pointer[cache.JellyfinUserCache]
+	plexCache     atomic.Pointer[cache.PlexUserCache]
+	webdavCache   atomic.Pointer[cache.WebDAVUserCache]
+	pluginCache   atomic.Pointer[cache.PluginUserCache]
+	subsonicCache atomic.Pointer[cache.SubsonicUserCache]
 	model.User
 	version uint32
 }
@@ -57,6 +63,61 @@ func (u *User) EmbyCache() *cache.EmbyUserCache {
 	return c
 }
 
+func (u *User) JellyfinCache() *cache.JellyfinUserCache {
+	c := u.jellyfinCache.Load()
+	if c == nil {
+		c = cache.NewJellyfinUserCache(u.ID)
+		if !u.jellyfinCache.CompareAndSwap(nil, c) {
+			return u.JellyfinCache()
+		}
+	}
+	return c
+}
+
+func (u *User) PlexCache() *cache.PlexUserCache {
+	c := u.plexCache.Load()
+	if c == nil {
+		c = cache.NewPlexUserCache(u.ID)
+		if !u.plexCache.CompareAndSwap(nil, c) {
+			return u.PlexCache()
+		}
+	}
+	return c
+}
+
+func (u *User) WebDAVCache() *cache.WebDAVUserCache {
+	c := u.webdavCache.Load()
+	if c == nil {
+		c = cache.NewWebDAVUserCache(u.ID)
+		if !u.webdavCache.CompareAndSwap(nil, c) {
+			return u.WebDAVCache()
+		}
+	}
+	return c
+}
+
+func (u *User) SubsonicCache() *cache.SubsonicUserCache {
+	c := u.subsonicCache.Load()
+	if c == nil {
+		c = cache.NewSubsonicUserCache(u.ID)
+		if !u.subsonicCache.CompareAndSwap(nil, c) {
+			return u.SubsonicCache()
+		}
+	}
+	return c
+}
+
+func (u *User) PluginCache() *cache.PluginUserCache {
+	c := u.pluginCache.Load()
+	if c == nil {
+		c = cache.NewPluginUserCache(u.ID)
+		if !u.pluginCache.CompareAndSwap(nil, c) {
+			return u.PluginCache()
+		}
+	}
+	return c
+}
+
 func (u *User) Version() uint32 {
 	return atomic.LoadUint32(&u.version)
 }
@@ -85,13 +146,23 @@ func (u *User) SetPassword(password string) error {
 }
 
 func (u *User) CreateRoom(name, password string, conf ...db.CreateRoomConfig) (*RoomEntry, error) {
+	return u.createRoom(name, password, password != "", conf...)
+}
+
+// createRoom checks the password settings against hasPassword, which tells whether the
+// room ends up with a password, the configs can set one in place of password.
+func (u *User) createRoom(
+	name, password string,
+	hasPassword bool,
+	conf ...db.CreateRoomConfig,
+) (*RoomEntry, error) {
 	if u.IsAdmin() {
 		conf = append(conf, db.WithStatus(model.RoomStatusActive))
 	} else {
-		if password == "" && settings.RoomMustNeedPwd.Get() {
+		if !hasPassword && settings.RoomMustNeedPwd.Get() {
 			return nil, errors.New("room must need password")
 		}
-		if password != "" && settings.RoomMustNoNeedPwd.Get() {
+		if hasPassword && settings.RoomMustNoNeedPwd.Get() {
 			return nil, errors.New("room must no need password")
 		}
 		if settings.CreateRoomNeedReview.Get() {
@@ -342,6 +413,14 @@ func (u *User) UpdateRoomSettings(room *Room, settings map[string]any) error {
 	if !u.HasRoomAdminPermission(room, model.PermissionSetRoomSettings) {
 		return model.ErrNoPermission
 	}
+	if v, ok := settings["chat_slow_mode"]; ok {
+		if !u.HasRoomAdminPermission(room, model.PermissionModerateChat) {
+			return model.ErrNoPermission
+		}
+		if s, ok := v.(float64); !ok || s < 0 || s > MaxChatSlowMode {
+			return fmt.Errorf("slow mode must be at most %d seconds", MaxChatSlowMode)
+		}
+	}
 	return room.UpdateSettings(settings)
 }
 
@@ -433,6 +512,9 @@ func (u *User) SetRoomCurrentMovie(room *Room, movieID, subPath string, play boo
 	if !u.HasRoomPermission(room, model.PermissionSetCurrentMovie) {
 		return model.ErrNoPermission
 	}
+	if room.IsChannelMode() {
+		return ErrChannelMode
+	}
 	err := room.SetCurrentMovie(movieID, subPath, play)
 	if err != nil {
 		return err
@@ -525,9 +607,42 @@ func (u *User) SetRoomCurrentStatus(
 	if !u.HasRoomPermission(room, model.PermissionSetCurrentStatus) {
 		return nil, model.ErrNoPermission
 	}
+	if room.IsChannelMode() {
+		return nil, ErrChannelMode
+	}
 	return room.SetCurrentStatus(playing, seek, rate, timeDiff), nil
 }
 
+func (u *User) RequestRoomTrack(room *Room, movieID, subPath string) (*model.MusicQueueItem, error) {
+	if !u.HasRoomPermission(room, model.PermissionRequestTrack) {
+		return nil, model.ErrNoPermission
+	}
+	return room.RequestTrack(u.ID, movieID, subPath)
+}
+
+// DeleteRoomTrackRequest removes a request from the queue, members can remove their
+// own requests and those who can set the current movie any of them.
+func (u *User) DeleteRoomTrackRequest(room *Room, id string) error {
+	item, err := db.GetMusicQueueItem(room.ID, id)
+	if err != nil {
+		return err
+	}
+	if item.UserID != u.ID && !u.HasRoomPermission(room, model.PermissionSetCurrentMovie) {
+		return model.ErrNoPermission
+	}
+	return room.DeleteTrackRequest(id)
+}
+
+func (u *User) SkipRoomTrack(room *Room) error {
+	if !u.HasRoomPermission(room, model.PermissionSetCurrentMovie) {
+		return model.ErrNoPermission
+	}
+	if !room.IsMusicMode() {
+		return ErrNotMusicMode
+	}
+	return room.NextTrack("")
+}
+
 func (u *User) BanRoomMember(room *Room, userID string) error {
 	if !u.HasRoomAdminPermission(room, model.PermissionBanRoomMember) {
 		return model.ErrNoPermission
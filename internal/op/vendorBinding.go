@@ -0,0 +1,243 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/email"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+	"github.com/PeterChen1997/vendors/api/alist"
+	"github.com/PeterChen1997/vendors/api/bilibili"
+	"github.com/PeterChen1997/vendors/api/emby"
+)
+
+const (
+	bindingCheckInterval = time.Hour
+	bindingCheckTimeout  = 15 * time.Second
+	// consecutive failed checks after which a binding is expired, a server down for a
+	// moment does not ask its users to log in again
+	bindingExpiredThreshold = 3
+)
+
+var errBilibiliNotLogin = errors.New("bilibili cookies are not logged in")
+
+// ValidateVendorBindings checks the credentials of the bilibili, alist and emby
+// bindings every hour until ctx is done, and asks the users of the expired ones to log
+// in again.
+func ValidateVendorBindings(ctx context.Context) {
+	ticker := time.NewTicker(bindingCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		validateVendorBindings(ctx)
+	}
+}
+
+func validateVendorBindings(ctx context.Context) {
+	err := db.RangeBilibiliVendors(func(vs []*model.BilibiliVendor) error {
+		for _, v := range vs {
+			err := checkBilibiliVendor(ctx, v)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			updateBindingStatus(&v.Status, err, errors.Is(err, errBilibiliNotLogin), func() bool {
+				return notifyBindingExpired(v.UserID, &pb.VendorBinding{
+					Vendor: string(model.VendorBilibili),
+					Error:  v.Status.Error,
+				})
+			})
+			if err := db.SetBilibiliVendorStatus(v.UserID, &v.Status); err != nil {
+				log.Errorf("set bilibili vendor status of user %s error: %v", v.UserID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Errorf("validate bilibili vendors error: %v", err)
+	}
+
+	err = db.RangeAlistVendors(func(vs []*model.AlistVendor) error {
+		for _, v := range vs {
+			err := checkAlistVendor(ctx, v)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			updateBindingStatus(&v.Status, err, false, func() bool {
+				return notifyBindingExpired(v.UserID, &pb.VendorBinding{
+					Vendor:   string(model.VendorAlist),
+					ServerId: v.ServerID,
+					Host:     v.Host,
+					Error:    v.Status.Error,
+				})
+			})
+			if err := db.SetAlistVendorStatus(v.UserID, v.ServerID, &v.Status); err != nil {
+				log.Errorf("set alist vendor status of user %s error: %v", v.UserID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Errorf("validate alist vendors error: %v", err)
+	}
+
+	err = db.RangeEmbyVendors(func(vs []*model.EmbyVendor) error {
+		for _, v := range vs {
+			err := checkEmbyVendor(ctx, v)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			updateBindingStatus(&v.Status, err, false, func() bool {
+				return notifyBindingExpired(v.UserID, &pb.VendorBinding{
+					Vendor:   string(model.VendorEmby),
+					ServerId: v.ServerID,
+					Host:     v.Host,
+					Error:    v.Status.Error,
+				})
+			})
+			if err := db.SetEmbyVendorStatus(v.UserID, v.ServerID, &v.Status); err != nil {
+				log.Errorf("set emby vendor status of user %s error: %v", v.UserID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil && ctx.Err() == nil {
+		log.Errorf("validate emby vendors error: %v", err)
+	}
+}
+
+// updateBindingStatus records a check, expired marks the binding expired at once
+// instead of after the threshold. notify is called until it reports the user was
+// told.
+func updateBindingStatus(s *model.BindingStatus, err error, expired bool, notify func() bool) {
+	s.CheckedAt = time.Now()
+	if err == nil {
+		*s = model.BindingStatus{CheckedAt: s.CheckedAt}
+		return
+	}
+	s.Error = err.Error()
+	s.Fails++
+	if expired || s.Fails >= bindingExpiredThreshold {
+		s.Expired = true
+	}
+	if s.Expired && !s.Notified {
+		s.Notified = notify()
+	}
+}
+
+func checkBilibiliVendor(ctx context.Context, v *model.BilibiliVendor) error {
+	if len(v.Cookies) == 0 {
+		return errBilibiliNotLogin
+	}
+	ctx, cancel := context.WithTimeout(ctx, bindingCheckTimeout)
+	defer cancel()
+	resp, err := vendor.LoadBilibiliClient(v.Backend).UserInfo(ctx, &bilibili.UserInfoReq{
+		Cookies: v.Cookies,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.GetIsLogin() {
+		return errBilibiliNotLogin
+	}
+	return nil
+}
+
+// checkAlistVendor checks the token the user is using when the user is loaded, logging
+// in again once when it expired, and the credentials otherwise.
+func checkAlistVendor(ctx context.Context, v *model.AlistVendor) error {
+	ctx, cancel := context.WithTimeout(ctx, bindingCheckTimeout)
+	defer cancel()
+	me := func(aucd *cache.AlistUserCacheData) error {
+		_, err := vendor.LoadAlistClient(aucd.Backend).Me(ctx, &alist.MeReq{
+			Host:  aucd.Host,
+			Token: aucd.Token,
+		})
+		return err
+	}
+	if u, ok := userCache.Load(v.UserID); ok {
+		ac := u.Value().AlistCache()
+		if aucd, err := ac.LoadOrStore(ctx, v.ServerID); err == nil && me(aucd) == nil {
+			return nil
+		}
+		aucd, err := ac.StoreOrRefresh(ctx, v.ServerID)
+		if err != nil {
+			return err
+		}
+		return me(aucd)
+	}
+	aucd, err := cache.AlistAuthorizationCacheWithConfigInitFunc(ctx, v)
+	if err != nil {
+		return err
+	}
+	return me(aucd)
+}
+
+func checkEmbyVendor(ctx context.Context, v *model.EmbyVendor) error {
+	ctx, cancel := context.WithTimeout(ctx, bindingCheckTimeout)
+	defer cancel()
+	_, err := vendor.LoadEmbyClient(v.Backend).GetSystemInfo(ctx, &emby.SystemInfoReq{
+		Host:  v.Host,
+		Token: v.APIKey,
+	})
+	return err
+}
+
+// notifyBindingExpired asks the user to log in again through the websocket of every
+// room the user is in, and by email. It reports whether the user was reached, an
+// offline user without an email is told at a later check.
+func notifyBindingExpired(userID string, binding *pb.VendorBinding) bool {
+	var sent bool
+	msg := &pb.Message{
+		Type:      pb.MessageType_VENDOR_BINDING,
+		Timestamp: time.Now().UnixMilli(),
+		Payload: &pb.Message_VendorBinding{
+			VendorBinding: binding,
+		},
+	}
+	RangeRoomCache(func(_ string, e *RoomEntry) bool {
+		r := e.Value()
+		if r.HubIsNotInited() || r.UserOnlineCount(userID) == 0 {
+			return true
+		}
+		if err := r.SendToUserWithID(userID, msg); err != nil {
+			log.Debugf("send vendor binding to user %s error: %v", userID, err)
+			return true
+		}
+		sent = true
+		return true
+	})
+
+	if !email.EnableEmail.Get() {
+		return sent
+	}
+	u, err := db.GetUserByID(userID)
+	if err != nil {
+		log.Errorf("notify vendor binding expired: %v", err)
+		return sent
+	}
+	if u.Email == "" {
+		return sent
+	}
+	err = email.SendVendorBindingExpiredEmail(
+		u.Username,
+		u.Email.String(),
+		binding.GetVendor(),
+		binding.GetHost(),
+		binding.GetError(),
+	)
+	if err != nil {
+		log.Errorf("send vendor binding expired email to user %s error: %v", userID, err)
+		return sent
+	}
+	return true
+}
@@ -0,0 +1,388 @@
+package op
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/email"
+	"github.com/PeterChen1997/synctv/internal/model"
+	pb "github.com/PeterChen1997/synctv/proto/message"
+)
+
+const (
+	watchPartyTick = time.Second
+	// watchPartyReload bounds how long a party scheduled on another instance of the
+	// database can go unnoticed
+	watchPartyReload = 30 * time.Second
+	// watchPartyRemindBefore is how long before the start the reminder emails go out
+	watchPartyRemindBefore = 30 * time.Minute
+	// watchPartyStartGrace is how late a party still starts, e.g. after a restart of
+	// the server, the later ones are missed
+	watchPartyStartGrace = 10 * time.Minute
+
+	watchPartyCanceled = "canceled"
+)
+
+// watchPartyCountdown are the times before the start the countdown is pushed at, the
+// clients count down between them.
+var watchPartyCountdown = []time.Duration{
+	time.Second,
+	2 * time.Second,
+	3 * time.Second,
+	4 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+	time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+}
+
+var (
+	ErrWatchPartyInPast    = errors.New("watch party must start in the future")
+	ErrWatchPartyNotActive = errors.New("watch party already started")
+
+	watchPartyChanged = make(chan struct{}, 1)
+)
+
+// notifyWatchPartyChanged wakes the watch party loop to reload the parties.
+func notifyWatchPartyChanged() {
+	select {
+	case watchPartyChanged <- struct{}{}:
+	default:
+	}
+}
+
+// WatchParties returns the parties of the room starting after since.
+func (r *Room) WatchParties(since time.Time) ([]*model.WatchParty, error) {
+	return db.GetWatchParties(r.ID, db.WithWatchPartyStartAfter(since))
+}
+
+func (r *Room) checkWatchPartyMovie(movieID string) error {
+	m, err := r.GetMovieByID(movieID)
+	if err != nil {
+		return err
+	}
+	if m.IsFolder && !m.IsDynamicFolder() {
+		return errors.New("cannot schedule a static folder")
+	}
+	return nil
+}
+
+func (u *User) CreateRoomWatchParty(
+	room *Room,
+	movieID, description string,
+	startAt time.Time,
+) (*model.WatchParty, error) {
+	if !u.HasRoomPermission(room, model.PermissionSetCurrentMovie) {
+		return nil, model.ErrNoPermission
+	}
+	if room.IsChannelMode() {
+		return nil, ErrChannelMode
+	}
+	if !startAt.After(time.Now()) {
+		return nil, ErrWatchPartyInPast
+	}
+	if err := room.checkWatchPartyMovie(movieID); err != nil {
+		return nil, err
+	}
+	p := &model.WatchParty{
+		RoomID:      room.ID,
+		CreatorID:   u.ID,
+		MovieID:     movieID,
+		Description: description,
+		StartAt:     startAt,
+		Status:      model.WatchPartyStatusScheduled,
+	}
+	if err := db.CreateWatchParty(p); err != nil {
+		return nil, err
+	}
+	if err := db.SetWatchPartyRSVP(p.ID, u.ID, model.RSVPGoing); err != nil {
+		log.Errorf("rsvp the creator of watch party %s error: %v", p.ID, err)
+	}
+	notifyWatchPartyChanged()
+	return p, room.broadcastWatchParty(p, "")
+}
+
+// UpdateRoomWatchParty moves a party that did not start yet, the creator of the party
+// and those who can set the current movie can.
+func (u *User) UpdateRoomWatchParty(
+	room *Room,
+	id, movieID, description string,
+	startAt time.Time,
+) (*model.WatchParty, error) {
+	p, err := u.loadModifiableWatchParty(room, id)
+	if err != nil {
+		return nil, err
+	}
+	if !startAt.After(time.Now()) {
+		return nil, ErrWatchPartyInPast
+	}
+	if err := room.checkWatchPartyMovie(movieID); err != nil {
+		return nil, err
+	}
+	// a moved party is reminded again, the loop reloads the parties from the database
+	if !p.StartAt.Equal(startAt) {
+		p.Reminded = false
+	}
+	p.MovieID = movieID
+	p.Description = description
+	p.StartAt = startAt
+	if err := db.UpdateWatchParty(p); err != nil {
+		return nil, err
+	}
+	notifyWatchPartyChanged()
+	return p, room.broadcastWatchParty(p, "")
+}
+
+func (u *User) DeleteRoomWatchParty(room *Room, id string) error {
+	p, err := db.GetWatchParty(room.ID, id)
+	if err != nil {
+		return err
+	}
+	if p.CreatorID != u.ID && !u.HasRoomPermission(room, model.PermissionSetCurrentMovie) {
+		return model.ErrNoPermission
+	}
+	if err := db.DeleteWatchParty(room.ID, id); err != nil {
+		return err
+	}
+	notifyWatchPartyChanged()
+	if p.Status != model.WatchPartyStatusScheduled {
+		return nil
+	}
+	return room.broadcastWatchParty(p, watchPartyCanceled)
+}
+
+func (u *User) loadModifiableWatchParty(room *Room, id string) (*model.WatchParty, error) {
+	p, err := db.GetWatchParty(room.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if p.CreatorID != u.ID && !u.HasRoomPermission(room, model.PermissionSetCurrentMovie) {
+		return nil, model.ErrNoPermission
+	}
+	if p.Status != model.WatchPartyStatusScheduled {
+		return nil, ErrWatchPartyNotActive
+	}
+	return p, nil
+}
+
+func (u *User) RSVPRoomWatchParty(room *Room, id string, response model.RSVPResponse) error {
+	if !response.Valid() {
+		return errors.New("invalid rsvp response")
+	}
+	p, err := db.GetWatchParty(room.ID, id)
+	if err != nil {
+		return err
+	}
+	if p.Status != model.WatchPartyStatusScheduled {
+		return ErrWatchPartyNotActive
+	}
+	return db.SetWatchPartyRSVP(p.ID, u.ID, response)
+}
+
+// broadcastWatchParty pushes a party to the room, status replaces the status of the
+// party when it is not empty.
+func (r *Room) broadcastWatchParty(p *model.WatchParty, status string) error {
+	if status == "" {
+		status = p.Status.String()
+	}
+	var countdown int64
+	if p.Status == model.WatchPartyStatusScheduled {
+		countdown = max(int64(time.Until(p.StartAt).Round(time.Second)/time.Second), 0)
+	}
+	return r.Broadcast(&pb.Message{
+		Type:      pb.MessageType_WATCH_PARTY,
+		Timestamp: time.Now().UnixMilli(),
+		Payload: &pb.Message_WatchParty{
+			WatchParty: &pb.WatchParty{
+				Id:          p.ID,
+				MovieId:     p.MovieID,
+				Description: p.Description,
+				StartAt:     p.StartAt.UnixMilli(),
+				Countdown:   countdown,
+				Status:      status,
+			},
+		},
+	})
+}
+
+// RunWatchParties reminds the members of the coming parties, pushes their countdown
+// and starts them until ctx is done.
+func RunWatchParties(ctx context.Context) {
+	ticker := time.NewTicker(watchPartyTick)
+	defer ticker.Stop()
+
+	var (
+		parties  []*model.WatchParty
+		loadedAt time.Time
+		reload   = true
+		// the last countdown mark pushed for each start of each party
+		marks = make(map[watchPartyStart]time.Duration)
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-watchPartyChanged:
+			reload = true
+		case <-ticker.C:
+		}
+		now := time.Now()
+		if reload || now.Sub(loadedAt) >= watchPartyReload {
+			ps, err := db.GetDueWatchParties(now.Add(watchPartyRemindBefore))
+			if err != nil {
+				log.Errorf("load watch parties error: %v", err)
+			} else {
+				parties, loadedAt, reload = ps, now, false
+				starts := make(map[watchPartyStart]struct{}, len(ps))
+				for _, p := range ps {
+					starts[newWatchPartyStart(p)] = struct{}{}
+				}
+				maps.DeleteFunc(marks, func(k watchPartyStart, _ time.Duration) bool {
+					_, ok := starts[k]
+					return !ok
+				})
+			}
+		}
+		parties = slices.DeleteFunc(parties, func(p *model.WatchParty) bool {
+			return runWatchParty(p, now, marks)
+		})
+	}
+}
+
+// watchPartyStart is a party at a start time, a moved party counts down again.
+type watchPartyStart struct {
+	id      string
+	startAt int64
+}
+
+func newWatchPartyStart(p *model.WatchParty) watchPartyStart {
+	return watchPartyStart{id: p.ID, startAt: p.StartAt.UnixMilli()}
+}
+
+// runWatchParty does what is due for a party and reports whether it is over.
+func runWatchParty(
+	p *model.WatchParty,
+	now time.Time,
+	marks map[watchPartyStart]time.Duration,
+) bool {
+	key := newWatchPartyStart(p)
+	remaining := p.StartAt.Sub(now)
+	if !p.Reminded {
+		p.Reminded = true
+		if err := db.SetWatchPartyReminded(p.ID); err != nil {
+			log.Errorf("set watch party %s reminded error: %v", p.ID, err)
+		}
+		if remaining > 0 {
+			go remindWatchParty(*p)
+		}
+	}
+
+	if remaining > 0 {
+		i, _ := slices.BinarySearch(watchPartyCountdown, remaining)
+		if i == len(watchPartyCountdown) {
+			return false
+		}
+		mark := watchPartyCountdown[i]
+		if last, ok := marks[key]; ok && last <= mark {
+			return false
+		}
+		marks[key] = mark
+		if e, loaded := roomCache.Load(p.RoomID); loaded {
+			if err := e.Value().broadcastWatchParty(p, ""); err != nil {
+				log.Debugf("broadcast watch party %s countdown error: %v", p.ID, err)
+			}
+		}
+		return false
+	}
+
+	delete(marks, key)
+	p.Status = model.WatchPartyStatusStarted
+	if -remaining > watchPartyStartGrace {
+		p.Status = model.WatchPartyStatusMissed
+		log.Warnf("watch party %s of room %s missed its start", p.ID, p.RoomID)
+	} else if err := startWatchParty(p); err != nil {
+		p.Status = model.WatchPartyStatusMissed
+		log.Errorf("start watch party %s of room %s error: %v", p.ID, p.RoomID, err)
+	}
+	if err := db.SetWatchPartyStatus(p.ID, p.Status); err != nil {
+		log.Errorf("set watch party %s status error: %v", p.ID, err)
+	}
+	return true
+}
+
+// startWatchParty makes the movie of the party the current movie of its room and plays
+// it.
+func startWatchParty(p *model.WatchParty) error {
+	e, err := LoadOrInitRoomByID(p.RoomID)
+	if err != nil {
+		return err
+	}
+	r := e.Value()
+	if r.IsChannelMode() {
+		return ErrChannelMode
+	}
+	if err := r.SetCurrentMovie(p.MovieID, "", true); err != nil {
+		return err
+	}
+	if err := r.Broadcast(&pb.Message{
+		Type:      pb.MessageType_CURRENT,
+		Timestamp: time.Now().UnixMilli(),
+		Sender: &pb.Sender{
+			Username: GetUserName(p.CreatorID),
+			UserId:   p.CreatorID,
+		},
+	}); err != nil {
+		log.Debugf("broadcast watch party %s current error: %v", p.ID, err)
+	}
+	return r.broadcastWatchParty(p, model.WatchPartyStatusStarted.String())
+}
+
+// remindWatchParty emails the members going or maybe going to a party.
+func remindWatchParty(p model.WatchParty) {
+	if !email.EnableEmail.Get() {
+		return
+	}
+	room, err := db.GetRoomByID(p.RoomID)
+	if err != nil {
+		log.Errorf("remind watch party %s: load room error: %v", p.ID, err)
+		return
+	}
+	movie, err := db.GetMovieByID(p.RoomID, p.MovieID)
+	if err != nil {
+		log.Errorf("remind watch party %s: load movie error: %v", p.ID, err)
+		return
+	}
+	rsvps, err := db.GetWatchPartyRSVPs(p.ID)
+	if err != nil {
+		log.Errorf("remind watch party %s: load rsvps error: %v", p.ID, err)
+		return
+	}
+	for _, rsvp := range rsvps {
+		if rsvp.Response != model.RSVPGoing && rsvp.Response != model.RSVPMaybe {
+			continue
+		}
+		u, err := db.GetUserByID(rsvp.UserID)
+		if err != nil || u.Email == "" {
+			continue
+		}
+		err = email.SendWatchPartyReminderEmail(
+			u.Username,
+			u.Email.String(),
+			room.ID,
+			room.Name,
+			movie.Name,
+			p.Description,
+			p.StartAt,
+		)
+		if err != nil {
+			log.Errorf("send watch party %s reminder to user %s error: %v", p.ID, u.ID, err)
+		}
+	}
+}
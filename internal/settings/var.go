@@ -26,6 +26,24 @@ var (
 			return i, nil
 		}),
 	)
+	// days the room chat is kept, 0 disables the chat history
+	ChatHistoryRetention = NewInt64Setting(
+		"chat_history_retention",
+		7,
+		model.SettingGroupRoom,
+		WithBeforeSetInt64(func(_ Int64Setting, i int64) (int64, error) {
+			if i < 0 {
+				return 0, errors.New("chat history retention must not be negative")
+			}
+			return i, nil
+		}),
+	)
+	// whether the direct messages are kept for the chat history retention as well
+	DirectMessageHistory = NewBoolSetting(
+		"direct_message_history",
+		false,
+		model.SettingGroupRoom,
+	)
 )
 
 func init() {
@@ -79,6 +97,42 @@ var (
 	LiveProxy         = NewBoolSetting("live_proxy", true, model.SettingGroupProxy)
 	AllowProxyToLocal = NewBoolSetting("allow_proxy_to_local", false, model.SettingGroupProxy)
 	ProxyCacheEnable  = NewBoolSetting("proxy_cache_enable", false, model.SettingGroupProxy)
+	// consecutive failed pulls before a live relay gives up, 0 means never give up
+	LiveProxyRetryBudget = NewInt64Setting(
+		"live_proxy_retry_budget",
+		10,
+		model.SettingGroupProxy,
+		WithBeforeSetInt64(func(_ Int64Setting, i int64) (int64, error) {
+			if i < 0 {
+				return 0, errors.New("live proxy retry budget must not be negative")
+			}
+			return i, nil
+		}),
+	)
+	// seconds, upper bound of the exponential backoff between relay attempts
+	LiveProxyMaxBackoff = NewInt64Setting(
+		"live_proxy_max_backoff",
+		30,
+		model.SettingGroupProxy,
+		WithBeforeSetInt64(func(_ Int64Setting, i int64) (int64, error) {
+			if i < 1 {
+				return 0, errors.New("live proxy max backoff must be greater than 0")
+			}
+			return i, nil
+		}),
+	)
+	// seconds without a packet before a live relay is considered stalled
+	LiveProxyStallTimeout = NewInt64Setting(
+		"live_proxy_stall_timeout",
+		15,
+		model.SettingGroupProxy,
+		WithBeforeSetInt64(func(_ Int64Setting, i int64) (int64, error) {
+			if i < 1 {
+				return 0, errors.New("live proxy stall timeout must be greater than 0")
+			}
+			return i, nil
+		}),
+	)
 )
 
 var (
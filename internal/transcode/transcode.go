@@ -0,0 +1,362 @@
+package transcode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/internal/conf"
+	"github.com/PeterChen1997/synctv/utils/m3u8"
+	"github.com/zijiren233/livelib/av"
+	"github.com/zijiren233/livelib/protocol/httpflv"
+)
+
+var (
+	ErrNotEnabled       = errors.New("live transcode is not enabled")
+	ErrEmptyLadder      = errors.New("transcode ladder is empty")
+	ErrRenditionInvalid = errors.New("invalid rendition")
+	ErrSegmentInvalid   = errors.New("invalid segment name")
+	ErrNotReady         = errors.New("rendition not ready")
+)
+
+var (
+	renditionNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	segmentNameRe   = regexp.MustCompile(`^seg\d+\.ts$`)
+)
+
+const (
+	playlistName  = "index.m3u8"
+	segmentFormat = "seg%d.ts"
+
+	// ffmpeg encodes aac at this bitrate when none is set
+	defaultAudioBitrate = 128000
+	// a stream without audio in its first second of video is transcoded without audio
+	probeDuration = 1000
+	maxProbeNum   = 1024
+
+	// IdleTimeout is how long a transcoder runs without a request for its playlists
+	// or segments, the players poll the playlists every few seconds
+	IdleTimeout = time.Minute
+)
+
+// Transcoder is a channel player that pipes the live stream into an ffmpeg worker
+// producing one hls rendition per ladder entry. The worker starts once the first
+// packets tell whether the stream has audio.
+type Transcoder struct {
+	ladder []conf.TranscodeRendition
+	ffmpeg string
+	dir    string
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+	// unix nano of the last request for a playlist or segment
+	lastAccess atomic.Int64
+
+	mu         sync.Mutex
+	closed     bool
+	started    bool
+	hasAudio   bool
+	probe      []*av.Packet
+	probeVideo bool
+	probeStart uint32
+	flv        *httpflv.HttpFlvWriter
+	stdin      io.WriteCloser
+}
+
+func New() (*Transcoder, error) {
+	cfg := conf.Conf.Transcode
+	if !cfg.Enable {
+		return nil, ErrNotEnabled
+	}
+	if len(cfg.Ladder) == 0 {
+		return nil, ErrEmptyLadder
+	}
+	for _, r := range cfg.Ladder {
+		if !renditionNameRe.MatchString(r.Name) || r.Height == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrRenditionInvalid, r.Name)
+		}
+		// the bitrates are the bandwidth of the renditions in the master playlist
+		if parseBitrate(r.VideoBitrate) <= 0 {
+			return nil, fmt.Errorf("%w: %s: video bitrate is required", ErrRenditionInvalid, r.Name)
+		}
+		if r.AudioBitrate != "" && parseBitrate(r.AudioBitrate) <= 0 {
+			return nil, fmt.Errorf("%w: %s: invalid audio bitrate", ErrRenditionInvalid, r.Name)
+		}
+	}
+
+	dir, err := os.MkdirTemp(cfg.WorkDir, "synctv-transcode-")
+	if err != nil {
+		return nil, fmt.Errorf("create transcode work dir error: %w", err)
+	}
+	for _, r := range cfg.Ladder {
+		if err := os.Mkdir(filepath.Join(dir, r.Name), 0o755); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("create rendition dir error: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Transcoder{
+		ladder: cfg.Ladder,
+		ffmpeg: cfg.FFmpegPath,
+		dir:    dir,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	t.touch()
+	return t, nil
+}
+
+// start runs ffmpeg and feeds it the probed packets, the caller holds mu.
+func (t *Transcoder) start(hasAudio bool) error {
+	//nolint:gosec
+	cmd := exec.CommandContext(t.ctx, t.ffmpeg, ffmpegArgs(t.dir, t.ladder, hasAudio)...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start ffmpeg error: %w", err)
+	}
+	t.started = true
+	t.hasAudio = hasAudio
+	t.stdin = stdin
+	t.flv = httpflv.NewHttpFLVWriter(stdin)
+	go func() {
+		if err := t.flv.SendPacket(t.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			log.Errorf("transcode: feed ffmpeg error: %v", err)
+		}
+		t.Close()
+	}()
+	go func() {
+		defer close(t.done)
+		if err := cmd.Wait(); err != nil && t.ctx.Err() == nil {
+			log.Errorf("transcode: ffmpeg exited: %v", err)
+		}
+		t.Close()
+		os.RemoveAll(t.dir)
+	}()
+	for _, p := range t.probe {
+		if err := t.flv.Write(p); err != nil {
+			return err
+		}
+	}
+	t.probe = nil
+	return nil
+}
+
+func ffmpegArgs(dir string, ladder []conf.TranscodeRendition, hasAudio bool) []string {
+	args := []string{
+		"-hide_banner", "-loglevel", "error",
+		"-f", "flv", "-i", "pipe:0",
+	}
+	streamMap := make([]string, 0, len(ladder))
+	for i, r := range ladder {
+		args = append(args,
+			"-map", "0:v:0",
+			fmt.Sprintf("-filter:v:%d", i), fmt.Sprintf("scale=-2:%d", r.Height),
+			fmt.Sprintf("-b:v:%d", i), r.VideoBitrate,
+		)
+		if !hasAudio {
+			streamMap = append(streamMap, fmt.Sprintf("v:%d,name:%s", i, r.Name))
+			continue
+		}
+		args = append(args, "-map", "0:a:0")
+		if r.AudioBitrate != "" {
+			args = append(args, fmt.Sprintf("-b:a:%d", i), r.AudioBitrate)
+		}
+		streamMap = append(streamMap, fmt.Sprintf("v:%d,a:%d,name:%s", i, i, r.Name))
+	}
+	args = append(args,
+		"-c:v", "libx264", "-preset", "veryfast", "-tune", "zerolatency",
+		"-g", "60", "-sc_threshold", "0",
+	)
+	if hasAudio {
+		args = append(args, "-c:a", "aac", "-ac", "2")
+	}
+	return append(args,
+		"-f", "hls",
+		"-hls_time", "2",
+		"-hls_list_size", "6",
+		"-hls_flags", "delete_segments+independent_segments",
+		"-hls_segment_filename", filepath.Join(dir, "%v", segmentFormat),
+		"-var_stream_map", strings.Join(streamMap, " "),
+		filepath.Join(dir, "%v", playlistName),
+	)
+}
+
+func (t *Transcoder) Write(p *av.Packet) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return av.ErrClosed
+	}
+	if t.started {
+		return t.flv.Write(p)
+	}
+	t.probe = append(t.probe, p)
+	if p.IsVideo {
+		if vh, ok := p.Header.(av.VideoPacketHeader); ok && !vh.IsSeq() && !t.probeVideo {
+			t.probeVideo = true
+			t.probeStart = p.TimeStamp
+		}
+	}
+	switch {
+	case p.IsAudio:
+		return t.start(true)
+	case t.probeVideo && p.TimeStamp-t.probeStart >= probeDuration,
+		len(t.probe) >= maxProbeNum:
+		return t.start(false)
+	}
+	return nil
+}
+
+func (t *Transcoder) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	t.cancel()
+	if !t.started {
+		t.probe = nil
+		close(t.done)
+		os.RemoveAll(t.dir)
+		return nil
+	}
+	t.flv.Close()
+	t.stdin.Close()
+	return nil
+}
+
+func (t *Transcoder) Closed() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Done is closed once the transcoder is closed and its ffmpeg worker has exited.
+func (t *Transcoder) Done() <-chan struct{} {
+	return t.done
+}
+
+func (t *Transcoder) touch() {
+	t.lastAccess.Store(time.Now().UnixNano())
+}
+
+// Idle returns how long ago the playlists or segments were last requested.
+func (t *Transcoder) Idle() time.Duration {
+	return time.Since(time.Unix(0, t.lastAccess.Load()))
+}
+
+func (t *Transcoder) rendition(name string) (conf.TranscodeRendition, bool) {
+	for _, r := range t.ladder {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return conf.TranscodeRendition{}, false
+}
+
+// MasterPlaylist builds the multivariant playlist, uri maps a rendition name to its media playlist url.
+func (t *Transcoder) MasterPlaylist(uri func(name string) string) []byte {
+	t.touch()
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-INDEPENDENT-SEGMENTS\n")
+	t.mu.Lock()
+	// the audio is counted until the stream is known to have none
+	hasAudio := !t.started || t.hasAudio
+	t.mu.Unlock()
+	for _, r := range t.ladder {
+		bandwidth := parseBitrate(r.VideoBitrate)
+		if hasAudio {
+			if audio := parseBitrate(r.AudioBitrate); audio > 0 {
+				bandwidth += audio
+			} else {
+				bandwidth += defaultAudioBitrate
+			}
+		}
+		// assume 16:9, ffmpeg keeps the width even with scale=-2
+		width := (int(r.Height)*16/9 + 1) &^ 1
+		fmt.Fprintf(&b,
+			"#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d\n%s\n",
+			bandwidth, width, r.Height, uri(r.Name),
+		)
+	}
+	return []byte(b.String())
+}
+
+// MediaPlaylist returns the live playlist of a rendition, segURI maps a segment name to its url.
+func (t *Transcoder) MediaPlaylist(name string, segURI func(seg string) string) ([]byte, error) {
+	t.touch()
+	if _, ok := t.rendition(name); !ok {
+		return nil, ErrRenditionInvalid
+	}
+	b, err := os.ReadFile(filepath.Join(t.dir, name, playlistName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotReady
+		}
+		return nil, err
+	}
+	s, err := m3u8.ReplaceM3u8Segments(string(b), func(segmentURL string) (string, error) {
+		return segURI(filepath.Base(segmentURL)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return []byte(s), nil
+}
+
+func (t *Transcoder) Segment(name, seg string) ([]byte, error) {
+	t.touch()
+	if _, ok := t.rendition(name); !ok {
+		return nil, ErrRenditionInvalid
+	}
+	if !segmentNameRe.MatchString(seg) {
+		return nil, ErrSegmentInvalid
+	}
+	b, err := os.ReadFile(filepath.Join(t.dir, name, seg))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotReady
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+// parseBitrate parses ffmpeg style bitrates such as 128k or 5M into bits per second.
+func parseBitrate(s string) int {
+	if s == "" {
+		return 0
+	}
+	mul := 1
+	switch s[len(s)-1] {
+	case 'k', 'K':
+		mul, s = 1000, s[:len(s)-1]
+	case 'm', 'M':
+		mul, s = 1000000, s[:len(s)-1]
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(f * float64(mul))
+}
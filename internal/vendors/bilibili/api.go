@@ -0,0 +1,78 @@
+// Package bilibili covers the parts of the bilibili web api that the bilibili service of
+// the vendors module does not.
+package bilibili
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/go-uhc"
+)
+
+type APIError struct {
+	Message string
+	Code    int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bilibili api error: code %d: %s", e.Code, e.Message)
+}
+
+type apiResp[T any] struct {
+	Data    T      `json:"data"`
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+}
+
+// get calls an api of the bilibili web, a non zero code of the response is an error.
+func get[T any](
+	ctx context.Context,
+	cookies []*http.Cookie,
+	api string,
+	query url.Values,
+	referer string,
+	out *T,
+) error {
+	if query != nil {
+		api += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, api, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", utils.UA)
+	req.Header.Set("Referer", referer)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	resp, err := uhc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &APIError{Code: resp.StatusCode, Message: resp.Status}
+	}
+	var data apiResp[T]
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return err
+	}
+	if data.Code != 0 {
+		return &APIError{Code: data.Code, Message: data.Message}
+	}
+	*out = data.Data
+	return nil
+}
+
+// fixScheme adds the scheme to the protocol relative urls of covers.
+func fixScheme(u string) string {
+	if strings.HasPrefix(u, "//") {
+		return "https:" + u
+	}
+	return u
+}
@@ -0,0 +1,290 @@
+package bilibili
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gorilla/websocket"
+	json "github.com/json-iterator/go"
+	log "github.com/sirupsen/logrus"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/PeterChen1997/vendors/api/bilibili"
+)
+
+type command uint32
+
+const (
+	CmdHeartbeat      command = 2
+	CmdHeartbeatReply command = 3
+	CmdNormal         command = 5
+	CmdAuth           command = 7
+	CmdAuthReply      command = 8
+)
+
+type header struct {
+	TotalSize uint32
+	HeaderLen uint16
+	Version   uint16
+	Command   command
+	Sequence  uint32
+}
+
+var headerLen = binary.Size(header{})
+
+func (h *header) Marshal() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, headerLen))
+	err := binary.Write(buf, binary.BigEndian, h)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (h *header) Unmarshal(data []byte) error {
+	return binary.Read(bytes.NewReader(data), binary.BigEndian, h)
+}
+
+//nolint:gosec
+func newHeader(size uint32, command command, sequence uint32) header {
+	h := header{
+		TotalSize: uint32(headerLen) + size,
+		HeaderLen: uint16(headerLen),
+		Command:   command,
+		Sequence:  sequence,
+	}
+	switch command {
+	case CmdHeartbeat, CmdAuth:
+		h.Version = 1
+	}
+	return h
+}
+
+type verifyHello struct {
+	UID      int64  `json:"uid"`
+	RoomID   uint64 `json:"roomid,omitempty"`
+	ProtoVer int    `json:"protover,omitempty"`
+	Platform string `json:"platform,omitempty"`
+	Type     int    `json:"type,omitempty"`
+	Key      string `json:"key,omitempty"`
+}
+
+func newVerifyHello(roomID uint64, key string) *verifyHello {
+	return &verifyHello{
+		RoomID:   roomID,
+		ProtoVer: 3,
+		Platform: "web",
+		Type:     2,
+		Key:      key,
+	}
+}
+
+//nolint:gosec
+func writeVerifyHello(conn *websocket.Conn, hello *verifyHello) error {
+	msg, err := json.Marshal(hello)
+	if err != nil {
+		return err
+	}
+	header := newHeader(uint32(len(msg)), CmdAuth, 1)
+	headerBytes, err := header.Marshal()
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.BinaryMessage, append(headerBytes, msg...))
+}
+
+func writeHeartbeat(conn *websocket.Conn, sequence uint32) error {
+	header := newHeader(0, CmdHeartbeat, sequence)
+	headerBytes, err := header.Marshal()
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.BinaryMessage, headerBytes)
+}
+
+type replyCmd struct {
+	Cmd string `json:"cmd"`
+}
+
+type danmuMsg struct {
+	Info []any `json:"info"`
+}
+
+// Danmu is a live comment, info[1] of a DANMU_MSG is the content and info[2] the
+// [uid, name, ...] of the sender.
+type Danmu struct {
+	Content   string
+	Sender    string
+	SenderUID int64
+}
+
+func parseDanmu(data []byte) (*Danmu, error) {
+	msg := danmuMsg{}
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+	if len(msg.Info) < 2 {
+		return nil, errors.New("danmu info is too short")
+	}
+	content, ok := msg.Info[1].(string)
+	if !ok {
+		return nil, errors.New("content is not string")
+	}
+	d := &Danmu{Content: content}
+	if len(msg.Info) > 2 {
+		if user, ok := msg.Info[2].([]any); ok && len(user) > 1 {
+			if uid, ok := user[0].(float64); ok {
+				d.SenderUID = int64(uid)
+			}
+			d.Sender, _ = user[1].(string)
+		}
+	}
+	return d, nil
+}
+
+// splitPackets splits the packets a compressed message is made of, each has a header.
+func splitPackets(data []byte) [][]byte {
+	var packets [][]byte
+	for len(data) >= headerLen {
+		h := header{}
+		if err := h.Unmarshal(data[:headerLen]); err != nil {
+			break
+		}
+		size := int(h.TotalSize)
+		if size < headerLen || size > len(data) {
+			break
+		}
+		packets = append(packets, data[headerLen:size])
+		data = data[size:]
+	}
+	return packets
+}
+
+// StreamLiveDanmu connects to the danmu server of a live room and calls handler for
+// every danmu until ctx is done or the connection fails, info is the danmu server and
+// token of the room.
+func StreamLiveDanmu(
+	ctx context.Context,
+	info *bilibili.GetLiveDanmuInfoResp,
+	roomID uint64,
+	handler func(danmu *Danmu) error,
+) error {
+	if len(info.GetHostList()) == 0 {
+		return errors.New("no host list")
+	}
+	wssHost := info.GetHostList()[0].GetHost()
+	wssPort := info.GetHostList()[0].GetWssPort()
+
+	conn, wsresp, err := websocket.
+		DefaultDialer.
+		DialContext(
+			ctx,
+			fmt.Sprintf("wss://%s/sub", net.JoinHostPort(wssHost, strconv.Itoa(int(wssPort)))),
+			http.Header{
+				"User-Agent": []string{utils.UA},
+				"Origin":     []string{"https://live.bilibili.com"},
+			},
+		)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	defer wsresp.Body.Close()
+
+	err = writeVerifyHello(conn, newVerifyHello(roomID, info.GetToken()))
+	if err != nil {
+		return err
+	}
+
+	_, _, err = conn.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Second * 20)
+		defer ticker.Stop()
+		sequence := uint32(1)
+		for {
+			select {
+			case <-ctx.Done():
+				// unblock the read below
+				conn.Close()
+				return
+			case <-ticker.C:
+				sequence++
+				if err := writeHeartbeat(conn, sequence); err != nil {
+					log.Errorf("write heartbeat error: %v", err)
+				}
+			}
+		}
+	}()
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if len(message) < headerLen {
+			continue
+		}
+		header := header{}
+		err = header.Unmarshal(message[:headerLen])
+		if err != nil {
+			return err
+		}
+		if header.Command == CmdHeartbeatReply {
+			continue
+		}
+		data := message[headerLen:]
+		packets := [][]byte{data}
+		switch header.Version {
+		case 2:
+			// zlib
+			zlibReader, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return err
+			}
+			data, err = io.ReadAll(zlibReader)
+			zlibReader.Close()
+			if err != nil {
+				return err
+			}
+			packets = splitPackets(data)
+		case 3:
+			// brotli
+			data, err = io.ReadAll(brotli.NewReader(bytes.NewReader(data)))
+			if err != nil {
+				return err
+			}
+			packets = splitPackets(data)
+		}
+		for _, p := range packets {
+			reply := replyCmd{}
+			if err := json.Unmarshal(p, &reply); err != nil {
+				return err
+			}
+			switch reply.Cmd {
+			case "DANMU_MSG":
+				danmu, err := parseDanmu(p)
+				if err != nil {
+					return err
+				}
+				_ = handler(danmu)
+			case "DM_INTERACTION":
+			}
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package bilibili
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	favoriteFoldersURL = "https://api.bilibili.com/x/v3/fav/folder/created/list-all"
+	favoriteVideosURL  = "https://api.bilibili.com/x/v3/fav/resource/list"
+	watchLaterURL      = "https://api.bilibili.com/x/v2/history/toview"
+
+	// the page size of a favorites folder is capped at 20 by bilibili
+	favoritePageSize = 20
+	// favorite resource of a video, the others are audio and bangumi
+	favoriteTypeVideo = 2
+)
+
+var ErrNotLogin = errors.New("bilibili is not logged in")
+
+// Video is the first part of a video of a favorites folder or the watch later list.
+type Video struct {
+	Bvid  string
+	Title string
+	Cover string
+	Cid   uint64
+	// Duration in seconds
+	Duration float64
+}
+
+type FavoriteFolder struct {
+	Title      string `json:"title"`
+	ID         uint64 `json:"id"`
+	MediaCount int    `json:"mediaCount"`
+}
+
+// mid is the id of the logged in user, it is kept in a cookie by the login.
+func mid(cookies []*http.Cookie) (string, error) {
+	for _, c := range cookies {
+		if c.Name == "DedeUserID" && c.Value != "" {
+			return c.Value, nil
+		}
+	}
+	return "", ErrNotLogin
+}
+
+// FavoriteFolders returns the favorites folders created by the logged in user.
+func FavoriteFolders(ctx context.Context, cookies []*http.Cookie) ([]*FavoriteFolder, error) {
+	upMid, err := mid(cookies)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("up_mid", upMid)
+	var data struct {
+		List []struct {
+			Title      string `json:"title"`
+			ID         uint64 `json:"id"`
+			MediaCount int    `json:"media_count"`
+		} `json:"list"`
+	}
+	err = get(ctx, cookies, favoriteFoldersURL, query, "https://space.bilibili.com/", &data)
+	if err != nil {
+		return nil, err
+	}
+	folders := make([]*FavoriteFolder, len(data.List))
+	for i, f := range data.List {
+		folders[i] = &FavoriteFolder{
+			Title:      f.Title,
+			ID:         f.ID,
+			MediaCount: f.MediaCount,
+		}
+	}
+	return folders, nil
+}
+
+type favoriteVideosData struct {
+	Info struct {
+		Title string `json:"title"`
+	} `json:"info"`
+	Medias []struct {
+		Bvid  string `json:"bvid"`
+		Title string `json:"title"`
+		Cover string `json:"cover"`
+		Ugc   struct {
+			FirstCid uint64 `json:"first_cid"`
+		} `json:"ugc"`
+		Type     int     `json:"type"`
+		Attr     int     `json:"attr"`
+		Duration float64 `json:"duration"`
+	} `json:"medias"`
+	HasMore bool `json:"has_more"`
+}
+
+// FavoriteVideos returns the title of a favorites folder and up to limit of its videos,
+// videos that were deleted by their uploader and other kinds of resources are skipped.
+func FavoriteVideos(
+	ctx context.Context,
+	cookies []*http.Cookie,
+	mediaID uint64,
+	limit int,
+) (string, []*Video, error) {
+	var (
+		title  string
+		videos []*Video
+	)
+	for pn := 1; len(videos) < limit; pn++ {
+		query := url.Values{}
+		query.Set("media_id", strconv.FormatUint(mediaID, 10))
+		query.Set("pn", strconv.Itoa(pn))
+		query.Set("ps", strconv.Itoa(favoritePageSize))
+		query.Set("platform", "web")
+		var data favoriteVideosData
+		err := get(ctx, cookies, favoriteVideosURL, query, "https://space.bilibili.com/", &data)
+		if err != nil {
+			return "", nil, err
+		}
+		title = data.Info.Title
+		for _, m := range data.Medias {
+			if m.Type != favoriteTypeVideo || m.Attr != 0 || m.Bvid == "" ||
+				m.Ugc.FirstCid == 0 {
+				continue
+			}
+			videos = append(videos, &Video{
+				Bvid:     m.Bvid,
+				Title:    m.Title,
+				Cover:    fixScheme(m.Cover),
+				Cid:      m.Ugc.FirstCid,
+				Duration: m.Duration,
+			})
+		}
+		if !data.HasMore {
+			break
+		}
+	}
+	if len(videos) > limit {
+		videos = videos[:limit]
+	}
+	return title, videos, nil
+}
+
+// WatchLater returns the watch later list of the logged in user.
+func WatchLater(ctx context.Context, cookies []*http.Cookie) ([]*Video, error) {
+	if _, err := mid(cookies); err != nil {
+		return nil, err
+	}
+	var data struct {
+		List []struct {
+			Bvid     string  `json:"bvid"`
+			Title    string  `json:"title"`
+			Pic      string  `json:"pic"`
+			Cid      uint64  `json:"cid"`
+			Duration float64 `json:"duration"`
+		} `json:"list"`
+	}
+	err := get(ctx, cookies, watchLaterURL, nil, "https://www.bilibili.com/", &data)
+	if err != nil {
+		return nil, err
+	}
+	videos := make([]*Video, 0, len(data.List))
+	for _, v := range data.List {
+		if v.Bvid == "" || v.Cid == 0 {
+			continue
+		}
+		videos = append(videos, &Video{
+			Bvid:     v.Bvid,
+			Title:    v.Title,
+			Cover:    fixScheme(v.Pic),
+			Cid:      v.Cid,
+			Duration: v.Duration,
+		})
+	}
+	return videos, nil
+}
@@ -0,0 +1,82 @@
+package bilibili
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const searchURL = "https://api.bilibili.com/x/web-interface/search/type"
+
+type SearchVideo struct {
+	Bvid   string
+	Title  string
+	Author string
+	Cover  string
+	// Duration in seconds
+	Duration float64
+}
+
+type searchData struct {
+	Result []struct {
+		Bvid     string `json:"bvid"`
+		Title    string `json:"title"`
+		Author   string `json:"author"`
+		Pic      string `json:"pic"`
+		Duration string `json:"duration"`
+	} `json:"result"`
+	NumResults int `json:"numResults"`
+}
+
+// titles mark the matched keyword with <em class="keyword">
+var highlightTag = regexp.MustCompile(`</?em[^>]*>`)
+
+// SearchVideos searches the videos of bilibili, the cookies of a login avoid the risk
+// control of anonymous requests.
+func SearchVideos(
+	ctx context.Context,
+	cookies []*http.Cookie,
+	keyword string,
+	page int,
+) ([]*SearchVideo, int, error) {
+	query := url.Values{}
+	query.Set("search_type", "video")
+	query.Set("keyword", keyword)
+	query.Set("page", strconv.Itoa(page))
+	var data searchData
+	err := get(ctx, cookies, searchURL, query, "https://search.bilibili.com/", &data)
+	if err != nil {
+		return nil, 0, err
+	}
+	videos := make([]*SearchVideo, 0, len(data.Result))
+	for _, r := range data.Result {
+		if r.Bvid == "" {
+			continue
+		}
+		videos = append(videos, &SearchVideo{
+			Bvid:     r.Bvid,
+			Title:    html.UnescapeString(highlightTag.ReplaceAllString(r.Title, "")),
+			Author:   r.Author,
+			Cover:    fixScheme(r.Pic),
+			Duration: parseDuration(r.Duration),
+		})
+	}
+	return videos, data.NumResults, nil
+}
+
+// parseDuration parses the m:ss or h:mm:ss duration of a search result.
+func parseDuration(s string) float64 {
+	var d float64
+	for _, part := range strings.Split(s, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0
+		}
+		d = d*60 + float64(n)
+	}
+	return d
+}
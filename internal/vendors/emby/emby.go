@@ -0,0 +1,110 @@
+// Package emby reports playback to an emby server over its http api, which the emby
+// service of the vendors module does not cover. The service implements the same contract
+// a grpc vendor backend serves, so it can be used locally or registered in a backend
+// process.
+package emby
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	json "github.com/json-iterator/go"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/emby"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/go-uhc"
+)
+
+var _ pb.EmbyPlaybackServer = (*Service)(nil)
+
+type Service struct {
+	pb.UnimplementedEmbyPlaybackServer
+	client *http.Client
+}
+
+// NewService returns a service using client, or the shared uhc client when it is nil.
+func NewService(client *http.Client) *Service {
+	return &Service{client: client}
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("emby api error: status code %d: %s", e.StatusCode, e.Body)
+}
+
+func (s *Service) post(ctx context.Context, host, path, token string, body any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		strings.TrimRight(host, "/")+"/emby"+path,
+		bytes.NewReader(b),
+	)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Emby-Token", token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", utils.UA)
+
+	var resp *http.Response
+	if s.client != nil {
+		resp, err = s.client.Do(req)
+	} else {
+		resp, err = uhc.Do(req)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &APIError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+	return nil
+}
+
+func (s *Service) ReportPlayback(
+	ctx context.Context,
+	req *pb.ReportPlaybackReq,
+) (*pb.Empty, error) {
+	var path string
+	switch req.GetEvent() {
+	case pb.PlaybackEvent_PLAYBACK_START:
+		path = "/Sessions/Playing"
+	case pb.PlaybackEvent_PLAYBACK_STOP:
+		path = "/Sessions/Playing/Stopped"
+	default:
+		path = "/Sessions/Playing/Progress"
+	}
+	body := map[string]any{
+		"ItemId":        req.GetItemId(),
+		"PositionTicks": req.GetPositionTicks(),
+		"IsPaused":      req.GetIsPaused(),
+		"CanSeek":       true,
+		"PlayMethod":    "DirectStream",
+	}
+	if req.GetMediaSourceId() != "" {
+		body["MediaSourceId"] = req.GetMediaSourceId()
+	}
+	if req.GetPlaySessionId() != "" {
+		body["PlaySessionId"] = req.GetPlaySessionId()
+	}
+	if req.GetPlaybackRate() != 0 {
+		body["PlaybackRate"] = req.GetPlaybackRate()
+	}
+	if err := s.post(ctx, req.GetHost(), path, req.GetToken(), body); err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
@@ -0,0 +1,503 @@
+// Package jellyfin talks to a jellyfin server over its http api. The service implements
+// the same contract a grpc vendor backend serves, so it can be used locally or registered
+// in a backend process.
+package jellyfin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	json "github.com/json-iterator/go"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/jellyfin"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/go-uhc"
+)
+
+const (
+	clientName = "SyncTV"
+	deviceName = "SyncTV"
+	deviceID   = "synctv-server"
+	version    = "1.0.0"
+
+	// max parent items walked when building the breadcrumb of a folder
+	maxPathDepth = 16
+)
+
+var _ pb.JellyfinServer = (*Service)(nil)
+
+type Service struct {
+	pb.UnimplementedJellyfinServer
+	client *http.Client
+}
+
+// NewService returns a service using client, or the shared uhc client when it is nil.
+func NewService(client *http.Client) *Service {
+	return &Service{client: client}
+}
+
+// AuthorizationHeader is the value jellyfin expects in the Authorization header,
+// token may be empty before login.
+func AuthorizationHeader(token string) string {
+	h := fmt.Sprintf(
+		`MediaBrowser Client="%s", Device="%s", DeviceId="%s", Version="%s"`,
+		clientName, deviceName, deviceID, version,
+	)
+	if token != "" {
+		h += fmt.Sprintf(`, Token="%s"`, token)
+	}
+	return h
+}
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jellyfin api error: status code %d: %s", e.StatusCode, e.Body)
+}
+
+func (s *Service) do(
+	ctx context.Context,
+	method, host, path, token string,
+	query url.Values,
+	body, out any,
+) error {
+	u, err := url.Parse(strings.TrimRight(host, "/") + path)
+	if err != nil {
+		return err
+	}
+	if query != nil {
+		u.RawQuery = query.Encode()
+	}
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", AuthorizationHeader(token))
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", utils.UA)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	var resp *http.Response
+	if s.client != nil {
+		resp, err = s.client.Do(req)
+	} else {
+		resp, err = uhc.Do(req)
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &APIError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type authResult struct {
+	User struct {
+		ID string `json:"Id"`
+	} `json:"User"`
+	AccessToken string `json:"AccessToken"`
+	ServerID    string `json:"ServerId"`
+}
+
+func (s *Service) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginResp, error) {
+	var res authResult
+	err := s.do(ctx, http.MethodPost, req.GetHost(), "/Users/AuthenticateByName", "", nil,
+		map[string]string{
+			"Username": req.GetUsername(),
+			"Pw":       req.GetPassword(),
+		}, &res)
+	if err != nil {
+		return nil, err
+	}
+	if res.AccessToken == "" {
+		return nil, errors.New("jellyfin login failed: empty access token")
+	}
+	return &pb.LoginResp{
+		Token:    res.AccessToken,
+		UserId:   res.User.ID,
+		ServerId: res.ServerID,
+	}, nil
+}
+
+func (s *Service) Logout(ctx context.Context, req *pb.LogoutReq) (*pb.Empty, error) {
+	err := s.do(ctx, http.MethodPost, req.GetHost(), "/Sessions/Logout", req.GetToken(), nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (s *Service) Me(ctx context.Context, req *pb.MeReq) (*pb.MeResp, error) {
+	var res struct {
+		ID       string `json:"Id"`
+		Name     string `json:"Name"`
+		ServerID string `json:"ServerId"`
+	}
+	err := s.do(ctx, http.MethodGet, req.GetHost(), "/Users/Me", req.GetToken(), nil, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.MeResp{
+		Id:       res.ID,
+		Name:     res.Name,
+		ServerId: res.ServerID,
+	}, nil
+}
+
+func (s *Service) GetSystemInfo(
+	ctx context.Context,
+	req *pb.SystemInfoReq,
+) (*pb.SystemInfoResp, error) {
+	var res struct {
+		ID              string `json:"Id"`
+		ServerName      string `json:"ServerName"`
+		Version         string `json:"Version"`
+		ProductName     string `json:"ProductName"`
+		OperatingSystem string `json:"OperatingSystem"`
+		LocalAddress    string `json:"LocalAddress"`
+	}
+	err := s.do(ctx, http.MethodGet, req.GetHost(), "/System/Info", req.GetToken(), nil, nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SystemInfoResp{
+		Id:              res.ID,
+		ServerName:      res.ServerName,
+		Version:         res.Version,
+		ProductName:     res.ProductName,
+		OperatingSystem: res.OperatingSystem,
+		LocalAddress:    res.LocalAddress,
+	}, nil
+}
+
+type item struct {
+	ID             string `json:"Id"`
+	Name           string `json:"Name"`
+	Type           string `json:"Type"`
+	ParentID       string `json:"ParentId"`
+	CollectionType string `json:"CollectionType"`
+	RunTimeTicks   uint64 `json:"RunTimeTicks"`
+	IsFolder       bool   `json:"IsFolder"`
+}
+
+func (i *item) proto() *pb.Item {
+	return &pb.Item{
+		Id:             i.ID,
+		Name:           i.Name,
+		Type:           i.Type,
+		IsFolder:       i.IsFolder,
+		ParentId:       i.ParentID,
+		RunTimeTicks:   i.RunTimeTicks,
+		CollectionType: i.CollectionType,
+	}
+}
+
+type itemsResult struct {
+	Items            []*item `json:"Items"`
+	TotalRecordCount uint64  `json:"TotalRecordCount"`
+}
+
+func (s *Service) getItem(ctx context.Context, host, token, userID, itemID string) (*item, error) {
+	var res item
+	err := s.do(
+		ctx,
+		http.MethodGet,
+		host,
+		fmt.Sprintf("/Users/%s/Items/%s", url.PathEscape(userID), url.PathEscape(itemID)),
+		token,
+		nil,
+		nil,
+		&res,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (s *Service) GetItem(ctx context.Context, req *pb.GetItemReq) (*pb.Item, error) {
+	i, err := s.getItem(ctx, req.GetHost(), req.GetToken(), req.GetUserId(), req.GetItemId())
+	if err != nil {
+		return nil, err
+	}
+	return i.proto(), nil
+}
+
+// FsList lists the user views when path is empty, otherwise the children of the item.
+// A search term searches recursively below the item.
+func (s *Service) FsList(ctx context.Context, req *pb.FsListReq) (*pb.FsListResp, error) {
+	query := url.Values{}
+	query.Set("Fields", "ParentId")
+	if req.GetLimit() != 0 {
+		query.Set("StartIndex", strconv.FormatUint(req.GetStartIndex(), 10))
+		query.Set("Limit", strconv.FormatUint(req.GetLimit(), 10))
+	}
+	path := fmt.Sprintf("/Users/%s/Items", url.PathEscape(req.GetUserId()))
+	switch {
+	case req.GetSearchTerm() != "":
+		query.Set("SearchTerm", req.GetSearchTerm())
+		query.Set("Recursive", "true")
+		query.Set("IncludeItemTypes", "Movie,Episode,Video,MusicVideo,Series,Season,Folder,BoxSet")
+		if req.GetPath() != "" {
+			query.Set("ParentId", req.GetPath())
+		}
+	case req.GetPath() == "":
+		path = fmt.Sprintf("/Users/%s/Views", url.PathEscape(req.GetUserId()))
+	default:
+		query.Set("ParentId", req.GetPath())
+		query.Set("SortBy", "IsFolder,SortName")
+	}
+
+	var res itemsResult
+	if err := s.do(ctx, http.MethodGet, req.GetHost(), path, req.GetToken(), query, nil, &res); err != nil {
+		return nil, err
+	}
+
+	resp := &pb.FsListResp{
+		Items: make([]*pb.Item, len(res.Items)),
+		Total: res.TotalRecordCount,
+	}
+	for i, v := range res.Items {
+		resp.Items[i] = v.proto()
+	}
+
+	paths, err := s.paths(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Paths = paths
+	return resp, nil
+}
+
+// paths builds the breadcrumb from the root views down to the listed item.
+func (s *Service) paths(ctx context.Context, req *pb.FsListReq) ([]*pb.Path, error) {
+	paths := []*pb.Path{{Name: "", Path: ""}}
+	if req.GetPath() == "" {
+		return paths, nil
+	}
+	var chain []*pb.Path
+	id := req.GetPath()
+	for range maxPathDepth {
+		i, err := s.getItem(ctx, req.GetHost(), req.GetToken(), req.GetUserId(), id)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, &pb.Path{Name: i.Name, Path: i.ID})
+		// views are direct children of the hidden root folder
+		if i.ParentID == "" || i.CollectionType != "" {
+			break
+		}
+		id = i.ParentID
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		paths = append(paths, chain[i])
+	}
+	return paths, nil
+}
+
+type mediaStream struct {
+	Codec           string `json:"Codec"`
+	Language        string `json:"Language"`
+	Type            string `json:"Type"`
+	Title           string `json:"Title"`
+	DisplayTitle    string `json:"DisplayTitle"`
+	DisplayLanguage string `json:"DisplayLanguage"`
+	DeliveryURL     string `json:"DeliveryUrl"`
+	Index           uint64 `json:"Index"`
+	IsDefault       bool   `json:"IsDefault"`
+	IsExternal      bool   `json:"IsExternal"`
+}
+
+type mediaSource struct {
+	ID                   string         `json:"Id"`
+	Name                 string         `json:"Name"`
+	Container            string         `json:"Container"`
+	Protocol             string         `json:"Protocol"`
+	TranscodingURL       string         `json:"TranscodingUrl"`
+	MediaStreams         []*mediaStream `json:"MediaStreams"`
+	RunTimeTicks         uint64         `json:"RunTimeTicks"`
+	SupportsDirectPlay   bool           `json:"SupportsDirectPlay"`
+	SupportsDirectStream bool           `json:"SupportsDirectStream"`
+	SupportsTranscoding  bool           `json:"SupportsTranscoding"`
+}
+
+// deviceProfile asks for direct streams, or hls with h264/aac when transcoding is enabled.
+func deviceProfile(transcode bool) map[string]any {
+	profile := map[string]any{
+		"TranscodingProfiles": []map[string]any{
+			{
+				"Container":        "ts",
+				"Type":             "Video",
+				"VideoCodec":       "h264",
+				"AudioCodec":       "aac",
+				"Protocol":         "hls",
+				"Context":          "Streaming",
+				"MaxAudioChannels": "2",
+			},
+		},
+		"SubtitleProfiles": []map[string]any{
+			{"Format": "srt", "Method": "External"},
+			{"Format": "ass", "Method": "External"},
+			{"Format": "vtt", "Method": "External"},
+		},
+	}
+	if !transcode {
+		profile["DirectPlayProfiles"] = []map[string]any{
+			{"Type": "Video"},
+			{"Type": "Audio"},
+		}
+	}
+	return profile
+}
+
+func (s *Service) PlaybackInfo(
+	ctx context.Context,
+	req *pb.PlaybackInfoReq,
+) (*pb.PlaybackInfoResp, error) {
+	query := url.Values{}
+	query.Set("UserId", req.GetUserId())
+	body := map[string]any{
+		"UserId":               req.GetUserId(),
+		"DeviceProfile":        deviceProfile(req.GetEnableTranscode()),
+		"EnableDirectPlay":     !req.GetEnableTranscode(),
+		"EnableDirectStream":   !req.GetEnableTranscode(),
+		"EnableTranscoding":    req.GetEnableTranscode(),
+		"AutoOpenLiveStream":   true,
+		"AllowVideoStreamCopy": true,
+		"AllowAudioStreamCopy": true,
+	}
+	if req.GetMaxStreamingBitrate() != 0 {
+		body["MaxStreamingBitrate"] = req.GetMaxStreamingBitrate()
+	}
+	var res struct {
+		PlaySessionID string         `json:"PlaySessionId"`
+		MediaSources  []*mediaSource `json:"MediaSources"`
+	}
+	err := s.do(
+		ctx,
+		http.MethodPost,
+		req.GetHost(),
+		fmt.Sprintf("/Items/%s/PlaybackInfo", url.PathEscape(req.GetItemId())),
+		req.GetToken(),
+		query,
+		body,
+		&res,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.PlaybackInfoResp{
+		PlaySessionId: res.PlaySessionID,
+		MediaSources:  make([]*pb.MediaSourceInfo, len(res.MediaSources)),
+	}
+	for i, ms := range res.MediaSources {
+		streams := make([]*pb.MediaStreamInfo, len(ms.MediaStreams))
+		for j, st := range ms.MediaStreams {
+			streams[j] = &pb.MediaStreamInfo{
+				Codec:           st.Codec,
+				Language:        st.Language,
+				Type:            st.Type,
+				Title:           st.Title,
+				DisplayTitle:    st.DisplayTitle,
+				DisplayLanguage: st.DisplayLanguage,
+				IsDefault:       st.IsDefault,
+				Index:           st.Index,
+				IsExternal:      st.IsExternal,
+				DeliveryUrl:     st.DeliveryURL,
+			}
+		}
+		resp.MediaSources[i] = &pb.MediaSourceInfo{
+			Id:                   ms.ID,
+			Name:                 ms.Name,
+			Container:            ms.Container,
+			Protocol:             ms.Protocol,
+			RunTimeTicks:         ms.RunTimeTicks,
+			TranscodingUrl:       ms.TranscodingURL,
+			SupportsDirectPlay:   ms.SupportsDirectPlay,
+			SupportsDirectStream: ms.SupportsDirectStream,
+			SupportsTranscoding:  ms.SupportsTranscoding,
+			MediaStreams:         streams,
+		}
+	}
+	return resp, nil
+}
+
+func (s *Service) DeleteActiveEncodings(
+	ctx context.Context,
+	req *pb.DeleteActiveEncodingsReq,
+) (*pb.Empty, error) {
+	query := url.Values{}
+	query.Set("deviceId", deviceID)
+	query.Set("playSessionId", req.GetPlaySessionId())
+	err := s.do(
+		ctx,
+		http.MethodDelete,
+		req.GetHost(),
+		"/Videos/ActiveEncodings",
+		req.GetToken(),
+		query,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (s *Service) ReportPlayback(
+	ctx context.Context,
+	req *pb.ReportPlaybackReq,
+) (*pb.Empty, error) {
+	var path string
+	switch req.GetEvent() {
+	case pb.PlaybackEvent_PLAYBACK_START:
+		path = "/Sessions/Playing"
+	case pb.PlaybackEvent_PLAYBACK_STOP:
+		path = "/Sessions/Playing/Stopped"
+	default:
+		path = "/Sessions/Playing/Progress"
+	}
+	body := map[string]any{
+		"ItemId":        req.GetItemId(),
+		"MediaSourceId": req.GetMediaSourceId(),
+		"PlaySessionId": req.GetPlaySessionId(),
+		"PositionTicks": req.GetPositionTicks(),
+		"IsPaused":      req.GetIsPaused(),
+		"CanSeek":       true,
+		"PlayMethod":    "DirectStream",
+	}
+	if req.GetPlaybackRate() != 0 {
+		body["PlaybackRate"] = req.GetPlaybackRate()
+	}
+	err := s.do(ctx, http.MethodPost, req.GetHost(), path, req.GetToken(), nil, body, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
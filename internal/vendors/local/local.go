@@ -0,0 +1,260 @@
+// Package local scans media files below a directory of the server.
+package local
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidPath = errors.New("invalid local library path")
+
+var (
+	videoExts = []string{
+		".mp4", ".m4v", ".mkv", ".webm", ".mov", ".avi", ".flv", ".wmv",
+		".ts", ".m2ts", ".mpg", ".mpeg", ".3gp", ".ogv",
+	}
+	audioExts    = []string{".mp3", ".flac", ".m4a", ".aac", ".ogg", ".opus", ".wav"}
+	subtitleExts = []string{".srt", ".ass", ".ssa", ".vtt"}
+)
+
+func hasExt(exts []string, name string) bool {
+	return slices.Contains(exts, strings.ToLower(path.Ext(name)))
+}
+
+// IsMedia reports whether the file name has a video or audio extension.
+func IsMedia(name string) bool {
+	return hasExt(videoExts, name) || hasExt(audioExts, name)
+}
+
+// Subtitle is a sidecar subtitle next to a media file with the same base name,
+// e.g. movie.mkv -> movie.srt or movie.zh.ass.
+type Subtitle struct {
+	// Path is relative to the library root
+	Path string `json:"path"`
+	// Name is the part between the media base name and the extension, or the file name
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type File struct {
+	ModTime time.Time
+	// Path is relative to the library root and always uses slashes
+	Path      string
+	Name      string
+	Subtitles []*Subtitle
+	Size      int64
+	IsDir     bool
+}
+
+// Dir is the parent directory of the file, the root is the empty string.
+func (f *File) Dir() string {
+	return Dir(f.Path)
+}
+
+// Dir is the parent directory of a path relative to the root, the root is the empty
+// string.
+func Dir(p string) string {
+	d := path.Dir(p)
+	if d == "." || d == "/" {
+		return ""
+	}
+	return d
+}
+
+// CleanPath cleans a path relative to the library root, it never escapes the root.
+func CleanPath(p string) (string, error) {
+	if strings.Contains(p, "\\") {
+		return "", ErrInvalidPath
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if seg == ".." {
+			return "", ErrInvalidPath
+		}
+	}
+	return strings.TrimPrefix(path.Clean("/"+p), "/"), nil
+}
+
+// InDir reports whether the cleaned path p is dir itself or below it.
+func InDir(dir, p string) bool {
+	return dir == "" || p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+func hidden(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+// Scan walks root and returns its directories and media files, hidden entries are
+// skipped and symlinks are not followed.
+func Scan(ctx context.Context, root string) ([]*File, error) {
+	var (
+		files []*File
+		// subtitles by directory, matched to the media files once the walk is done
+		subtitles = map[string][]string{}
+	)
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if hidden(d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch {
+		case d.IsDir():
+		case !d.Type().IsRegular():
+			return nil
+		case hasExt(subtitleExts, d.Name()):
+			subtitles[Dir(rel)] = append(subtitles[Dir(rel)], rel)
+			return nil
+		case !IsMedia(d.Name()):
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		f := &File{
+			ModTime: info.ModTime(),
+			Path:    rel,
+			Name:    d.Name(),
+			IsDir:   d.IsDir(),
+		}
+		if !f.IsDir {
+			f.Size = info.Size()
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		if !f.IsDir {
+			f.Subtitles = matchSubtitles(f.Name, subtitles[f.Dir()])
+		}
+	}
+	return files, nil
+}
+
+func matchSubtitles(name string, candidates []string) []*Subtitle {
+	base := strings.TrimSuffix(name, path.Ext(name))
+	var subtitles []*Subtitle
+	for _, c := range candidates {
+		subName := path.Base(c)
+		ext := path.Ext(subName)
+		rest, ok := strings.CutPrefix(strings.TrimSuffix(subName, ext), base)
+		if !ok {
+			continue
+		}
+		rest = strings.Trim(rest, ".-_ ")
+		if rest == "" {
+			rest = subName
+		}
+		subtitles = append(subtitles, &Subtitle{
+			Path: c,
+			Name: rest,
+			Type: strings.TrimPrefix(strings.ToLower(ext), "."),
+		})
+	}
+	return subtitles
+}
+
+// Fingerprint hashes the modification times of all directories below root. Adding,
+// removing or renaming an entry changes the mtime of its directory, so a changed
+// fingerprint means the library needs a rescan.
+func Fingerprint(ctx context.Context, root string) (string, error) {
+	h := fnv.New64a()
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if p != root && hidden(d.Name()) {
+			return filepath.SkipDir
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		_, _ = h.Write([]byte(p))
+		_, _ = h.Write([]byte(strconv.FormatInt(info.ModTime().UnixNano(), 10)))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return strconv.FormatUint(h.Sum64(), 16), nil
+}
+
+// Open opens a regular file below root, the path can not escape the root, not even
+// through symlinks.
+func Open(root, p string) (*os.File, fs.FileInfo, error) {
+	p, err := CleanPath(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	if p == "" {
+		return nil, nil, ErrInvalidPath
+	}
+	r, err := os.OpenRoot(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+	f, err := r.Open(filepath.FromSlash(p))
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, nil, ErrInvalidPath
+	}
+	return f, info, nil
+}
+
+// CheckRoot checks that root is an absolute path of an existing directory.
+func CheckRoot(root string) error {
+	if !filepath.IsAbs(root) {
+		return errors.New("library root must be an absolute path")
+	}
+	info, err := os.Stat(root)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return errors.New("library root is not a directory")
+	}
+	return nil
+}
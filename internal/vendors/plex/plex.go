@@ -0,0 +1,354 @@
+// Package plex talks to a plex media server over its http api.
+package plex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/go-uhc"
+)
+
+const (
+	product          = "SyncTV"
+	clientIdentifier = "synctv-server"
+
+	SectionsPrefix = "library/sections"
+	MetadataPrefix = "library/metadata"
+
+	StreamTypeVideo    = 1
+	StreamTypeAudio    = 2
+	StreamTypeSubtitle = 3
+)
+
+var ErrInvalidPath = errors.New("invalid plex path")
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("plex api error: status code %d: %s", e.StatusCode, e.Body)
+}
+
+type Client struct {
+	host  string
+	token string
+}
+
+func NewClient(host, token string) *Client {
+	return &Client{
+		host:  strings.TrimRight(host, "/"),
+		token: token,
+	}
+}
+
+func (c *Client) url(path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("X-Plex-Token", c.token)
+	return c.host + path + "?" + query.Encode()
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(path, query), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", utils.UA)
+	req.Header.Set("X-Plex-Product", product)
+	req.Header.Set("X-Plex-Client-Identifier", clientIdentifier)
+	resp, err := uhc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return &APIError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type Stream struct {
+	Key          string `json:"key"`
+	Codec        string `json:"codec"`
+	Language     string `json:"language"`
+	Title        string `json:"title"`
+	DisplayTitle string `json:"displayTitle"`
+	ID           uint64 `json:"id"`
+	StreamType   int    `json:"streamType"`
+	Selected     bool   `json:"selected"`
+}
+
+type Part struct {
+	Key       string    `json:"key"`
+	File      string    `json:"file"`
+	Container string    `json:"container"`
+	Stream    []*Stream `json:"Stream"`
+	ID        uint64    `json:"id"`
+	Duration  uint64    `json:"duration"`
+}
+
+type Media struct {
+	Container       string  `json:"container"`
+	VideoResolution string  `json:"videoResolution"`
+	VideoCodec      string  `json:"videoCodec"`
+	Part            []*Part `json:"Part"`
+	ID              uint64  `json:"id"`
+}
+
+// Name describes the media version, e.g. "1080 h264 mkv".
+func (m *Media) Name() string {
+	return strings.Join(
+		nonEmpty(m.VideoResolution, m.VideoCodec, m.Container),
+		" ",
+	)
+}
+
+func nonEmpty(s ...string) []string {
+	r := s[:0]
+	for _, v := range s {
+		if v != "" {
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+type Metadata struct {
+	RatingKey        string   `json:"ratingKey"`
+	Key              string   `json:"key"`
+	Title            string   `json:"title"`
+	Type             string   `json:"type"`
+	ParentRatingKey  string   `json:"parentRatingKey"`
+	ParentTitle      string   `json:"parentTitle"`
+	GrandparentTitle string   `json:"grandparentTitle"`
+	Media            []*Media `json:"Media"`
+	LibrarySectionID uint64   `json:"librarySectionID"`
+	Duration         uint64   `json:"duration"`
+	Index            uint64   `json:"index"`
+}
+
+// IsFolder reports whether the item has children instead of media.
+func (m *Metadata) IsFolder() bool {
+	switch m.Type {
+	case "show", "season", "artist", "album", "collection", "photoalbum":
+		return true
+	}
+	return len(m.Media) == 0
+}
+
+// Path is the item path below the server, see ParsePath.
+func (m *Metadata) Path() string {
+	return MetadataPrefix + "/" + m.RatingKey
+}
+
+type Directory struct {
+	Key   string `json:"key"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+}
+
+type Hub struct {
+	Type     string      `json:"type"`
+	Metadata []*Metadata `json:"Metadata"`
+}
+
+type MediaContainer struct {
+	MachineIdentifier    string       `json:"machineIdentifier"`
+	Version              string       `json:"version"`
+	FriendlyName         string       `json:"friendlyName"`
+	Title1               string       `json:"title1"`
+	Title2               string       `json:"title2"`
+	LibrarySectionTitle  string       `json:"librarySectionTitle"`
+	GrandparentRatingKey string       `json:"grandparentRatingKey"`
+	GrandparentTitle     string       `json:"grandparentTitle"`
+	Directory            []*Directory `json:"Directory"`
+	Metadata             []*Metadata  `json:"Metadata"`
+	Hub                  []*Hub       `json:"Hub"`
+	Size                 uint64       `json:"size"`
+	TotalSize            uint64       `json:"totalSize"`
+	LibrarySectionID     uint64       `json:"librarySectionID"`
+}
+
+type response struct {
+	MediaContainer MediaContainer `json:"MediaContainer"`
+}
+
+func (c *Client) container(
+	ctx context.Context,
+	path string,
+	query url.Values,
+) (*MediaContainer, error) {
+	var resp response
+	if err := c.get(ctx, path, query, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.MediaContainer, nil
+}
+
+// Identity returns the server machine identifier and version, it does not need a token
+// but is also used to check the token.
+func (c *Client) Identity(ctx context.Context) (*MediaContainer, error) {
+	return c.container(ctx, "/identity", nil)
+}
+
+// Server returns the server name and version, it fails when the token is invalid.
+func (c *Client) Server(ctx context.Context) (*MediaContainer, error) {
+	return c.container(ctx, "/", nil)
+}
+
+func (c *Client) Sections(ctx context.Context) ([]*Directory, error) {
+	mc, err := c.container(ctx, "/"+SectionsPrefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	return mc.Directory, nil
+}
+
+func pageQuery(start, size uint64) url.Values {
+	query := url.Values{}
+	if size != 0 {
+		query.Set("X-Plex-Container-Start", strconv.FormatUint(start, 10))
+		query.Set("X-Plex-Container-Size", strconv.FormatUint(size, 10))
+	}
+	return query
+}
+
+// Children lists a section or the children of a show, season or other folder.
+func (c *Client) Children(
+	ctx context.Context,
+	path string,
+	start, size uint64,
+) (*MediaContainer, error) {
+	prefix, key, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	var p string
+	switch prefix {
+	case SectionsPrefix:
+		p = fmt.Sprintf("/%s/%s/all", SectionsPrefix, url.PathEscape(key))
+	default:
+		p = fmt.Sprintf("/%s/%s/children", MetadataPrefix, url.PathEscape(key))
+	}
+	mc, err := c.container(ctx, p, pageQuery(start, size))
+	if err != nil {
+		return nil, err
+	}
+	if mc.TotalSize == 0 {
+		mc.TotalSize = mc.Size
+	}
+	return mc, nil
+}
+
+// Search searches movies, shows, seasons and episodes, in one section when sectionID
+// is not empty.
+func (c *Client) Search(
+	ctx context.Context,
+	query, sectionID string,
+	limit uint64,
+) ([]*Metadata, error) {
+	q := url.Values{}
+	q.Set("query", query)
+	if sectionID != "" {
+		q.Set("sectionId", sectionID)
+	}
+	if limit != 0 {
+		q.Set("limit", strconv.FormatUint(limit, 10))
+	}
+	mc, err := c.container(ctx, "/hubs/search", q)
+	if err != nil {
+		return nil, err
+	}
+	var items []*Metadata
+	for _, h := range mc.Hub {
+		switch h.Type {
+		case "movie", "show", "season", "episode", "clip":
+			items = append(items, h.Metadata...)
+		}
+	}
+	return items, nil
+}
+
+func (c *Client) Metadata(ctx context.Context, ratingKey string) (*Metadata, error) {
+	mc, err := c.container(
+		ctx,
+		fmt.Sprintf("/%s/%s", MetadataPrefix, url.PathEscape(ratingKey)),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(mc.Metadata) == 0 {
+		return nil, fmt.Errorf("plex item not found: %s", ratingKey)
+	}
+	return mc.Metadata[0], nil
+}
+
+// PartURL is the direct play url of a media part.
+func (c *Client) PartURL(part *Part) string {
+	return c.url(part.Key, nil)
+}
+
+// StreamURL is the url of an external stream, embedded streams have no key.
+func (c *Client) StreamURL(stream *Stream) string {
+	if stream.Key == "" {
+		return ""
+	}
+	return c.url(stream.Key, nil)
+}
+
+// TranscodeURL is the hls url of the universal transcoder for a media version.
+func (c *Client) TranscodeURL(ratingKey string, mediaIndex int, session string) string {
+	query := url.Values{}
+	query.Set("path", "/"+MetadataPrefix+"/"+ratingKey)
+	query.Set("mediaIndex", strconv.Itoa(mediaIndex))
+	query.Set("partIndex", "0")
+	query.Set("protocol", "hls")
+	query.Set("fastSeek", "1")
+	query.Set("directPlay", "0")
+	query.Set("directStream", "1")
+	query.Set("subtitles", "none")
+	query.Set("session", session)
+	query.Set("X-Plex-Session-Identifier", session)
+	query.Set("X-Plex-Product", product)
+	query.Set("X-Plex-Client-Identifier", clientIdentifier)
+	query.Set("X-Plex-Platform", "Chrome")
+	return c.url("/video/:/transcode/universal/start.m3u8", query)
+}
+
+func (c *Client) StopTranscode(ctx context.Context, session string) error {
+	query := url.Values{}
+	query.Set("session", session)
+	return c.get(ctx, "/video/:/transcode/universal/stop", query, nil)
+}
+
+// ParsePath splits an item path into its prefix and key, an empty path is the section
+// list.
+func ParsePath(path string) (prefix, key string, err error) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return "", "", nil
+	}
+	for _, p := range []string{SectionsPrefix, MetadataPrefix} {
+		if k, ok := strings.CutPrefix(path, p+"/"); ok && k != "" && !strings.Contains(k, "/") {
+			return p, k, nil
+		}
+	}
+	return "", "", ErrInvalidPath
+}
@@ -0,0 +1,38 @@
+package plugins
+
+import (
+	"context"
+
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+)
+
+type GRPCClient struct{ client pb.VendorPluginClient }
+
+var _ Interface = (*GRPCClient)(nil)
+
+func (c *GRPCClient) Info(ctx context.Context, req *pb.Empty) (*pb.InfoResp, error) {
+	return c.client.Info(ctx, req)
+}
+
+func (c *GRPCClient) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginResp, error) {
+	return c.client.Login(ctx, req)
+}
+
+func (c *GRPCClient) Me(ctx context.Context, req *pb.MeReq) (*pb.MeResp, error) {
+	return c.client.Me(ctx, req)
+}
+
+func (c *GRPCClient) List(ctx context.Context, req *pb.ListReq) (*pb.ListResp, error) {
+	return c.client.List(ctx, req)
+}
+
+func (c *GRPCClient) Resolve(ctx context.Context, req *pb.ResolveReq) (*pb.ResolveResp, error) {
+	return c.client.Resolve(ctx, req)
+}
+
+func (c *GRPCClient) Subtitles(
+	ctx context.Context,
+	req *pb.SubtitlesReq,
+) (*pb.SubtitlesResp, error) {
+	return c.client.Subtitles(ctx, req)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	plugin "github.com/hashicorp/go-plugin"
+	"github.com/PeterChen1997/synctv/internal/vendors/plugins"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+)
+
+// A vendor plugin serving the videos of a json index file, e.g.
+//
+//	[
+//	  {"name": "Movies", "children": [
+//	    {"name": "Big Buck Bunny", "url": "https://example.com/bbb.mp4",
+//	     "subtitles": [{"name": "en", "url": "https://example.com/bbb.en.vtt"}]}
+//	  ]}
+//	]
+//
+// go build -o index ./internal/vendors/plugins/example/example_index
+//
+// mv index {data-dir}/plugins/vendor/index
+//
+// config.yaml:
+//
+// vendor_plugins:
+//   - plugin_file: plugins/vendor/index
+type IndexVendor struct{}
+
+type entry struct {
+	Name      string   `json:"name"`
+	URL       string   `json:"url"`
+	Type      string   `json:"type"`
+	Children  []*entry `json:"children"`
+	Subtitles []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+		Type string `json:"type"`
+	} `json:"subtitles"`
+}
+
+// credential is stored by synctv and passed back on every call.
+type credential struct {
+	Index string `json:"index"`
+	Token string `json:"token"`
+}
+
+func (c *credential) headers() map[string]string {
+	if c.Token == "" {
+		return nil
+	}
+	return map[string]string{"Authorization": "Bearer " + c.Token}
+}
+
+func decodeCredential(s string) (*credential, error) {
+	var c credential
+	if err := json.Unmarshal([]byte(s), &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (c *credential) fetch(ctx context.Context) ([]*entry, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Index, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.headers() {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch index: status code %d", resp.StatusCode)
+	}
+	var entries []*entry
+	return entries, json.NewDecoder(resp.Body).Decode(&entries)
+}
+
+// find walks the index along a path of child indexes, e.g. "0/3".
+func find(entries []*entry, path string) (*entry, []*entry, error) {
+	var cur *entry
+	for _, seg := range strings.Split(strings.Trim(path, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		var i int
+		if _, err := fmt.Sscanf(seg, "%d", &i); err != nil || i < 0 || i >= len(entries) {
+			return nil, nil, errors.New("invalid path")
+		}
+		cur = entries[i]
+		entries = cur.Children
+	}
+	return cur, entries, nil
+}
+
+func (p *IndexVendor) Info(_ context.Context, _ *pb.Empty) (*pb.InfoResp, error) {
+	return &pb.InfoResp{
+		Name:  "index",
+		Title: "JSON Index",
+		LoginFields: []*pb.LoginField{
+			{Name: "index", Label: "Index URL"},
+			{Name: "token", Label: "Bearer Token", Secret: true, Optional: true},
+		},
+	}, nil
+}
+
+func (p *IndexVendor) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginResp, error) {
+	c := &credential{
+		Index: req.GetFields()["index"],
+		Token: req.GetFields()["token"],
+	}
+	if _, err := c.fetch(ctx); err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	id := sha256.Sum256([]byte(c.Index))
+	return &pb.LoginResp{
+		ServerId:   hex.EncodeToString(id[:16]),
+		Name:       c.Index,
+		Credential: string(b),
+	}, nil
+}
+
+func (p *IndexVendor) Me(ctx context.Context, req *pb.MeReq) (*pb.MeResp, error) {
+	c, err := decodeCredential(req.GetCredential())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := c.fetch(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.MeResp{Name: c.Index}, nil
+}
+
+func (p *IndexVendor) List(ctx context.Context, req *pb.ListReq) (*pb.ListResp, error) {
+	c, err := decodeCredential(req.GetCredential())
+	if err != nil {
+		return nil, err
+	}
+	entries, err := c.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dir := strings.Trim(req.GetPath(), "/")
+	_, children, err := find(entries, dir)
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListResp{}
+	walked := ""
+	root := entries
+	for _, seg := range strings.Split(dir, "/") {
+		if seg == "" {
+			continue
+		}
+		e, _, _ := find(root, seg)
+		walked = strings.TrimPrefix(walked+"/"+seg, "/")
+		resp.Paths = append(resp.Paths, &pb.Path{Name: e.Name, Path: walked})
+		root = e.Children
+	}
+	var items []*pb.Item
+	for i, e := range children {
+		if req.GetKeyword() != "" &&
+			!strings.Contains(strings.ToLower(e.Name), strings.ToLower(req.GetKeyword())) {
+			continue
+		}
+		items = append(items, &pb.Item{
+			Name:  e.Name,
+			Path:  strings.TrimPrefix(fmt.Sprintf("%s/%d", dir, i), "/"),
+			IsDir: e.URL == "",
+		})
+	}
+	resp.Total = uint64(len(items))
+	if req.GetSize() != 0 {
+		start := min((max(req.GetPage(), 1)-1)*req.GetSize(), uint64(len(items)))
+		items = items[start:min(start+req.GetSize(), uint64(len(items)))]
+	}
+	resp.Items = items
+	return resp, nil
+}
+
+func (p *IndexVendor) resolve(
+	ctx context.Context,
+	cred, path string,
+) (*credential, *entry, error) {
+	c, err := decodeCredential(cred)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := c.fetch(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, _, err := find(entries, path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e == nil || e.URL == "" {
+		return nil, nil, errors.New("not a video")
+	}
+	return c, e, nil
+}
+
+func (p *IndexVendor) Resolve(ctx context.Context, req *pb.ResolveReq) (*pb.ResolveResp, error) {
+	c, e, err := p.resolve(ctx, req.GetCredential(), req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResolveResp{
+		Sources: []*pb.Source{
+			{Name: e.Name, Url: e.URL, Type: e.Type, Headers: c.headers()},
+		},
+	}, nil
+}
+
+func (p *IndexVendor) Subtitles(
+	ctx context.Context,
+	req *pb.SubtitlesReq,
+) (*pb.SubtitlesResp, error) {
+	c, e, err := p.resolve(ctx, req.GetCredential(), req.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.SubtitlesResp{}
+	for _, s := range e.Subtitles {
+		resp.Subtitles = append(resp.Subtitles, &pb.Subtitle{
+			Name:    s.Name,
+			Url:     s.URL,
+			Type:    s.Type,
+			Headers: c.headers(),
+		})
+	}
+	return resp, nil
+}
+
+func main() {
+	pluginMap := map[string]plugin.Plugin{
+		"Vendor": &plugins.VendorPlugin{Impl: &IndexVendor{}},
+	}
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: plugins.HandshakeConfig,
+		Plugins:         pluginMap,
+		GRPCServer:      plugin.DefaultGRPCServer,
+	})
+}
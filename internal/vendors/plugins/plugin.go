@@ -0,0 +1,103 @@
+// Package plugins loads media vendors from external binaries over hashicorp go-plugin,
+// the same way oauth2 providers are loaded.
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-plugin"
+	"github.com/PeterChen1997/synctv/internal/sysnotify"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+	"google.golang.org/grpc"
+)
+
+// Interface is implemented by vendor plugins, the messages are described in
+// proto/vendors/plugin/plugin.proto.
+type Interface interface {
+	Info(ctx context.Context, req *pb.Empty) (*pb.InfoResp, error)
+	Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginResp, error)
+	Me(ctx context.Context, req *pb.MeReq) (*pb.MeResp, error)
+	List(ctx context.Context, req *pb.ListReq) (*pb.ListResp, error)
+	Resolve(ctx context.Context, req *pb.ResolveReq) (*pb.ResolveResp, error)
+	Subtitles(ctx context.Context, req *pb.SubtitlesReq) (*pb.SubtitlesResp, error)
+}
+
+var nameReg = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,32}$`)
+
+func InitVendorPlugins(name string, arg []string, logger hclog.Logger) error {
+	client := NewVendorPlugin(name, arg, logger)
+	err := sysnotify.RegisterSysNotifyTask(
+		0,
+		sysnotify.NewSysNotifyTask("vendor-plugin", sysnotify.NotifyTypeEXIT, func() error {
+			client.Kill()
+			return nil
+		}),
+	)
+	if err != nil {
+		return err
+	}
+	c, err := client.Client()
+	if err != nil {
+		return err
+	}
+	i, err := c.Dispense("Vendor")
+	if err != nil {
+		return err
+	}
+	impl, ok := i.(Interface)
+	if !ok {
+		return fmt.Errorf("%s not implement vendor plugin interface", name)
+	}
+	info, err := impl.Info(context.Background(), &pb.Empty{})
+	if err != nil {
+		return fmt.Errorf("get vendor plugin info error: %w", err)
+	}
+	if !nameReg.MatchString(info.GetName()) {
+		return fmt.Errorf("invalid vendor plugin name: %q", info.GetName())
+	}
+	return RegisterVendor(info, impl)
+}
+
+var HandshakeConfig = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "VENDOR_PLUGIN",
+	MagicCookieValue: "synctv",
+}
+
+var pluginMap = map[string]plugin.Plugin{
+	"Vendor": &VendorPlugin{},
+}
+
+type VendorPlugin struct {
+	plugin.Plugin
+	Impl Interface
+}
+
+func (p *VendorPlugin) GRPCServer(_ *plugin.GRPCBroker, s *grpc.Server) error {
+	pb.RegisterVendorPluginServer(s, &GRPCServer{Impl: p.Impl})
+	return nil
+}
+
+func (p *VendorPlugin) GRPCClient(
+	_ context.Context,
+	_ *plugin.GRPCBroker,
+	c *grpc.ClientConn,
+) (any, error) {
+	return &GRPCClient{client: pb.NewVendorPluginClient(c)}, nil
+}
+
+func NewVendorPlugin(name string, arg []string, logger hclog.Logger) *plugin.Client {
+	return plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: HandshakeConfig,
+		Plugins:         pluginMap,
+		Cmd:             exec.Command(name, arg...),
+		AllowedProtocols: []plugin.Protocol{
+			plugin.ProtocolGRPC,
+		},
+		Logger: logger,
+	})
+}
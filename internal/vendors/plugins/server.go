@@ -0,0 +1,39 @@
+package plugins
+
+import (
+	"context"
+
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+)
+
+type GRPCServer struct {
+	pb.UnimplementedVendorPluginServer
+	Impl Interface
+}
+
+func (s *GRPCServer) Info(ctx context.Context, req *pb.Empty) (*pb.InfoResp, error) {
+	return s.Impl.Info(ctx, req)
+}
+
+func (s *GRPCServer) Login(ctx context.Context, req *pb.LoginReq) (*pb.LoginResp, error) {
+	return s.Impl.Login(ctx, req)
+}
+
+func (s *GRPCServer) Me(ctx context.Context, req *pb.MeReq) (*pb.MeResp, error) {
+	return s.Impl.Me(ctx, req)
+}
+
+func (s *GRPCServer) List(ctx context.Context, req *pb.ListReq) (*pb.ListResp, error) {
+	return s.Impl.List(ctx, req)
+}
+
+func (s *GRPCServer) Resolve(ctx context.Context, req *pb.ResolveReq) (*pb.ResolveResp, error) {
+	return s.Impl.Resolve(ctx, req)
+}
+
+func (s *GRPCServer) Subtitles(
+	ctx context.Context,
+	req *pb.SubtitlesReq,
+) (*pb.SubtitlesResp, error) {
+	return s.Impl.Subtitles(ctx, req)
+}
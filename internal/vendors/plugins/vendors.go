@@ -0,0 +1,54 @@
+package plugins
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+)
+
+// Vendor is a loaded vendor plugin.
+type Vendor struct {
+	Interface
+	Info *pb.InfoResp
+}
+
+var (
+	vendorsLock sync.RWMutex
+	vendors     = map[string]*Vendor{}
+)
+
+func RegisterVendor(info *pb.InfoResp, impl Interface) error {
+	vendorsLock.Lock()
+	defer vendorsLock.Unlock()
+	if _, ok := vendors[info.GetName()]; ok {
+		return fmt.Errorf("vendor plugin %s already loaded", info.GetName())
+	}
+	vendors[info.GetName()] = &Vendor{
+		Interface: impl,
+		Info:      info,
+	}
+	return nil
+}
+
+func LoadVendor(name string) (*Vendor, bool) {
+	vendorsLock.RLock()
+	defer vendorsLock.RUnlock()
+	v, ok := vendors[name]
+	return v, ok
+}
+
+func AllVendors() []*Vendor {
+	vendorsLock.RLock()
+	defer vendorsLock.RUnlock()
+	vs := make([]*Vendor, 0, len(vendors))
+	for _, v := range vendors {
+		vs = append(vs, v)
+	}
+	slices.SortFunc(vs, func(a, b *Vendor) int {
+		return strings.Compare(a.Info.GetName(), b.Info.GetName())
+	})
+	return vs
+}
@@ -0,0 +1,295 @@
+// Package subsonic browses and streams a server speaking the subsonic api, such as
+// navidrome, airsonic or subsonic itself.
+package subsonic
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/go-uhc"
+)
+
+const (
+	apiVersion = "1.16.1"
+	clientName = "synctv"
+)
+
+const (
+	KindArtist = "artist"
+	KindAlbum  = "album"
+	KindSong   = "song"
+)
+
+var ErrInvalidPath = errors.New("invalid subsonic path")
+
+type APIError struct {
+	Message string
+	Code    int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("subsonic error: code %d: %s", e.Code, e.Message)
+}
+
+type Client struct {
+	base     *url.URL
+	username string
+	password string
+}
+
+func NewClient(host, username, password string) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(host, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	return &Client{
+		base:     u,
+		username: username,
+		password: password,
+	}, nil
+}
+
+// ParsePath parses a path of the form kind/id, the empty path is the list of artists.
+func ParsePath(p string) (kind, id string, err error) {
+	p = strings.Trim(p, "/")
+	if p == "" {
+		return "", "", nil
+	}
+	kind, id, ok := strings.Cut(p, "/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return "", "", ErrInvalidPath
+	}
+	switch kind {
+	case KindArtist, KindAlbum, KindSong:
+		return kind, id, nil
+	default:
+		return "", "", ErrInvalidPath
+	}
+}
+
+func FormatPath(kind, id string) string {
+	return kind + "/" + id
+}
+
+// URL is the url of an api method with the auth of the user, it carries a token of the
+// password and must never be sent to the browser.
+func (c *Client) URL(method string, query url.Values) string {
+	salt := utils.RandString(12)
+	sum := md5.Sum([]byte(c.password + salt)) //nolint:gosec
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("u", c.username)
+	query.Set("t", hex.EncodeToString(sum[:]))
+	query.Set("s", salt)
+	query.Set("v", apiVersion)
+	query.Set("c", clientName)
+	u := *c.base
+	u.Path = strings.TrimRight(u.Path, "/") + "/rest/" + method + ".view"
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+type response struct {
+	Error *struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+	Status string `json:"status"`
+}
+
+func (c *Client) get(ctx context.Context, method string, query url.Values, key string, v any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("f", "json")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL(method, query), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", utils.UA)
+	resp, err := uhc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("subsonic error: status code %d: %s", resp.StatusCode, b)
+	}
+	var body map[string]json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("decode subsonic response: %w", err)
+	}
+	raw, ok := body["subsonic-response"]
+	if !ok {
+		return errors.New("not a subsonic response")
+	}
+	var r response
+	if err := json.Unmarshal(raw, &r); err != nil {
+		return err
+	}
+	if r.Status != "ok" {
+		if r.Error != nil {
+			return &APIError{Code: r.Error.Code, Message: r.Error.Message}
+		}
+		return fmt.Errorf("subsonic error: status %s", r.Status)
+	}
+	if v == nil {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return err
+	}
+	data, ok := fields[key]
+	if !ok {
+		return fmt.Errorf("subsonic response has no %s", key)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Ping checks the server is reachable and the credentials are right.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.get(ctx, "ping", nil, "", nil)
+}
+
+type Artist struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	CoverArt   string `json:"coverArt"`
+	AlbumCount int    `json:"albumCount"`
+}
+
+type Album struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Artist    string `json:"artist"`
+	CoverArt  string `json:"coverArt"`
+	SongCount int    `json:"songCount"`
+	// Duration in seconds
+	Duration int `json:"duration"`
+	Year     int `json:"year"`
+}
+
+type Song struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Album       string `json:"album"`
+	AlbumID     string `json:"albumId"`
+	Artist      string `json:"artist"`
+	ArtistID    string `json:"artistId"`
+	CoverArt    string `json:"coverArt"`
+	Suffix      string `json:"suffix"`
+	ContentType string `json:"contentType"`
+	// TranscodedSuffix is set when the server transcodes the stream of the song
+	TranscodedSuffix string `json:"transcodedSuffix"`
+	// Duration in seconds
+	Duration int   `json:"duration"`
+	Track    int   `json:"track"`
+	Size     int64 `json:"size"`
+}
+
+// Artists returns every artist of the server, the index groups them by their first
+// letter already in order.
+func (c *Client) Artists(ctx context.Context) ([]*Artist, error) {
+	var data struct {
+		Index []struct {
+			Artist []*Artist `json:"artist"`
+		} `json:"index"`
+	}
+	if err := c.get(ctx, "getArtists", nil, "artists", &data); err != nil {
+		return nil, err
+	}
+	var artists []*Artist
+	for _, i := range data.Index {
+		artists = append(artists, i.Artist...)
+	}
+	return artists, nil
+}
+
+// ArtistAlbums returns the name of an artist and its albums.
+func (c *Client) ArtistAlbums(ctx context.Context, id string) (string, []*Album, error) {
+	var data struct {
+		Name  string   `json:"name"`
+		Album []*Album `json:"album"`
+	}
+	query := url.Values{}
+	query.Set("id", id)
+	if err := c.get(ctx, "getArtist", query, "artist", &data); err != nil {
+		return "", nil, err
+	}
+	return data.Name, data.Album, nil
+}
+
+// AlbumSongs returns an album and its songs in track order.
+func (c *Client) AlbumSongs(ctx context.Context, id string) (*Album, []*Song, error) {
+	var data struct {
+		Album
+		Song []*Song `json:"song"`
+	}
+	query := url.Values{}
+	query.Set("id", id)
+	if err := c.get(ctx, "getAlbum", query, "album", &data); err != nil {
+		return nil, nil, err
+	}
+	return &data.Album, data.Song, nil
+}
+
+func (c *Client) Song(ctx context.Context, id string) (*Song, error) {
+	var song Song
+	query := url.Values{}
+	query.Set("id", id)
+	if err := c.get(ctx, "getSong", query, "song", &song); err != nil {
+		return nil, err
+	}
+	return &song, nil
+}
+
+// SearchSongs searches the songs by title, artist and album.
+func (c *Client) SearchSongs(ctx context.Context, keyword string, offset, count int) ([]*Song, error) {
+	var data struct {
+		Song []*Song `json:"song"`
+	}
+	query := url.Values{}
+	query.Set("query", keyword)
+	query.Set("artistCount", "0")
+	query.Set("albumCount", "0")
+	query.Set("songCount", strconv.Itoa(count))
+	query.Set("songOffset", strconv.Itoa(offset))
+	if err := c.get(ctx, "search3", query, "searchResult3", &data); err != nil {
+		return nil, err
+	}
+	return data.Song, nil
+}
+
+// StreamURL is the url of the audio of a song, transcoded as configured on the server.
+func (c *Client) StreamURL(id string) string {
+	query := url.Values{}
+	query.Set("id", id)
+	return c.URL("stream", query)
+}
+
+// CoverArtURL is the url of a cover art image, size 0 is the original size.
+func (c *Client) CoverArtURL(id string, size int) string {
+	query := url.Values{}
+	query.Set("id", id)
+	if size > 0 {
+		query.Set("size", strconv.Itoa(size))
+	}
+	return c.URL("getCoverArt", query)
+}
@@ -0,0 +1,315 @@
+// Package webdav browses a webdav server with PROPFIND requests.
+package webdav
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/zijiren233/go-uhc"
+)
+
+var ErrInvalidPath = errors.New("invalid webdav path")
+
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("webdav error: status code %d: %s", e.StatusCode, e.Body)
+}
+
+type Client struct {
+	base     *url.URL
+	username string
+	password string
+}
+
+// NewClient returns a client rooted at host, which may contain a path such as
+// https://cloud.example.com/remote.php/dav/files/user.
+func NewClient(host, username, password string) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(host, "/"))
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	return &Client{
+		base:     u,
+		username: username,
+		password: password,
+	}, nil
+}
+
+// Headers are the headers every request to the server needs, they must never be
+// sent to the browser.
+func (c *Client) Headers() map[string]string {
+	headers := map[string]string{
+		"User-Agent": utils.UA,
+	}
+	if c.username != "" || c.password != "" {
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString(
+			[]byte(c.username+":"+c.password),
+		)
+	}
+	return headers
+}
+
+// CleanPath cleans a path relative to the client root, it never escapes the root.
+func CleanPath(p string) (string, error) {
+	if strings.Contains(p, "\\") {
+		return "", ErrInvalidPath
+	}
+	for _, seg := range strings.Split(p, "/") {
+		if seg == ".." {
+			return "", ErrInvalidPath
+		}
+	}
+	return strings.TrimPrefix(path.Clean("/"+p), "/"), nil
+}
+
+// InDir reports whether the cleaned path p is dir itself or below it.
+func InDir(dir, p string) bool {
+	return dir == "" || p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// URL is the absolute url of a path relative to the client root.
+func (c *Client) URL(p string) (string, error) {
+	p, err := CleanPath(p)
+	if err != nil {
+		return "", err
+	}
+	u := *c.base
+	u.Path = strings.TrimRight(u.Path, "/") + "/" + p
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+type File struct {
+	ModTime time.Time
+	Name    string
+	// Path is relative to the client root
+	Path        string
+	ContentType string
+	Size        int64
+	IsDir       bool
+}
+
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"href"`
+		Propstat []struct {
+			Prop struct {
+				DisplayName   string `xml:"displayname"`
+				ContentType   string `xml:"getcontenttype"`
+				ContentLength string `xml:"getcontentlength"`
+				LastModified  string `xml:"getlastmodified"`
+				ResourceType  struct {
+					Collection *struct{} `xml:"collection"`
+				} `xml:"resourcetype"`
+			} `xml:"prop"`
+			Status string `xml:"status"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>` +
+	`<d:propfind xmlns:d="DAV:"><d:prop>` +
+	`<d:displayname/><d:resourcetype/><d:getcontenttype/>` +
+	`<d:getcontentlength/><d:getlastmodified/>` +
+	`</d:prop></d:propfind>`
+
+func (c *Client) propfind(ctx context.Context, p, depth string) ([]*File, error) {
+	p, err := CleanPath(p)
+	if err != nil {
+		return nil, err
+	}
+	u, err := c.URL(p)
+	if err != nil {
+		return nil, err
+	}
+	// collections are listed with a trailing slash, some servers redirect otherwise
+	if depth != "0" && !strings.HasSuffix(u, "/") {
+		u += "/"
+	}
+	req, err := http.NewRequestWithContext(ctx, "PROPFIND", u, strings.NewReader(propfindBody))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.Headers() {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Depth", depth)
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	resp, err := uhc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusMultiStatus && resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, &APIError{StatusCode: resp.StatusCode, Body: string(b)}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var ms multistatus
+	if err := xml.NewDecoder(bytes.NewReader(body)).Decode(&ms); err != nil {
+		return nil, fmt.Errorf("decode propfind response: %w", err)
+	}
+
+	files := make([]*File, 0, len(ms.Responses))
+	for _, r := range ms.Responses {
+		f, ok := c.parseResponse(r.Href)
+		if !ok {
+			continue
+		}
+		for _, ps := range r.Propstat {
+			if ps.Status != "" && !strings.Contains(ps.Status, " 200 ") {
+				continue
+			}
+			prop := ps.Prop
+			f.IsDir = prop.ResourceType.Collection != nil
+			f.ContentType = prop.ContentType
+			if prop.DisplayName != "" {
+				f.Name = prop.DisplayName
+			}
+			if prop.ContentLength != "" {
+				f.Size, _ = strconv.ParseInt(prop.ContentLength, 10, 64)
+			}
+			if prop.LastModified != "" {
+				f.ModTime, _ = http.ParseTime(prop.LastModified)
+			}
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// parseResponse maps an href of the multistatus response to a path relative to the
+// client root, hrefs outside the root are skipped.
+func (c *Client) parseResponse(href string) (*File, bool) {
+	hu, err := url.Parse(href)
+	if err != nil {
+		return nil, false
+	}
+	p, err := url.PathUnescape(hu.EscapedPath())
+	if err != nil {
+		return nil, false
+	}
+	root := strings.TrimRight(c.base.Path, "/")
+	rel, ok := strings.CutPrefix(strings.TrimRight(p, "/"), root)
+	if !ok || (rel != "" && !strings.HasPrefix(rel, "/")) {
+		return nil, false
+	}
+	rel = strings.Trim(rel, "/")
+	return &File{
+		Name: path.Base("/" + rel),
+		Path: rel,
+	}, true
+}
+
+// Stat returns the file or directory at p.
+func (c *Client) Stat(ctx context.Context, p string) (*File, error) {
+	files, err := c.propfind(ctx, p, "0")
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("webdav file not found: %s", p)
+	}
+	return files[0], nil
+}
+
+// List lists the directory at p, directories first and then by name.
+func (c *Client) List(ctx context.Context, p string) ([]*File, error) {
+	p, err := CleanPath(p)
+	if err != nil {
+		return nil, err
+	}
+	files, err := c.propfind(ctx, p, "1")
+	if err != nil {
+		return nil, err
+	}
+	children := files[:0]
+	for _, f := range files {
+		// the directory itself is part of the response
+		if f.Path == p {
+			continue
+		}
+		children = append(children, f)
+	}
+	sort.SliceStable(children, func(i, j int) bool {
+		if children[i].IsDir != children[j].IsDir {
+			return children[i].IsDir
+		}
+		return strings.ToLower(children[i].Name) < strings.ToLower(children[j].Name)
+	})
+	return children, nil
+}
+
+var subtitleExts = []string{".srt", ".ass", ".ssa", ".vtt"}
+
+// Subtitle is a sidecar subtitle of a video, found next to it with the same base name,
+// e.g. movie.mkv -> movie.srt or movie.zh.ass.
+type Subtitle struct {
+	File *File
+	// Name is the part between the video base name and the extension, or the file name
+	Name string
+	Type string
+}
+
+func (c *Client) Subtitles(ctx context.Context, p string) ([]*Subtitle, error) {
+	p, err := CleanPath(p)
+	if err != nil {
+		return nil, err
+	}
+	dir := path.Dir("/" + p)
+	base := path.Base(p)
+	base = strings.TrimSuffix(base, path.Ext(base))
+	files, err := c.List(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	var subtitles []*Subtitle
+	for _, f := range files {
+		if f.IsDir {
+			continue
+		}
+		ext := strings.ToLower(path.Ext(f.Name))
+		if !slices.Contains(subtitleExts, ext) {
+			continue
+		}
+		name := strings.TrimSuffix(f.Name, path.Ext(f.Name))
+		rest, ok := strings.CutPrefix(name, base)
+		if !ok {
+			continue
+		}
+		rest = strings.Trim(rest, ".-_ ")
+		if rest == "" {
+			rest = f.Name
+		}
+		subtitles = append(subtitles, &Subtitle{
+			File: f,
+			Name: rest,
+			Type: strings.TrimPrefix(ext, "."),
+		})
+	}
+	return subtitles, nil
+}
@@ -0,0 +1,310 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.0
+// 	protoc        v5.29.1
+// source: proto/vendors/emby/emby.proto
+
+package emby
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PlaybackEvent int32
+
+const (
+	PlaybackEvent_PLAYBACK_PROGRESS PlaybackEvent = 0
+	PlaybackEvent_PLAYBACK_START    PlaybackEvent = 1
+	PlaybackEvent_PLAYBACK_STOP     PlaybackEvent = 2
+)
+
+// Enum value maps for PlaybackEvent.
+var (
+	PlaybackEvent_name = map[int32]string{
+		0: "PLAYBACK_PROGRESS",
+		1: "PLAYBACK_START",
+		2: "PLAYBACK_STOP",
+	}
+	PlaybackEvent_value = map[string]int32{
+		"PLAYBACK_PROGRESS": 0,
+		"PLAYBACK_START":    1,
+		"PLAYBACK_STOP":     2,
+	}
+)
+
+func (x PlaybackEvent) Enum() *PlaybackEvent {
+	p := new(PlaybackEvent)
+	*p = x
+	return p
+}
+
+func (x PlaybackEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlaybackEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_vendors_emby_emby_proto_enumTypes[0].Descriptor()
+}
+
+func (PlaybackEvent) Type() protoreflect.EnumType {
+	return &file_proto_vendors_emby_emby_proto_enumTypes[0]
+}
+
+func (x PlaybackEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlaybackEvent.Descriptor instead.
+func (PlaybackEvent) EnumDescriptor() ([]byte, []int) {
+	return file_proto_vendors_emby_emby_proto_rawDescGZIP(), []int{0}
+}
+
+type ReportPlaybackReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	ItemId        string                 `protobuf:"bytes,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	MediaSourceId string                 `protobuf:"bytes,4,opt,name=media_source_id,json=mediaSourceId,proto3" json:"media_source_id,omitempty"`
+	PlaySessionId string                 `protobuf:"bytes,5,opt,name=play_session_id,json=playSessionId,proto3" json:"play_session_id,omitempty"`
+	Event         PlaybackEvent          `protobuf:"varint,6,opt,name=event,proto3,enum=api.emby.PlaybackEvent" json:"event,omitempty"`
+	PositionTicks uint64                 `protobuf:"varint,7,opt,name=position_ticks,json=positionTicks,proto3" json:"position_ticks,omitempty"`
+	IsPaused      bool                   `protobuf:"varint,8,opt,name=is_paused,json=isPaused,proto3" json:"is_paused,omitempty"`
+	PlaybackRate  float64                `protobuf:"fixed64,9,opt,name=playback_rate,json=playbackRate,proto3" json:"playback_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportPlaybackReq) Reset() {
+	*x = ReportPlaybackReq{}
+	mi := &file_proto_vendors_emby_emby_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPlaybackReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPlaybackReq) ProtoMessage() {}
+
+func (x *ReportPlaybackReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_emby_emby_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPlaybackReq.ProtoReflect.Descriptor instead.
+func (*ReportPlaybackReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_emby_emby_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReportPlaybackReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetMediaSourceId() string {
+	if x != nil {
+		return x.MediaSourceId
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetPlaySessionId() string {
+	if x != nil {
+		return x.PlaySessionId
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetEvent() PlaybackEvent {
+	if x != nil {
+		return x.Event
+	}
+	return PlaybackEvent_PLAYBACK_PROGRESS
+}
+
+func (x *ReportPlaybackReq) GetPositionTicks() uint64 {
+	if x != nil {
+		return x.PositionTicks
+	}
+	return 0
+}
+
+func (x *ReportPlaybackReq) GetIsPaused() bool {
+	if x != nil {
+		return x.IsPaused
+	}
+	return false
+}
+
+func (x *ReportPlaybackReq) GetPlaybackRate() float64 {
+	if x != nil {
+		return x.PlaybackRate
+	}
+	return 0
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_proto_vendors_emby_emby_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_emby_emby_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_emby_emby_proto_rawDescGZIP(), []int{1}
+}
+
+var File_proto_vendors_emby_emby_proto protoreflect.FileDescriptor
+
+var file_proto_vendors_emby_emby_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x2f,
+	0x65, 0x6d, 0x62, 0x79, 0x2f, 0x65, 0x6d, 0x62, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x08, 0x61, 0x70, 0x69, 0x2e, 0x65, 0x6d, 0x62, 0x79, 0x22, 0xbe, 0x02, 0x0a, 0x11, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68,
+	0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65,
+	0x6d, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d,
+	0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x6c,
+	0x61, 0x79, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x2d, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x65, 0x6d, 0x62, 0x79, 0x2e, 0x50, 0x6c, 0x61,
+	0x79, 0x62, 0x61, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69,
+	0x63, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x6f, 0x73, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x70,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x50,
+	0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63,
+	0x6b, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x70, 0x6c,
+	0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x61, 0x74, 0x65, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x2a, 0x4d, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b,
+	0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x50,
+	0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x53, 0x54, 0x4f, 0x50,
+	0x10, 0x02, 0x32, 0x4e, 0x0a, 0x0c, 0x45, 0x6d, 0x62, 0x79, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61,
+	0x63, 0x6b, 0x12, 0x3e, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79,
+	0x62, 0x61, 0x63, 0x6b, 0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x65, 0x6d, 0x62, 0x79, 0x2e,
+	0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x1a, 0x0f, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x65, 0x6d, 0x62, 0x79, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x42, 0x08, 0x5a, 0x06, 0x2e, 0x3b, 0x65, 0x6d, 0x62, 0x79, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_vendors_emby_emby_proto_rawDescOnce sync.Once
+	file_proto_vendors_emby_emby_proto_rawDescData = file_proto_vendors_emby_emby_proto_rawDesc
+)
+
+func file_proto_vendors_emby_emby_proto_rawDescGZIP() []byte {
+	file_proto_vendors_emby_emby_proto_rawDescOnce.Do(func() {
+		file_proto_vendors_emby_emby_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_vendors_emby_emby_proto_rawDescData)
+	})
+	return file_proto_vendors_emby_emby_proto_rawDescData
+}
+
+var file_proto_vendors_emby_emby_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_vendors_emby_emby_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_vendors_emby_emby_proto_goTypes = []any{
+	(PlaybackEvent)(0),        // 0: api.emby.PlaybackEvent
+	(*ReportPlaybackReq)(nil), // 1: api.emby.ReportPlaybackReq
+	(*Empty)(nil),             // 2: api.emby.Empty
+}
+var file_proto_vendors_emby_emby_proto_depIdxs = []int32{
+	0, // 0: api.emby.ReportPlaybackReq.event:type_name -> api.emby.PlaybackEvent
+	1, // 1: api.emby.EmbyPlayback.ReportPlayback:input_type -> api.emby.ReportPlaybackReq
+	2, // 2: api.emby.EmbyPlayback.ReportPlayback:output_type -> api.emby.Empty
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_vendors_emby_emby_proto_init() }
+func file_proto_vendors_emby_emby_proto_init() {
+	if File_proto_vendors_emby_emby_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_vendors_emby_emby_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_vendors_emby_emby_proto_goTypes,
+		DependencyIndexes: file_proto_vendors_emby_emby_proto_depIdxs,
+		EnumInfos:         file_proto_vendors_emby_emby_proto_enumTypes,
+		MessageInfos:      file_proto_vendors_emby_emby_proto_msgTypes,
+	}.Build()
+	File_proto_vendors_emby_emby_proto = out.File
+	file_proto_vendors_emby_emby_proto_rawDesc = nil
+	file_proto_vendors_emby_emby_proto_goTypes = nil
+	file_proto_vendors_emby_emby_proto_depIdxs = nil
+}
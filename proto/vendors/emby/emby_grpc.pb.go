@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: proto/vendors/emby/emby.proto
+
+package emby
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	EmbyPlayback_ReportPlayback_FullMethodName = "/api.emby.EmbyPlayback/ReportPlayback"
+)
+
+// EmbyPlaybackClient is the client API for EmbyPlayback service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EmbyPlaybackClient interface {
+	ReportPlayback(ctx context.Context, in *ReportPlaybackReq, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type embyPlaybackClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEmbyPlaybackClient(cc grpc.ClientConnInterface) EmbyPlaybackClient {
+	return &embyPlaybackClient{cc}
+}
+
+func (c *embyPlaybackClient) ReportPlayback(ctx context.Context, in *ReportPlaybackReq, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, EmbyPlayback_ReportPlayback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// EmbyPlaybackServer is the server API for EmbyPlayback service.
+// All implementations must embed UnimplementedEmbyPlaybackServer
+// for forward compatibility.
+type EmbyPlaybackServer interface {
+	ReportPlayback(context.Context, *ReportPlaybackReq) (*Empty, error)
+	mustEmbedUnimplementedEmbyPlaybackServer()
+}
+
+// UnimplementedEmbyPlaybackServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedEmbyPlaybackServer struct{}
+
+func (UnimplementedEmbyPlaybackServer) ReportPlayback(context.Context, *ReportPlaybackReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPlayback not implemented")
+}
+func (UnimplementedEmbyPlaybackServer) mustEmbedUnimplementedEmbyPlaybackServer() {}
+func (UnimplementedEmbyPlaybackServer) testEmbeddedByValue()                      {}
+
+// UnsafeEmbyPlaybackServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EmbyPlaybackServer will
+// result in compilation errors.
+type UnsafeEmbyPlaybackServer interface {
+	mustEmbedUnimplementedEmbyPlaybackServer()
+}
+
+func RegisterEmbyPlaybackServer(s grpc.ServiceRegistrar, srv EmbyPlaybackServer) {
+	// If the following call pancis, it indicates UnimplementedEmbyPlaybackServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&EmbyPlayback_ServiceDesc, srv)
+}
+
+func _EmbyPlayback_ReportPlayback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportPlaybackReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmbyPlaybackServer).ReportPlayback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmbyPlayback_ReportPlayback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmbyPlaybackServer).ReportPlayback(ctx, req.(*ReportPlaybackReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// EmbyPlayback_ServiceDesc is the grpc.ServiceDesc for EmbyPlayback service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EmbyPlayback_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.emby.EmbyPlayback",
+	HandlerType: (*EmbyPlaybackServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReportPlayback",
+			Handler:    _EmbyPlayback_ReportPlayback_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/vendors/emby/emby.proto",
+}
@@ -0,0 +1,1747 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.0
+// 	protoc        v5.29.1
+// source: proto/vendors/jellyfin/jellyfin.proto
+
+package jellyfin
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PlaybackEvent int32
+
+const (
+	PlaybackEvent_PLAYBACK_PROGRESS PlaybackEvent = 0
+	PlaybackEvent_PLAYBACK_START    PlaybackEvent = 1
+	PlaybackEvent_PLAYBACK_STOP     PlaybackEvent = 2
+)
+
+// Enum value maps for PlaybackEvent.
+var (
+	PlaybackEvent_name = map[int32]string{
+		0: "PLAYBACK_PROGRESS",
+		1: "PLAYBACK_START",
+		2: "PLAYBACK_STOP",
+	}
+	PlaybackEvent_value = map[string]int32{
+		"PLAYBACK_PROGRESS": 0,
+		"PLAYBACK_START":    1,
+		"PLAYBACK_STOP":     2,
+	}
+)
+
+func (x PlaybackEvent) Enum() *PlaybackEvent {
+	p := new(PlaybackEvent)
+	*p = x
+	return p
+}
+
+func (x PlaybackEvent) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlaybackEvent) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_vendors_jellyfin_jellyfin_proto_enumTypes[0].Descriptor()
+}
+
+func (PlaybackEvent) Type() protoreflect.EnumType {
+	return &file_proto_vendors_jellyfin_jellyfin_proto_enumTypes[0]
+}
+
+func (x PlaybackEvent) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlaybackEvent.Descriptor instead.
+func (PlaybackEvent) EnumDescriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{0}
+}
+
+type LoginReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginReq) Reset() {
+	*x = LoginReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginReq) ProtoMessage() {}
+
+func (x *LoginReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginReq.ProtoReflect.Descriptor instead.
+func (*LoginReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LoginReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *LoginReq) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *LoginReq) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type LoginResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ServerId      string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoginResp) Reset() {
+	*x = LoginResp{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoginResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoginResp) ProtoMessage() {}
+
+func (x *LoginResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoginResp.ProtoReflect.Descriptor instead.
+func (*LoginResp) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LoginResp) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *LoginResp) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *LoginResp) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+type LogoutReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutReq) Reset() {
+	*x = LogoutReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutReq) ProtoMessage() {}
+
+func (x *LogoutReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutReq.ProtoReflect.Descriptor instead.
+func (*LogoutReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LogoutReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *LogoutReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type MeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeReq) Reset() {
+	*x = MeReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeReq) ProtoMessage() {}
+
+func (x *MeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeReq.ProtoReflect.Descriptor instead.
+func (*MeReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MeReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *MeReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type MeResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ServerId      string                 `protobuf:"bytes,3,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeResp) Reset() {
+	*x = MeResp{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeResp) ProtoMessage() {}
+
+func (x *MeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeResp.ProtoReflect.Descriptor instead.
+func (*MeResp) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MeResp) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MeResp) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MeResp) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+type SystemInfoReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SystemInfoReq) Reset() {
+	*x = SystemInfoReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemInfoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemInfoReq) ProtoMessage() {}
+
+func (x *SystemInfoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemInfoReq.ProtoReflect.Descriptor instead.
+func (*SystemInfoReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SystemInfoReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *SystemInfoReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SystemInfoResp struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServerName      string                 `protobuf:"bytes,2,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	Version         string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	ProductName     string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	OperatingSystem string                 `protobuf:"bytes,5,opt,name=operating_system,json=operatingSystem,proto3" json:"operating_system,omitempty"`
+	LocalAddress    string                 `protobuf:"bytes,6,opt,name=local_address,json=localAddress,proto3" json:"local_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SystemInfoResp) Reset() {
+	*x = SystemInfoResp{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemInfoResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemInfoResp) ProtoMessage() {}
+
+func (x *SystemInfoResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemInfoResp.ProtoReflect.Descriptor instead.
+func (*SystemInfoResp) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SystemInfoResp) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SystemInfoResp) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *SystemInfoResp) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SystemInfoResp) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *SystemInfoResp) GetOperatingSystem() string {
+	if x != nil {
+		return x.OperatingSystem
+	}
+	return ""
+}
+
+func (x *SystemInfoResp) GetLocalAddress() string {
+	if x != nil {
+		return x.LocalAddress
+	}
+	return ""
+}
+
+type FsListReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	StartIndex    uint64                 `protobuf:"varint,5,opt,name=start_index,json=startIndex,proto3" json:"start_index,omitempty"`
+	Limit         uint64                 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	SearchTerm    string                 `protobuf:"bytes,7,opt,name=search_term,json=searchTerm,proto3" json:"search_term,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsListReq) Reset() {
+	*x = FsListReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsListReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsListReq) ProtoMessage() {}
+
+func (x *FsListReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsListReq.ProtoReflect.Descriptor instead.
+func (*FsListReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FsListReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *FsListReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *FsListReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FsListReq) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FsListReq) GetStartIndex() uint64 {
+	if x != nil {
+		return x.StartIndex
+	}
+	return 0
+}
+
+func (x *FsListReq) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FsListReq) GetSearchTerm() string {
+	if x != nil {
+		return x.SearchTerm
+	}
+	return ""
+}
+
+type Path struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Path) Reset() {
+	*x = Path{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Path) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Path) ProtoMessage() {}
+
+func (x *Path) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Path.ProtoReflect.Descriptor instead.
+func (*Path) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Path) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Path) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type Item struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type           string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	IsFolder       bool                   `protobuf:"varint,4,opt,name=is_folder,json=isFolder,proto3" json:"is_folder,omitempty"`
+	ParentId       string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	RunTimeTicks   uint64                 `protobuf:"varint,6,opt,name=run_time_ticks,json=runTimeTicks,proto3" json:"run_time_ticks,omitempty"`
+	CollectionType string                 `protobuf:"bytes,7,opt,name=collection_type,json=collectionType,proto3" json:"collection_type,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Item) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Item) GetIsFolder() bool {
+	if x != nil {
+		return x.IsFolder
+	}
+	return false
+}
+
+func (x *Item) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Item) GetRunTimeTicks() uint64 {
+	if x != nil {
+		return x.RunTimeTicks
+	}
+	return 0
+}
+
+func (x *Item) GetCollectionType() string {
+	if x != nil {
+		return x.CollectionType
+	}
+	return ""
+}
+
+type FsListResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []*Path                `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	Items         []*Item                `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Total         uint64                 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FsListResp) Reset() {
+	*x = FsListResp{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FsListResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FsListResp) ProtoMessage() {}
+
+func (x *FsListResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FsListResp.ProtoReflect.Descriptor instead.
+func (*FsListResp) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *FsListResp) GetPaths() []*Path {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *FsListResp) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *FsListResp) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetItemReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId        string                 `protobuf:"bytes,4,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemReq) Reset() {
+	*x = GetItemReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemReq) ProtoMessage() {}
+
+func (x *GetItemReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemReq.ProtoReflect.Descriptor instead.
+func (*GetItemReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetItemReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *GetItemReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetItemReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetItemReq) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+type MediaStreamInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Codec           string                 `protobuf:"bytes,1,opt,name=codec,proto3" json:"codec,omitempty"`
+	Language        string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Type            string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Title           string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	DisplayTitle    string                 `protobuf:"bytes,5,opt,name=display_title,json=displayTitle,proto3" json:"display_title,omitempty"`
+	DisplayLanguage string                 `protobuf:"bytes,6,opt,name=display_language,json=displayLanguage,proto3" json:"display_language,omitempty"`
+	IsDefault       bool                   `protobuf:"varint,7,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	Index           uint64                 `protobuf:"varint,8,opt,name=index,proto3" json:"index,omitempty"`
+	IsExternal      bool                   `protobuf:"varint,9,opt,name=is_external,json=isExternal,proto3" json:"is_external,omitempty"`
+	DeliveryUrl     string                 `protobuf:"bytes,10,opt,name=delivery_url,json=deliveryUrl,proto3" json:"delivery_url,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MediaStreamInfo) Reset() {
+	*x = MediaStreamInfo{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaStreamInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaStreamInfo) ProtoMessage() {}
+
+func (x *MediaStreamInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaStreamInfo.ProtoReflect.Descriptor instead.
+func (*MediaStreamInfo) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MediaStreamInfo) GetCodec() string {
+	if x != nil {
+		return x.Codec
+	}
+	return ""
+}
+
+func (x *MediaStreamInfo) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *MediaStreamInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *MediaStreamInfo) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MediaStreamInfo) GetDisplayTitle() string {
+	if x != nil {
+		return x.DisplayTitle
+	}
+	return ""
+}
+
+func (x *MediaStreamInfo) GetDisplayLanguage() string {
+	if x != nil {
+		return x.DisplayLanguage
+	}
+	return ""
+}
+
+func (x *MediaStreamInfo) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+func (x *MediaStreamInfo) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *MediaStreamInfo) GetIsExternal() bool {
+	if x != nil {
+		return x.IsExternal
+	}
+	return false
+}
+
+func (x *MediaStreamInfo) GetDeliveryUrl() string {
+	if x != nil {
+		return x.DeliveryUrl
+	}
+	return ""
+}
+
+type MediaSourceInfo struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Container            string                 `protobuf:"bytes,3,opt,name=container,proto3" json:"container,omitempty"`
+	Protocol             string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	RunTimeTicks         uint64                 `protobuf:"varint,5,opt,name=run_time_ticks,json=runTimeTicks,proto3" json:"run_time_ticks,omitempty"`
+	TranscodingUrl       string                 `protobuf:"bytes,6,opt,name=transcoding_url,json=transcodingUrl,proto3" json:"transcoding_url,omitempty"`
+	SupportsDirectPlay   bool                   `protobuf:"varint,7,opt,name=supports_direct_play,json=supportsDirectPlay,proto3" json:"supports_direct_play,omitempty"`
+	SupportsDirectStream bool                   `protobuf:"varint,8,opt,name=supports_direct_stream,json=supportsDirectStream,proto3" json:"supports_direct_stream,omitempty"`
+	SupportsTranscoding  bool                   `protobuf:"varint,9,opt,name=supports_transcoding,json=supportsTranscoding,proto3" json:"supports_transcoding,omitempty"`
+	MediaStreams         []*MediaStreamInfo     `protobuf:"bytes,10,rep,name=media_streams,json=mediaStreams,proto3" json:"media_streams,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *MediaSourceInfo) Reset() {
+	*x = MediaSourceInfo{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaSourceInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaSourceInfo) ProtoMessage() {}
+
+func (x *MediaSourceInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaSourceInfo.ProtoReflect.Descriptor instead.
+func (*MediaSourceInfo) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MediaSourceInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MediaSourceInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MediaSourceInfo) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *MediaSourceInfo) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *MediaSourceInfo) GetRunTimeTicks() uint64 {
+	if x != nil {
+		return x.RunTimeTicks
+	}
+	return 0
+}
+
+func (x *MediaSourceInfo) GetTranscodingUrl() string {
+	if x != nil {
+		return x.TranscodingUrl
+	}
+	return ""
+}
+
+func (x *MediaSourceInfo) GetSupportsDirectPlay() bool {
+	if x != nil {
+		return x.SupportsDirectPlay
+	}
+	return false
+}
+
+func (x *MediaSourceInfo) GetSupportsDirectStream() bool {
+	if x != nil {
+		return x.SupportsDirectStream
+	}
+	return false
+}
+
+func (x *MediaSourceInfo) GetSupportsTranscoding() bool {
+	if x != nil {
+		return x.SupportsTranscoding
+	}
+	return false
+}
+
+func (x *MediaSourceInfo) GetMediaStreams() []*MediaStreamInfo {
+	if x != nil {
+		return x.MediaStreams
+	}
+	return nil
+}
+
+type PlaybackInfoReq struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Host                string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token               string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	UserId              string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ItemId              string                 `protobuf:"bytes,4,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	EnableTranscode     bool                   `protobuf:"varint,5,opt,name=enable_transcode,json=enableTranscode,proto3" json:"enable_transcode,omitempty"`
+	MaxStreamingBitrate uint64                 `protobuf:"varint,6,opt,name=max_streaming_bitrate,json=maxStreamingBitrate,proto3" json:"max_streaming_bitrate,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *PlaybackInfoReq) Reset() {
+	*x = PlaybackInfoReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaybackInfoReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackInfoReq) ProtoMessage() {}
+
+func (x *PlaybackInfoReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackInfoReq.ProtoReflect.Descriptor instead.
+func (*PlaybackInfoReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PlaybackInfoReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PlaybackInfoReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PlaybackInfoReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaybackInfoReq) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *PlaybackInfoReq) GetEnableTranscode() bool {
+	if x != nil {
+		return x.EnableTranscode
+	}
+	return false
+}
+
+func (x *PlaybackInfoReq) GetMaxStreamingBitrate() uint64 {
+	if x != nil {
+		return x.MaxStreamingBitrate
+	}
+	return 0
+}
+
+type PlaybackInfoResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlaySessionId string                 `protobuf:"bytes,1,opt,name=play_session_id,json=playSessionId,proto3" json:"play_session_id,omitempty"`
+	MediaSources  []*MediaSourceInfo     `protobuf:"bytes,2,rep,name=media_sources,json=mediaSources,proto3" json:"media_sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaybackInfoResp) Reset() {
+	*x = PlaybackInfoResp{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaybackInfoResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaybackInfoResp) ProtoMessage() {}
+
+func (x *PlaybackInfoResp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaybackInfoResp.ProtoReflect.Descriptor instead.
+func (*PlaybackInfoResp) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PlaybackInfoResp) GetPlaySessionId() string {
+	if x != nil {
+		return x.PlaySessionId
+	}
+	return ""
+}
+
+func (x *PlaybackInfoResp) GetMediaSources() []*MediaSourceInfo {
+	if x != nil {
+		return x.MediaSources
+	}
+	return nil
+}
+
+type DeleteActiveEncodingsReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	PlaySessionId string                 `protobuf:"bytes,3,opt,name=play_session_id,json=playSessionId,proto3" json:"play_session_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteActiveEncodingsReq) Reset() {
+	*x = DeleteActiveEncodingsReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteActiveEncodingsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteActiveEncodingsReq) ProtoMessage() {}
+
+func (x *DeleteActiveEncodingsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteActiveEncodingsReq.ProtoReflect.Descriptor instead.
+func (*DeleteActiveEncodingsReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteActiveEncodingsReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *DeleteActiveEncodingsReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DeleteActiveEncodingsReq) GetPlaySessionId() string {
+	if x != nil {
+		return x.PlaySessionId
+	}
+	return ""
+}
+
+type ReportPlaybackReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Host          string                 `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	ItemId        string                 `protobuf:"bytes,3,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	MediaSourceId string                 `protobuf:"bytes,4,opt,name=media_source_id,json=mediaSourceId,proto3" json:"media_source_id,omitempty"`
+	PlaySessionId string                 `protobuf:"bytes,5,opt,name=play_session_id,json=playSessionId,proto3" json:"play_session_id,omitempty"`
+	Event         PlaybackEvent          `protobuf:"varint,6,opt,name=event,proto3,enum=api.jellyfin.PlaybackEvent" json:"event,omitempty"`
+	PositionTicks uint64                 `protobuf:"varint,7,opt,name=position_ticks,json=positionTicks,proto3" json:"position_ticks,omitempty"`
+	IsPaused      bool                   `protobuf:"varint,8,opt,name=is_paused,json=isPaused,proto3" json:"is_paused,omitempty"`
+	PlaybackRate  float64                `protobuf:"fixed64,9,opt,name=playback_rate,json=playbackRate,proto3" json:"playback_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportPlaybackReq) Reset() {
+	*x = ReportPlaybackReq{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportPlaybackReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportPlaybackReq) ProtoMessage() {}
+
+func (x *ReportPlaybackReq) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportPlaybackReq.ProtoReflect.Descriptor instead.
+func (*ReportPlaybackReq) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReportPlaybackReq) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetItemId() string {
+	if x != nil {
+		return x.ItemId
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetMediaSourceId() string {
+	if x != nil {
+		return x.MediaSourceId
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetPlaySessionId() string {
+	if x != nil {
+		return x.PlaySessionId
+	}
+	return ""
+}
+
+func (x *ReportPlaybackReq) GetEvent() PlaybackEvent {
+	if x != nil {
+		return x.Event
+	}
+	return PlaybackEvent_PLAYBACK_PROGRESS
+}
+
+func (x *ReportPlaybackReq) GetPositionTicks() uint64 {
+	if x != nil {
+		return x.PositionTicks
+	}
+	return 0
+}
+
+func (x *ReportPlaybackReq) GetIsPaused() bool {
+	if x != nil {
+		return x.IsPaused
+	}
+	return false
+}
+
+func (x *ReportPlaybackReq) GetPlaybackRate() float64 {
+	if x != nil {
+		return x.PlaybackRate
+	}
+	return 0
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vendors_jellyfin_jellyfin_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP(), []int{18}
+}
+
+var File_proto_vendors_jellyfin_jellyfin_proto protoreflect.FileDescriptor
+
+var file_proto_vendors_jellyfin_jellyfin_proto_rawDesc = []byte{
+	0x0a, 0x25, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x73, 0x2f,
+	0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2f, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c,
+	0x6c, 0x79, 0x66, 0x69, 0x6e, 0x22, 0x56, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x57, 0x0a,
+	0x09, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x35, 0x0a, 0x09, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74,
+	0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x31, 0x0a,
+	0x05, 0x4d, 0x65, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x22, 0x49, 0x0a, 0x06, 0x4d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x49, 0x64, 0x22, 0x39, 0x0a, 0x0d, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xce, 0x01, 0x0a, 0x0e, 0x53, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x64,
+	0x75, 0x63, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x53, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xba, 0x01, 0x0a, 0x09, 0x46, 0x73, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x74, 0x65,
+	0x72, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x54, 0x65, 0x72, 0x6d, 0x22, 0x2e, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x22, 0xc7, 0x01, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f, 0x66, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x46, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
+	0x24, 0x0a, 0x0e, 0x72, 0x75, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x69, 0x63, 0x6b,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65,
+	0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x22, 0x76,
+	0x0a, 0x0a, 0x46, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x28, 0x0a, 0x05,
+	0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52,
+	0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x28, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c,
+	0x79, 0x66, 0x69, 0x6e, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x68, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64,
+	0x22, 0xb6, 0x02, 0x0a, 0x0f, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x74, 0x69, 0x74, 0x6c,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79,
+	0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x73, 0x5f, 0x65, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x45, 0x78,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x6c, 0x69, 0x76, 0x65,
+	0x72, 0x79, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x6c, 0x69, 0x76, 0x65, 0x72, 0x79, 0x55, 0x72, 0x6c, 0x22, 0x9d, 0x03, 0x0a, 0x0f, 0x4d, 0x65,
+	0x64, 0x69, 0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12,
+	0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x24, 0x0a, 0x0e, 0x72,
+	0x75, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x75, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x54, 0x69, 0x63, 0x6b,
+	0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x55, 0x72, 0x6c, 0x12, 0x30, 0x0a, 0x14, 0x73, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x70, 0x6c,
+	0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72,
+	0x74, 0x73, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x12, 0x34, 0x0a, 0x16,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x73, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x12, 0x31, 0x0a, 0x14, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x5f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x13, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x42, 0x0a, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x4d, 0x65, 0x64, 0x69,
+	0x61, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x0f, 0x50, 0x6c,
+	0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x69, 0x6e, 0x67, 0x5f, 0x62, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x69, 0x6e,
+	0x67, 0x42, 0x69, 0x74, 0x72, 0x61, 0x74, 0x65, 0x22, 0x7e, 0x0a, 0x10, 0x50, 0x6c, 0x61, 0x79,
+	0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x12, 0x26, 0x0a, 0x0f,
+	0x70, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x42, 0x0a, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x6d, 0x65, 0x64, 0x69,
+	0x61, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x6c, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x26,
+	0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xc2, 0x02, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x74, 0x65, 0x6d, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12,
+	0x26, 0x0a, 0x0f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x5f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x6c, 0x61, 0x79, 0x5f,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12,
+	0x31, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b,
+	0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x50, 0x6c,
+	0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74,
+	0x69, 0x63, 0x6b, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x63, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x5f,
+	0x70, 0x61, 0x75, 0x73, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73,
+	0x50, 0x61, 0x75, 0x73, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x70, 0x6c, 0x61, 0x79, 0x62, 0x61,
+	0x63, 0x6b, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x70,
+	0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x61, 0x74, 0x65, 0x22, 0x07, 0x0a, 0x05, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x2a, 0x4d, 0x0a, 0x0d, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43,
+	0x4b, 0x5f, 0x50, 0x52, 0x4f, 0x47, 0x52, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
+	0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x01,
+	0x12, 0x11, 0x0a, 0x0d, 0x50, 0x4c, 0x41, 0x59, 0x42, 0x41, 0x43, 0x4b, 0x5f, 0x53, 0x54, 0x4f,
+	0x50, 0x10, 0x02, 0x32, 0xdc, 0x04, 0x0a, 0x08, 0x4a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e,
+	0x12, 0x38, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x12, 0x16, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65,
+	0x71, 0x1a, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e,
+	0x2e, 0x4c, 0x6f, 0x67, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x12, 0x36, 0x0a, 0x06, 0x4c, 0x6f,
+	0x67, 0x6f, 0x75, 0x74, 0x12, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79,
+	0x66, 0x69, 0x6e, 0x2e, 0x4c, 0x6f, 0x67, 0x6f, 0x75, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x2f, 0x0a, 0x02, 0x4d, 0x65, 0x12, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a,
+	0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x4d, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x14, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x4d, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x4a, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79,
+	0x66, 0x69, 0x6e, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
+	0x71, 0x1a, 0x1c, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e,
+	0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x3b, 0x0a, 0x06, 0x46, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x17, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x46, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x1a, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69,
+	0x6e, 0x2e, 0x46, 0x73, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x07,
+	0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65,
+	0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x65,
+	0x71, 0x1a, 0x12, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e,
+	0x2e, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x4d, 0x0a, 0x0c, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63,
+	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c,
+	0x79, 0x66, 0x69, 0x6e, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79,
+	0x66, 0x69, 0x6e, 0x2e, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x54, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x65, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x26, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c,
+	0x79, 0x66, 0x69, 0x6e, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x46, 0x0a, 0x0e, 0x52, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x1f, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x72, 0x74, 0x50, 0x6c, 0x61, 0x79, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x1a, 0x13, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x42, 0x0c, 0x5a, 0x0a, 0x2e, 0x3b, 0x6a, 0x65, 0x6c, 0x6c, 0x79, 0x66, 0x69, 0x6e,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_vendors_jellyfin_jellyfin_proto_rawDescOnce sync.Once
+	file_proto_vendors_jellyfin_jellyfin_proto_rawDescData = file_proto_vendors_jellyfin_jellyfin_proto_rawDesc
+)
+
+func file_proto_vendors_jellyfin_jellyfin_proto_rawDescGZIP() []byte {
+	file_proto_vendors_jellyfin_jellyfin_proto_rawDescOnce.Do(func() {
+		file_proto_vendors_jellyfin_jellyfin_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_vendors_jellyfin_jellyfin_proto_rawDescData)
+	})
+	return file_proto_vendors_jellyfin_jellyfin_proto_rawDescData
+}
+
+var file_proto_vendors_jellyfin_jellyfin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_vendors_jellyfin_jellyfin_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_proto_vendors_jellyfin_jellyfin_proto_goTypes = []any{
+	(PlaybackEvent)(0),               // 0: api.jellyfin.PlaybackEvent
+	(*LoginReq)(nil),                 // 1: api.jellyfin.LoginReq
+	(*LoginResp)(nil),                // 2: api.jellyfin.LoginResp
+	(*LogoutReq)(nil),                // 3: api.jellyfin.LogoutReq
+	(*MeReq)(nil),                    // 4: api.jellyfin.MeReq
+	(*MeResp)(nil),                   // 5: api.jellyfin.MeResp
+	(*SystemInfoReq)(nil),            // 6: api.jellyfin.SystemInfoReq
+	(*SystemInfoResp)(nil),           // 7: api.jellyfin.SystemInfoResp
+	(*FsListReq)(nil),                // 8: api.jellyfin.FsListReq
+	(*Path)(nil),                     // 9: api.jellyfin.Path
+	(*Item)(nil),                     // 10: api.jellyfin.Item
+	(*FsListResp)(nil),               // 11: api.jellyfin.FsListResp
+	(*GetItemReq)(nil),               // 12: api.jellyfin.GetItemReq
+	(*MediaStreamInfo)(nil),          // 13: api.jellyfin.MediaStreamInfo
+	(*MediaSourceInfo)(nil),          // 14: api.jellyfin.MediaSourceInfo
+	(*PlaybackInfoReq)(nil),          // 15: api.jellyfin.PlaybackInfoReq
+	(*PlaybackInfoResp)(nil),         // 16: api.jellyfin.PlaybackInfoResp
+	(*DeleteActiveEncodingsReq)(nil), // 17: api.jellyfin.DeleteActiveEncodingsReq
+	(*ReportPlaybackReq)(nil),        // 18: api.jellyfin.ReportPlaybackReq
+	(*Empty)(nil),                    // 19: api.jellyfin.Empty
+}
+var file_proto_vendors_jellyfin_jellyfin_proto_depIdxs = []int32{
+	9,  // 0: api.jellyfin.FsListResp.paths:type_name -> api.jellyfin.Path
+	10, // 1: api.jellyfin.FsListResp.items:type_name -> api.jellyfin.Item
+	13, // 2: api.jellyfin.MediaSourceInfo.media_streams:type_name -> api.jellyfin.MediaStreamInfo
+	14, // 3: api.jellyfin.PlaybackInfoResp.media_sources:type_name -> api.jellyfin.MediaSourceInfo
+	0,  // 4: api.jellyfin.ReportPlaybackReq.event:type_name -> api.jellyfin.PlaybackEvent
+	1,  // 5: api.jellyfin.Jellyfin.Login:input_type -> api.jellyfin.LoginReq
+	3,  // 6: api.jellyfin.Jellyfin.Logout:input_type -> api.jellyfin.LogoutReq
+	4,  // 7: api.jellyfin.Jellyfin.Me:input_type -> api.jellyfin.MeReq
+	6,  // 8: api.jellyfin.Jellyfin.GetSystemInfo:input_type -> api.jellyfin.SystemInfoReq
+	8,  // 9: api.jellyfin.Jellyfin.FsList:input_type -> api.jellyfin.FsListReq
+	12, // 10: api.jellyfin.Jellyfin.GetItem:input_type -> api.jellyfin.GetItemReq
+	15, // 11: api.jellyfin.Jellyfin.PlaybackInfo:input_type -> api.jellyfin.PlaybackInfoReq
+	17, // 12: api.jellyfin.Jellyfin.DeleteActiveEncodings:input_type -> api.jellyfin.DeleteActiveEncodingsReq
+	18, // 13: api.jellyfin.Jellyfin.ReportPlayback:input_type -> api.jellyfin.ReportPlaybackReq
+	2,  // 14: api.jellyfin.Jellyfin.Login:output_type -> api.jellyfin.LoginResp
+	19, // 15: api.jellyfin.Jellyfin.Logout:output_type -> api.jellyfin.Empty
+	5,  // 16: api.jellyfin.Jellyfin.Me:output_type -> api.jellyfin.MeResp
+	7,  // 17: api.jellyfin.Jellyfin.GetSystemInfo:output_type -> api.jellyfin.SystemInfoResp
+	11, // 18: api.jellyfin.Jellyfin.FsList:output_type -> api.jellyfin.FsListResp
+	10, // 19: api.jellyfin.Jellyfin.GetItem:output_type -> api.jellyfin.Item
+	16, // 20: api.jellyfin.Jellyfin.PlaybackInfo:output_type -> api.jellyfin.PlaybackInfoResp
+	19, // 21: api.jellyfin.Jellyfin.DeleteActiveEncodings:output_type -> api.jellyfin.Empty
+	19, // 22: api.jellyfin.Jellyfin.ReportPlayback:output_type -> api.jellyfin.Empty
+	14, // [14:23] is the sub-list for method output_type
+	5,  // [5:14] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_vendors_jellyfin_jellyfin_proto_init() }
+func file_proto_vendors_jellyfin_jellyfin_proto_init() {
+	if File_proto_vendors_jellyfin_jellyfin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_vendors_jellyfin_jellyfin_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   19,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_vendors_jellyfin_jellyfin_proto_goTypes,
+		DependencyIndexes: file_proto_vendors_jellyfin_jellyfin_proto_depIdxs,
+		EnumInfos:         file_proto_vendors_jellyfin_jellyfin_proto_enumTypes,
+		MessageInfos:      file_proto_vendors_jellyfin_jellyfin_proto_msgTypes,
+	}.Build()
+	File_proto_vendors_jellyfin_jellyfin_proto = out.File
+	file_proto_vendors_jellyfin_jellyfin_proto_rawDesc = nil
+	file_proto_vendors_jellyfin_jellyfin_proto_goTypes = nil
+	file_proto_vendors_jellyfin_jellyfin_proto_depIdxs = nil
+}
@@ -0,0 +1,425 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: proto/vendors/jellyfin/jellyfin.proto
+
+package jellyfin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Jellyfin_Login_FullMethodName                 = "/api.jellyfin.Jellyfin/Login"
+	Jellyfin_Logout_FullMethodName                = "/api.jellyfin.Jellyfin/Logout"
+	Jellyfin_Me_FullMethodName                    = "/api.jellyfin.Jellyfin/Me"
+	Jellyfin_GetSystemInfo_FullMethodName         = "/api.jellyfin.Jellyfin/GetSystemInfo"
+	Jellyfin_FsList_FullMethodName                = "/api.jellyfin.Jellyfin/FsList"
+	Jellyfin_GetItem_FullMethodName               = "/api.jellyfin.Jellyfin/GetItem"
+	Jellyfin_PlaybackInfo_FullMethodName          = "/api.jellyfin.Jellyfin/PlaybackInfo"
+	Jellyfin_DeleteActiveEncodings_FullMethodName = "/api.jellyfin.Jellyfin/DeleteActiveEncodings"
+	Jellyfin_ReportPlayback_FullMethodName        = "/api.jellyfin.Jellyfin/ReportPlayback"
+)
+
+// JellyfinClient is the client API for Jellyfin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type JellyfinClient interface {
+	Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginResp, error)
+	Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*Empty, error)
+	Me(ctx context.Context, in *MeReq, opts ...grpc.CallOption) (*MeResp, error)
+	GetSystemInfo(ctx context.Context, in *SystemInfoReq, opts ...grpc.CallOption) (*SystemInfoResp, error)
+	FsList(ctx context.Context, in *FsListReq, opts ...grpc.CallOption) (*FsListResp, error)
+	GetItem(ctx context.Context, in *GetItemReq, opts ...grpc.CallOption) (*Item, error)
+	PlaybackInfo(ctx context.Context, in *PlaybackInfoReq, opts ...grpc.CallOption) (*PlaybackInfoResp, error)
+	DeleteActiveEncodings(ctx context.Context, in *DeleteActiveEncodingsReq, opts ...grpc.CallOption) (*Empty, error)
+	ReportPlayback(ctx context.Context, in *ReportPlaybackReq, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type jellyfinClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJellyfinClient(cc grpc.ClientConnInterface) JellyfinClient {
+	return &jellyfinClient{cc}
+}
+
+func (c *jellyfinClient) Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResp)
+	err := c.cc.Invoke(ctx, Jellyfin_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) Logout(ctx context.Context, in *LogoutReq, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Jellyfin_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) Me(ctx context.Context, in *MeReq, opts ...grpc.CallOption) (*MeResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeResp)
+	err := c.cc.Invoke(ctx, Jellyfin_Me_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) GetSystemInfo(ctx context.Context, in *SystemInfoReq, opts ...grpc.CallOption) (*SystemInfoResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemInfoResp)
+	err := c.cc.Invoke(ctx, Jellyfin_GetSystemInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) FsList(ctx context.Context, in *FsListReq, opts ...grpc.CallOption) (*FsListResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FsListResp)
+	err := c.cc.Invoke(ctx, Jellyfin_FsList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) GetItem(ctx context.Context, in *GetItemReq, opts ...grpc.CallOption) (*Item, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Item)
+	err := c.cc.Invoke(ctx, Jellyfin_GetItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) PlaybackInfo(ctx context.Context, in *PlaybackInfoReq, opts ...grpc.CallOption) (*PlaybackInfoResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaybackInfoResp)
+	err := c.cc.Invoke(ctx, Jellyfin_PlaybackInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) DeleteActiveEncodings(ctx context.Context, in *DeleteActiveEncodingsReq, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Jellyfin_DeleteActiveEncodings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jellyfinClient) ReportPlayback(ctx context.Context, in *ReportPlaybackReq, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, Jellyfin_ReportPlayback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JellyfinServer is the server API for Jellyfin service.
+// All implementations must embed UnimplementedJellyfinServer
+// for forward compatibility.
+type JellyfinServer interface {
+	Login(context.Context, *LoginReq) (*LoginResp, error)
+	Logout(context.Context, *LogoutReq) (*Empty, error)
+	Me(context.Context, *MeReq) (*MeResp, error)
+	GetSystemInfo(context.Context, *SystemInfoReq) (*SystemInfoResp, error)
+	FsList(context.Context, *FsListReq) (*FsListResp, error)
+	GetItem(context.Context, *GetItemReq) (*Item, error)
+	PlaybackInfo(context.Context, *PlaybackInfoReq) (*PlaybackInfoResp, error)
+	DeleteActiveEncodings(context.Context, *DeleteActiveEncodingsReq) (*Empty, error)
+	ReportPlayback(context.Context, *ReportPlaybackReq) (*Empty, error)
+	mustEmbedUnimplementedJellyfinServer()
+}
+
+// UnimplementedJellyfinServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJellyfinServer struct{}
+
+func (UnimplementedJellyfinServer) Login(context.Context, *LoginReq) (*LoginResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedJellyfinServer) Logout(context.Context, *LogoutReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedJellyfinServer) Me(context.Context, *MeReq) (*MeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Me not implemented")
+}
+func (UnimplementedJellyfinServer) GetSystemInfo(context.Context, *SystemInfoReq) (*SystemInfoResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSystemInfo not implemented")
+}
+func (UnimplementedJellyfinServer) FsList(context.Context, *FsListReq) (*FsListResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FsList not implemented")
+}
+func (UnimplementedJellyfinServer) GetItem(context.Context, *GetItemReq) (*Item, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetItem not implemented")
+}
+func (UnimplementedJellyfinServer) PlaybackInfo(context.Context, *PlaybackInfoReq) (*PlaybackInfoResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaybackInfo not implemented")
+}
+func (UnimplementedJellyfinServer) DeleteActiveEncodings(context.Context, *DeleteActiveEncodingsReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteActiveEncodings not implemented")
+}
+func (UnimplementedJellyfinServer) ReportPlayback(context.Context, *ReportPlaybackReq) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportPlayback not implemented")
+}
+func (UnimplementedJellyfinServer) mustEmbedUnimplementedJellyfinServer() {}
+func (UnimplementedJellyfinServer) testEmbeddedByValue()                  {}
+
+// UnsafeJellyfinServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JellyfinServer will
+// result in compilation errors.
+type UnsafeJellyfinServer interface {
+	mustEmbedUnimplementedJellyfinServer()
+}
+
+func RegisterJellyfinServer(s grpc.ServiceRegistrar, srv JellyfinServer) {
+	// If the following call pancis, it indicates UnimplementedJellyfinServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Jellyfin_ServiceDesc, srv)
+}
+
+func _Jellyfin_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).Login(ctx, req.(*LoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).Logout(ctx, req.(*LogoutReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_Me_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).Me(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_Me_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).Me(ctx, req.(*MeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_GetSystemInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SystemInfoReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).GetSystemInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_GetSystemInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).GetSystemInfo(ctx, req.(*SystemInfoReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_FsList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FsListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).FsList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_FsList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).FsList(ctx, req.(*FsListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_GetItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).GetItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_GetItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).GetItem(ctx, req.(*GetItemReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_PlaybackInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaybackInfoReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).PlaybackInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_PlaybackInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).PlaybackInfo(ctx, req.(*PlaybackInfoReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_DeleteActiveEncodings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteActiveEncodingsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).DeleteActiveEncodings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_DeleteActiveEncodings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).DeleteActiveEncodings(ctx, req.(*DeleteActiveEncodingsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Jellyfin_ReportPlayback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportPlaybackReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JellyfinServer).ReportPlayback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Jellyfin_ReportPlayback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JellyfinServer).ReportPlayback(ctx, req.(*ReportPlaybackReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Jellyfin_ServiceDesc is the grpc.ServiceDesc for Jellyfin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Jellyfin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.jellyfin.Jellyfin",
+	HandlerType: (*JellyfinServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _Jellyfin_Login_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _Jellyfin_Logout_Handler,
+		},
+		{
+			MethodName: "Me",
+			Handler:    _Jellyfin_Me_Handler,
+		},
+		{
+			MethodName: "GetSystemInfo",
+			Handler:    _Jellyfin_GetSystemInfo_Handler,
+		},
+		{
+			MethodName: "FsList",
+			Handler:    _Jellyfin_FsList_Handler,
+		},
+		{
+			MethodName: "GetItem",
+			Handler:    _Jellyfin_GetItem_Handler,
+		},
+		{
+			MethodName: "PlaybackInfo",
+			Handler:    _Jellyfin_PlaybackInfo_Handler,
+		},
+		{
+			MethodName: "DeleteActiveEncodings",
+			Handler:    _Jellyfin_DeleteActiveEncodings_Handler,
+		},
+		{
+			MethodName: "ReportPlayback",
+			Handler:    _Jellyfin_ReportPlayback_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/vendors/jellyfin/jellyfin.proto",
+}
@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.1
+// source: proto/vendors/plugin/plugin.proto
+
+package vendorpluginpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VendorPlugin_Info_FullMethodName      = "/proto.vendorplugin.VendorPlugin/Info"
+	VendorPlugin_Login_FullMethodName     = "/proto.vendorplugin.VendorPlugin/Login"
+	VendorPlugin_Me_FullMethodName        = "/proto.vendorplugin.VendorPlugin/Me"
+	VendorPlugin_List_FullMethodName      = "/proto.vendorplugin.VendorPlugin/List"
+	VendorPlugin_Resolve_FullMethodName   = "/proto.vendorplugin.VendorPlugin/Resolve"
+	VendorPlugin_Subtitles_FullMethodName = "/proto.vendorplugin.VendorPlugin/Subtitles"
+)
+
+// VendorPluginClient is the client API for VendorPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VendorPluginClient interface {
+	Info(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InfoResp, error)
+	Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginResp, error)
+	Me(ctx context.Context, in *MeReq, opts ...grpc.CallOption) (*MeResp, error)
+	List(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*ListResp, error)
+	Resolve(ctx context.Context, in *ResolveReq, opts ...grpc.CallOption) (*ResolveResp, error)
+	Subtitles(ctx context.Context, in *SubtitlesReq, opts ...grpc.CallOption) (*SubtitlesResp, error)
+}
+
+type vendorPluginClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVendorPluginClient(cc grpc.ClientConnInterface) VendorPluginClient {
+	return &vendorPluginClient{cc}
+}
+
+func (c *vendorPluginClient) Info(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*InfoResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InfoResp)
+	err := c.cc.Invoke(ctx, VendorPlugin_Info_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorPluginClient) Login(ctx context.Context, in *LoginReq, opts ...grpc.CallOption) (*LoginResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LoginResp)
+	err := c.cc.Invoke(ctx, VendorPlugin_Login_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorPluginClient) Me(ctx context.Context, in *MeReq, opts ...grpc.CallOption) (*MeResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeResp)
+	err := c.cc.Invoke(ctx, VendorPlugin_Me_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorPluginClient) List(ctx context.Context, in *ListReq, opts ...grpc.CallOption) (*ListResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListResp)
+	err := c.cc.Invoke(ctx, VendorPlugin_List_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorPluginClient) Resolve(ctx context.Context, in *ResolveReq, opts ...grpc.CallOption) (*ResolveResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolveResp)
+	err := c.cc.Invoke(ctx, VendorPlugin_Resolve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vendorPluginClient) Subtitles(ctx context.Context, in *SubtitlesReq, opts ...grpc.CallOption) (*SubtitlesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubtitlesResp)
+	err := c.cc.Invoke(ctx, VendorPlugin_Subtitles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VendorPluginServer is the server API for VendorPlugin service.
+// All implementations must embed UnimplementedVendorPluginServer
+// for forward compatibility.
+type VendorPluginServer interface {
+	Info(context.Context, *Empty) (*InfoResp, error)
+	Login(context.Context, *LoginReq) (*LoginResp, error)
+	Me(context.Context, *MeReq) (*MeResp, error)
+	List(context.Context, *ListReq) (*ListResp, error)
+	Resolve(context.Context, *ResolveReq) (*ResolveResp, error)
+	Subtitles(context.Context, *SubtitlesReq) (*SubtitlesResp, error)
+	mustEmbedUnimplementedVendorPluginServer()
+}
+
+// UnimplementedVendorPluginServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVendorPluginServer struct{}
+
+func (UnimplementedVendorPluginServer) Info(context.Context, *Empty) (*InfoResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedVendorPluginServer) Login(context.Context, *LoginReq) (*LoginResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedVendorPluginServer) Me(context.Context, *MeReq) (*MeResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Me not implemented")
+}
+func (UnimplementedVendorPluginServer) List(context.Context, *ListReq) (*ListResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedVendorPluginServer) Resolve(context.Context, *ResolveReq) (*ResolveResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resolve not implemented")
+}
+func (UnimplementedVendorPluginServer) Subtitles(context.Context, *SubtitlesReq) (*SubtitlesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Subtitles not implemented")
+}
+func (UnimplementedVendorPluginServer) mustEmbedUnimplementedVendorPluginServer() {}
+func (UnimplementedVendorPluginServer) testEmbeddedByValue()                      {}
+
+// UnsafeVendorPluginServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VendorPluginServer will
+// result in compilation errors.
+type UnsafeVendorPluginServer interface {
+	mustEmbedUnimplementedVendorPluginServer()
+}
+
+func RegisterVendorPluginServer(s grpc.ServiceRegistrar, srv VendorPluginServer) {
+	// If the following call pancis, it indicates UnimplementedVendorPluginServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VendorPlugin_ServiceDesc, srv)
+}
+
+func _VendorPlugin_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorPluginServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorPlugin_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorPluginServer).Info(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorPlugin_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorPluginServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorPlugin_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorPluginServer).Login(ctx, req.(*LoginReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorPlugin_Me_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorPluginServer).Me(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorPlugin_Me_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorPluginServer).Me(ctx, req.(*MeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorPlugin_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorPluginServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorPlugin_List_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorPluginServer).List(ctx, req.(*ListReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorPlugin_Resolve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorPluginServer).Resolve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorPlugin_Resolve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorPluginServer).Resolve(ctx, req.(*ResolveReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VendorPlugin_Subtitles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubtitlesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VendorPluginServer).Subtitles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VendorPlugin_Subtitles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VendorPluginServer).Subtitles(ctx, req.(*SubtitlesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VendorPlugin_ServiceDesc is the grpc.ServiceDesc for VendorPlugin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VendorPlugin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.vendorplugin.VendorPlugin",
+	HandlerType: (*VendorPluginServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Info",
+			Handler:    _VendorPlugin_Info_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _VendorPlugin_Login_Handler,
+		},
+		{
+			MethodName: "Me",
+			Handler:    _VendorPlugin_Me_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _VendorPlugin_List_Handler,
+		},
+		{
+			MethodName: "Resolve",
+			Handler:    _VendorPlugin_Resolve_Handler,
+		},
+		{
+			MethodName: "Subtitles",
+			Handler:    _VendorPlugin_Subtitles_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/vendors/plugin/plugin.proto",
+}
@@ -1,3 +1,6 @@
 #!/bin/bash
 protoc --go_out=./proto/message ./proto/message/*.proto
 protoc --go_out=./proto/provider --go-grpc_out=./proto/provider ./proto/provider/*.proto
+protoc --go_out=./proto/vendors/emby --go-grpc_out=./proto/vendors/emby ./proto/vendors/emby/*.proto
+protoc --go_out=./proto/vendors/jellyfin --go-grpc_out=./proto/vendors/jellyfin ./proto/vendors/jellyfin/*.proto
+protoc --go_out=./proto/vendors/plugin --go-grpc_out=./proto/vendors/plugin ./proto/vendors/plugin/*.proto
@@ -334,6 +334,9 @@ func genRoomMemberListResp(us []*dbModel.User, room *op.Room) []*model.RoomMembe
 			Permissions:      permissions,
 			AdminPermissions: v.RoomMembers[0].AdminPermissions,
 		}
+		if v.RoomMembers[0].IsMuted() {
+			resp[i].MutedUntil = v.RoomMembers[0].MutedUntil.UnixMilli()
+		}
 	}
 	return resp
 }
@@ -1207,6 +1210,7 @@ func AdminGetVendorBackends(ctx *gin.Context) {
 		for _, v := range s[(page-1)*size : (page-1)*size+l] {
 			resp = append(resp, &model.GetVendorBackendResp{
 				Info:   conns[v].Info,
+				Health: vendor.LoadHealth(v),
 				Status: conns[v].Conn.GetState(),
 			})
 		}
@@ -1375,3 +1379,148 @@ func AdminSendTestEmail(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func AdminGetLocalLibraries(ctx *gin.Context) {
+	// user := middlewares.GetUserEntry(ctx)
+	log := middlewares.GetLogger(ctx)
+
+	page, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		log.Errorf("get page and max error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	total, err := db.GetLocalLibrariesCount()
+	if err != nil {
+		log.Errorf("get local libraries count error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	libraries, err := db.GetLocalLibraries(db.OrderByCreatedAtAsc, db.Paginate(page, size))
+	if err != nil {
+		log.Errorf("get local libraries error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(gin.H{
+		"total": total,
+		"list":  libraries,
+	}))
+}
+
+func AdminAddLocalLibrary(ctx *gin.Context) {
+	// user := middlewares.GetUserEntry(ctx)
+	log := middlewares.GetLogger(ctx)
+
+	var req model.AddLocalLibraryReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	library := &dbModel.LocalLibrary{
+		Name:  req.Name,
+		Root:  req.Root,
+		Watch: req.Watch,
+	}
+	if err := db.CreateLocalLibrary(library); err != nil {
+		log.Errorf("add local library error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+	op.ScanLocalLibraryAsync(library.ID)
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(library))
+}
+
+func AdminUpdateLocalLibrary(ctx *gin.Context) {
+	// user := middlewares.GetUserEntry(ctx)
+	log := middlewares.GetLogger(ctx)
+
+	var req model.UpdateLocalLibraryReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	old, err := db.GetLocalLibrary(req.ID)
+	if err != nil {
+		log.Errorf("get local library error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	err = db.UpdateLocalLibrary(&dbModel.LocalLibrary{
+		ID:    req.ID,
+		Name:  req.Name,
+		Root:  req.Root,
+		Watch: req.Watch,
+	})
+	if err != nil {
+		log.Errorf("update local library error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+	if old.Root != req.Root {
+		op.ForgetLocalLibrary(req.ID)
+		op.ScanLocalLibraryAsync(req.ID)
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func AdminDeleteLocalLibraries(ctx *gin.Context) {
+	// user := middlewares.GetUserEntry(ctx)
+	log := middlewares.GetLogger(ctx)
+
+	var req model.IDsReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := db.DeleteLocalLibraries(req.IDs); err != nil {
+		log.Errorf("delete local libraries error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	for _, id := range req.IDs {
+		op.ForgetLocalLibrary(id)
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// AdminScanLocalLibrary rescans a library and waits for the new index.
+func AdminScanLocalLibrary(ctx *gin.Context) {
+	// user := middlewares.GetUserEntry(ctx)
+	log := middlewares.GetLogger(ctx)
+
+	var req model.IDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := op.ScanLocalLibrary(ctx, req.ID); err != nil {
+		log.Errorf("scan local library error: %v", err)
+		if errors.Is(err, op.ErrLocalLibraryScanning) {
+			ctx.AbortWithStatusJSON(http.StatusConflict, model.NewAPIErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	library, err := db.GetLocalLibrary(req.ID)
+	if err != nil {
+		log.Errorf("get local library error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(library))
+}
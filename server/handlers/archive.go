@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/archive"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/internal/settings"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+// ExportMovies downloads the movie tree of the room as a json or zip archive.
+func ExportMovies(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	if !user.HasRoomPermission(room, dbModel.PermissionGetMovieList) {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			model.NewAPIErrorResp(dbModel.ErrNoPermission),
+		)
+		return
+	}
+
+	format := ctx.DefaultQuery("format", archive.FormatJSON)
+	if format != archive.FormatJSON && format != archive.FormatZip {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("format must be json or zip"),
+		)
+		return
+	}
+
+	p, err := room.ExportPlaylist()
+	if err != nil {
+		log.Errorf("export movies error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.Header("Content-Type", archive.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(
+		`attachment; filename="synctv-playlist-%s-%s.%s"`,
+		room.ID,
+		time.Now().Format("20060102"),
+		format,
+	))
+	ctx.Status(http.StatusOK)
+	if err := p.Write(ctx.Writer, format); err != nil {
+		log.Errorf("write playlist archive error: %v", err)
+	}
+}
+
+// ImportMovies adds the movies of an archive to the room, the archive is the body or
+// the file field of a multipart form.
+func ImportMovies(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	data, err := readArchive(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	p, err := archive.ReadPlaylist(data)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(fmt.Errorf("read playlist archive error: %w", err)),
+		)
+		return
+	}
+
+	results, err := user.ImportRoomPlaylist(room, p, ctx.Query("parentId"))
+	if err != nil {
+		log.Errorf("import movies error: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				model.NewAPIErrorResp(
+					fmt.Errorf("import movies error: %w", err),
+				),
+			)
+			return
+		}
+		if results == nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(model.NewImportResultsResp(results)))
+}
+
+// CloneRoom creates a room of the creator with the settings, members and movies of the room.
+func CloneRoom(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	if settings.DisableCreateRoom.Get() && !user.IsAdmin() {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("create room is disabled"),
+		)
+		return
+	}
+
+	req := model.CloneRoomReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	clone, err := user.CloneRoom(room, req.RoomName, req.Password)
+	if err != nil {
+		log.Errorf("clone room error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.NewAPIDataResp(gin.H{
+		"roomId": clone.Value().ID,
+		"status": clone.Value().Status,
+	}))
+}
+
+// RootExportRoom downloads the backup of a room as a json or zip archive.
+func RootExportRoom(ctx *gin.Context) {
+	log := middlewares.GetLogger(ctx)
+
+	format := ctx.DefaultQuery("format", archive.FormatJSON)
+	if format != archive.FormatJSON && format != archive.FormatZip {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("format must be json or zip"),
+		)
+		return
+	}
+
+	a, err := op.ExportRoomByID(ctx.Query("id"))
+	if err != nil {
+		log.Errorf("export room error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.Header("Content-Type", archive.ContentType(format))
+	ctx.Header("Content-Disposition", fmt.Sprintf(
+		`attachment; filename="synctv-room-%s-%s.%s"`,
+		a.ID,
+		time.Now().Format("20060102"),
+		format,
+	))
+	ctx.Status(http.StatusOK)
+	if err := a.Write(ctx.Writer, format); err != nil {
+		log.Errorf("write room archive error: %v", err)
+	}
+}
+
+// RootRestoreRoom creates a room from a backup, the name and creator queries replace the
+// ones of the archive.
+func RootRestoreRoom(ctx *gin.Context) {
+	log := middlewares.GetLogger(ctx)
+
+	name := ctx.Query("name")
+	if name != "" {
+		if err := (&model.CloneRoomReq{RoomName: name}).Validate(); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+	}
+
+	data, err := readArchive(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	a, err := archive.ReadRoom(data)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(fmt.Errorf("read room archive error: %w", err)),
+		)
+		return
+	}
+
+	room, err := op.RestoreRoom(a, name, ctx.Query("creator"))
+	if err != nil {
+		log.Errorf("restore room error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.NewAPIDataResp(gin.H{
+		"roomId": room.ID,
+		"status": room.Status,
+	}))
+}
+
+func readArchive(ctx *gin.Context) ([]byte, error) {
+	var r io.Reader = ctx.Request.Body
+	if strings.HasPrefix(ctx.ContentType(), "multipart/form-data") {
+		fh, err := ctx.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		f, err := fh.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	data, err := io.ReadAll(io.LimitReader(r, archive.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > archive.MaxSize {
+		return nil, errors.New("archive too large")
+	}
+	if len(data) == 0 {
+		return nil, errors.New("archive is empty")
+	}
+	return data, nil
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+// RoomChatHistory returns up to max messages sent before the before query, a unix
+// timestamp in milliseconds that defaults to now, oldest first so a page can be
+// prepended to the chat as it is.
+func RoomChatHistory(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	_, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	before := time.Now()
+	if s := ctx.Query("before"); s != "" {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorResp(errors.New("before must be a number")),
+			)
+			return
+		}
+		before = time.UnixMilli(ms)
+	}
+
+	messages, err := db.GetChatMessages(room.ID, before, size)
+	if err != nil {
+		log.Errorf("get chat history error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+	slices.Reverse(messages)
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(messages))
+}
+
+// RoomDirectMessageHistory returns the direct messages between the user and the member
+// of the userId query, paged like RoomChatHistory.
+func RoomDirectMessageHistory(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	otherID := ctx.Query("userId")
+	if len(otherID) != 32 {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(errors.New("userId is required")),
+		)
+		return
+	}
+
+	_, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	before := time.Now()
+	if s := ctx.Query("before"); s != "" {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorResp(errors.New("before must be a number")),
+			)
+			return
+		}
+		before = time.UnixMilli(ms)
+	}
+
+	messages, err := user.DirectMessages(room, otherID, before, size)
+	if err != nil {
+		log.Errorf("get direct message history error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+	slices.Reverse(messages)
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(messages))
+}
+
+func handleChatModerationError(ctx *gin.Context, msg string, err error) {
+	log := middlewares.GetLogger(ctx)
+	log.Errorf("%s: %v", msg, err)
+	if errors.Is(err, dbModel.ErrNoPermission) {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			model.NewAPIErrorResp(fmt.Errorf("%s: %w", msg, err)),
+		)
+		return
+	}
+	ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+}
+
+func RoomAdminMuteMember(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	room := middlewares.GetRoomEntry(ctx).Value()
+
+	var req model.RoomMuteMemberReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	err := user.MuteRoomMember(room, req.ID, time.Duration(req.Duration)*time.Second)
+	if err != nil {
+		handleChatModerationError(ctx, "mute room member failed", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func RoomAdminUnmuteMember(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	room := middlewares.GetRoomEntry(ctx).Value()
+
+	var req model.RoomUnmuteMemberReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.UnmuteRoomMember(room, req.ID); err != nil {
+		handleChatModerationError(ctx, "unmute room member failed", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func RoomAdminSetChatSlowMode(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	room := middlewares.GetRoomEntry(ctx).Value()
+
+	var req model.ChatSlowModeReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.SetRoomChatSlowMode(room, req.Seconds); err != nil {
+		handleChatModerationError(ctx, "set chat slow mode failed", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func RoomAdminChatFilters(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	filters, err := room.ChatFilters()
+	if err != nil {
+		log.Errorf("get chat filters error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(filters))
+}
+
+func RoomAdminAddChatFilter(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	room := middlewares.GetRoomEntry(ctx).Value()
+
+	var req model.ChatFilterReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	f := &dbModel.ChatFilter{
+		Pattern:     req.Pattern,
+		Regex:       req.Regex,
+		Action:      req.Action,
+		Replacement: req.Replacement,
+	}
+	if err := user.AddRoomChatFilter(room, f); err != nil {
+		handleChatModerationError(ctx, "add chat filter failed", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.NewAPIDataResp(f))
+}
+
+func RoomAdminDeleteChatFilter(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	room := middlewares.GetRoomEntry(ctx).Value()
+
+	var req model.IDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.DeleteRoomChatFilter(room, req.ID); err != nil {
+		handleChatModerationError(ctx, "delete chat filter failed", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// RoomAdminDeleteChatMessage removes a message from the chat of the members and the
+// history, the clients replace it with a tombstone.
+func RoomAdminDeleteChatMessage(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	room := middlewares.GetRoomEntry(ctx).Value()
+
+	var req model.IDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.DeleteRoomChatMessage(room, req.ID); err != nil {
+		handleChatModerationError(ctx, "delete chat message failed", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -6,6 +6,12 @@ import (
 	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendoralist"
 	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorbilibili"
 	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendoremby"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorjellyfin"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorlocal"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorplex"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorplugin"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorsubsonic"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorwebdav"
 	"github.com/PeterChen1997/synctv/server/middlewares"
 	"github.com/PeterChen1997/synctv/utils"
 )
@@ -84,6 +90,16 @@ func initAdmin(admin, root *gin.RouterGroup) {
 
 		admin.POST("/vendors/disable", AdminDisableVendorBackends)
 
+		admin.GET("/local/libraries", AdminGetLocalLibraries)
+
+		admin.POST("/local/libraries/add", AdminAddLocalLibrary)
+
+		admin.POST("/local/libraries/update", AdminUpdateLocalLibrary)
+
+		admin.POST("/local/libraries/delete", AdminDeleteLocalLibraries)
+
+		admin.POST("/local/libraries/scan", AdminScanLocalLibrary)
+
 		{
 			user := admin.Group("/user")
 
@@ -132,6 +148,10 @@ func initAdmin(admin, root *gin.RouterGroup) {
 		root.POST("/admin/add", RootAddAdmin)
 
 		root.POST("/admin/delete", RootDeleteAdmin)
+
+		root.GET("/room/export", RootExportRoom)
+
+		root.POST("/room/restore", RootRestoreRoom)
 	}
 }
 
@@ -154,6 +174,30 @@ func initRoom(room, needAuthUser, needAuthRoom, needAuthWithoutGuestRoom *gin.Ro
 
 	needAuthRoom.GET("/ws", NewWebSocketHandler(utils.NewWebSocketServer()))
 
+	needAuthRoom.GET("/chat/history", RoomChatHistory)
+
+	needAuthRoom.GET("/music/queue", MusicQueue)
+
+	needAuthRoom.POST("/music/queue", RequestTrack)
+
+	needAuthRoom.POST("/music/queue/delete", DeleteTrackRequest)
+
+	needAuthRoom.POST("/music/skip", SkipTrack)
+
+	needAuthRoom.GET("/party/list", WatchParties)
+
+	needAuthRoom.GET("/party/ics", WatchPartyCalendar)
+
+	needAuthRoom.POST("/party/create", CreateWatchParty)
+
+	needAuthRoom.POST("/party/edit", EditWatchParty)
+
+	needAuthRoom.POST("/party/delete", DeleteWatchParty)
+
+	needAuthWithoutGuestRoom.POST("/party/rsvp", RSVPWatchParty)
+
+	needAuthWithoutGuestRoom.GET("/dm/history", RoomDirectMessageHistory)
+
 	needAuthWithoutGuestRoom.GET("/settings", RoomPiblicSettings)
 
 	needAuthWithoutGuestRoom.GET("/members", RoomMembers)
@@ -182,6 +226,20 @@ func initRoom(room, needAuthUser, needAuthRoom, needAuthWithoutGuestRoom *gin.Ro
 
 		needAuthRoomAdmin.POST("/members/unban", RoomAdminUnbanMember)
 
+		needAuthRoomAdmin.POST("/members/mute", RoomAdminMuteMember)
+
+		needAuthRoomAdmin.POST("/members/unmute", RoomAdminUnmuteMember)
+
+		needAuthRoomAdmin.POST("/chat/slowmode", RoomAdminSetChatSlowMode)
+
+		needAuthRoomAdmin.GET("/chat/filters", RoomAdminChatFilters)
+
+		needAuthRoomAdmin.POST("/chat/filters/add", RoomAdminAddChatFilter)
+
+		needAuthRoomAdmin.POST("/chat/filters/delete", RoomAdminDeleteChatFilter)
+
+		needAuthRoomAdmin.POST("/chat/delete", RoomAdminDeleteChatMessage)
+
 		needAuthRoomCreator.POST("/members/member", RoomSetMember)
 
 		needAuthRoomCreator.POST("/members/member/permissions", RoomSetMemberPermissions)
@@ -189,6 +247,8 @@ func initRoom(room, needAuthUser, needAuthRoom, needAuthWithoutGuestRoom *gin.Ro
 		needAuthRoomCreator.POST("/members/admin", RoomSetAdmin)
 
 		needAuthRoomCreator.POST("/members/admin/permissions", RoomSetAdminPermissions)
+
+		needAuthRoomCreator.POST("/clone", CloneRoom)
 	}
 }
 
@@ -199,12 +259,24 @@ func initMovie(movie, needAuthMovie *gin.RouterGroup) {
 
 	needAuthMovie.GET("/movies", Movies)
 
+	needAuthMovie.GET("/schedule", MovieSchedule)
+
 	needAuthMovie.POST("/current", ChangeCurrentMovie)
 
 	needAuthMovie.POST("/push", PushMovie)
 
 	needAuthMovie.POST("/pushs", PushMovies)
 
+	needAuthMovie.POST("/resolve", ResolveMovie)
+
+	needAuthMovie.POST("/import/bilibili", vendorbilibili.Import)
+
+	needAuthMovie.POST("/import/m3u", ImportM3U)
+
+	needAuthMovie.GET("/export", ExportMovies)
+
+	needAuthMovie.POST("/import", ImportMovies)
+
 	needAuthMovie.POST("/edit", EditMovie)
 
 	needAuthMovie.POST("/swap", SwapMovie)
@@ -274,6 +346,12 @@ func initUser(user, needAuthUser *gin.RouterGroup) {
 
 	needAuthUser.POST("/unbind/email", UserUnbindEmail)
 
+	needAuthUser.GET("/blocks", UserBlockedUsers)
+
+	needAuthUser.POST("/blocks/add", UserBlockUser)
+
+	needAuthUser.POST("/blocks/delete", UserUnblockUser)
+
 	{
 		needAuthRoom := needAuthUser.Group("/room")
 
@@ -288,6 +366,8 @@ func initUser(user, needAuthUser *gin.RouterGroup) {
 func initVendor(vendor *gin.RouterGroup) {
 	vendor.GET("/backends/:vendor", vendors.Backends)
 
+	vendor.POST("/search", vendors.Search)
+
 	{
 		bilibili := vendor.Group("/bilibili")
 
@@ -305,6 +385,8 @@ func initVendor(vendor *gin.RouterGroup) {
 
 		bilibili.POST("/parse", vendorbilibili.Parse)
 
+		bilibili.GET("/favorites", vendorbilibili.Favorites)
+
 		bilibili.GET("/me", vendorbilibili.Me)
 
 		bilibili.POST("/logout", vendorbilibili.Logout)
@@ -336,5 +418,85 @@ func initVendor(vendor *gin.RouterGroup) {
 		emby.GET("/me", vendoremby.Me)
 
 		emby.GET("/binds", vendoremby.Binds)
+
+		emby.POST("/report", vendoremby.ReportPlayback)
+	}
+
+	{
+		jellyfin := vendor.Group("/jellyfin")
+
+		jellyfin.POST("/login", vendorjellyfin.Login)
+
+		jellyfin.POST("/logout", vendorjellyfin.Logout)
+
+		jellyfin.POST("/list", vendorjellyfin.List)
+
+		jellyfin.GET("/me", vendorjellyfin.Me)
+
+		jellyfin.GET("/binds", vendorjellyfin.Binds)
+	}
+
+	{
+		plex := vendor.Group("/plex")
+
+		plex.POST("/login", vendorplex.Login)
+
+		plex.POST("/logout", vendorplex.Logout)
+
+		plex.POST("/list", vendorplex.List)
+
+		plex.GET("/me", vendorplex.Me)
+
+		plex.GET("/binds", vendorplex.Binds)
+	}
+
+	{
+		webdav := vendor.Group("/webdav")
+
+		webdav.POST("/login", vendorwebdav.Login)
+
+		webdav.POST("/logout", vendorwebdav.Logout)
+
+		webdav.POST("/list", vendorwebdav.List)
+
+		webdav.GET("/me", vendorwebdav.Me)
+
+		webdav.GET("/binds", vendorwebdav.Binds)
+	}
+
+	{
+		subsonic := vendor.Group("/subsonic")
+
+		subsonic.POST("/login", vendorsubsonic.Login)
+
+		subsonic.POST("/logout", vendorsubsonic.Logout)
+
+		subsonic.POST("/list", vendorsubsonic.List)
+
+		subsonic.GET("/me", vendorsubsonic.Me)
+
+		subsonic.GET("/binds", vendorsubsonic.Binds)
+	}
+
+	{
+		local := vendor.Group("/local")
+
+		local.POST("/list", vendorlocal.List)
+	}
+
+	vendor.GET("/plugins", vendorplugin.Plugins)
+
+	{
+		plugin := vendor.Group("/plugin/:plugin")
+
+		plugin.POST("/login", vendorplugin.Login)
+
+		plugin.POST("/logout", vendorplugin.Logout)
+
+		plugin.POST("/list", vendorplugin.List)
+
+		plugin.GET("/me", vendorplugin.Me)
+
+		plugin.GET("/binds", vendorplugin.Binds)
 	}
 }
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/iptv"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+// max time a movie list waits for a guide that is not loaded yet
+const epgLoadTimeout = 10 * time.Second
+
+func truncateName(name string) string {
+	if len(name) > 256 {
+		return utils.TruncateByRune(name, 253) + "..."
+	}
+	return name
+}
+
+// ImportM3U adds the channels of an extended m3u playlist to a folder of the room as
+// live movies, with the guide of the playlist attached when it has one.
+func ImportM3U(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	if !user.HasRoomPermission(room, dbModel.PermissionAddMovie) {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			model.NewAPIErrorResp(fmt.Errorf("import m3u error: %w", dbModel.ErrNoPermission)),
+		)
+		return
+	}
+
+	req := model.ImportM3UReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	var (
+		playlist *iptv.Playlist
+		err      error
+	)
+	if req.Content != "" {
+		playlist, err = iptv.ParseM3U(strings.NewReader(req.Content))
+	} else if err = op.CheckRemoteURL(req.URL, "http", "https"); err == nil {
+		playlist, err = iptv.FetchM3U(ctx, req.URL)
+	}
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(fmt.Errorf("load playlist error: %w", err)),
+		)
+		return
+	}
+	if len(playlist.Channels) == 0 {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("no channel found"),
+		)
+		return
+	}
+
+	epgURL := req.EPGURL
+	if epgURL == "" {
+		epgURL = playlist.EPGURL
+	}
+	if epgURL != "" {
+		if err := op.CheckRemoteURL(epgURL, "http", "https"); err != nil {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorResp(fmt.Errorf("invalid epg url: %w", err)),
+			)
+			return
+		}
+	}
+
+	if req.Name == "" && req.URL != "" {
+		if u, err := url.Parse(req.URL); err == nil {
+			req.Name = truncateName(strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path)))
+		}
+	}
+	if req.Name == "" || req.Name == "." || req.Name == "/" {
+		req.Name = "IPTV"
+	}
+
+	folder, err := user.AddRoomMovie(room, &dbModel.MovieBase{
+		Name:     req.Name,
+		IsFolder: true,
+		ParentID: dbModel.EmptyNullString(req.ParentID),
+	})
+	if err != nil {
+		log.Errorf("import m3u error: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				model.NewAPIErrorResp(fmt.Errorf("import m3u error: %w", err)),
+			)
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	count, err := importChannels(user, room, folder.ID, playlist.Channels, epgURL, &req)
+	if err != nil {
+		log.Errorf("import m3u error: %v", err)
+		// do not leave an empty folder behind
+		if err := room.DeleteMovieByID(folder.ID); err != nil {
+			log.Errorf("delete import folder error: %v", err)
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&model.ImportM3UResp{
+		Folder: folder,
+		Count:  count,
+	}))
+}
+
+func importChannels(
+	user *op.User,
+	room *op.Room,
+	folderID string,
+	channels []*iptv.Channel,
+	epgURL string,
+	req *model.ImportM3UReq,
+) (int, error) {
+	groups := map[string]string{}
+	movies := make([]*dbModel.MovieBase, 0, len(channels))
+	for _, c := range channels {
+		if len(c.URL) > 8192 {
+			continue
+		}
+		parentID := folderID
+		if req.GroupFolders && c.Group != "" {
+			id, ok := groups[c.Group]
+			if !ok {
+				g, err := user.AddRoomMovie(room, &dbModel.MovieBase{
+					Name:     truncateName(c.Group),
+					IsFolder: true,
+					ParentID: dbModel.EmptyNullString(folderID),
+				})
+				if err != nil {
+					return 0, err
+				}
+				id = g.ID
+				groups[c.Group] = id
+			}
+			parentID = id
+		}
+		m := &dbModel.MovieBase{
+			Name:     truncateName(c.Name),
+			URL:      c.URL,
+			Live:     true,
+			Proxy:    req.Proxy,
+			Headers:  c.Headers,
+			ParentID: dbModel.EmptyNullString(parentID),
+			TvgID:    c.TvgID,
+			Logo:     c.Logo,
+			EPGURL:   epgURL,
+		}
+		if m.TvgID == "" {
+			m.TvgID = c.TvgName
+		}
+		if len(m.Logo) > 8192 {
+			m.Logo = ""
+		}
+		if len(m.Headers) == 0 {
+			m.Headers = nil
+		}
+		movies = append(movies, m)
+	}
+	if len(movies) == 0 {
+		return 0, errors.New("no channel found")
+	}
+	if _, err := user.AddRoomMovies(room, movies); err != nil {
+		return 0, err
+	}
+	return len(movies), nil
+}
+
+// fillProgrammes sets the programmes now and next of the live channels with a guide,
+// a guide failing to load leaves its channels without programmes.
+func fillProgrammes(ctx *gin.Context, movies []*model.Movie) {
+	log := middlewares.GetLogger(ctx)
+	now := time.Now()
+	failed := map[string]bool{}
+	for _, m := range movies {
+		b := &m.Base
+		if !b.Live || b.EPGURL == "" || failed[b.EPGURL] {
+			continue
+		}
+		loadCtx, cancel := context.WithTimeout(ctx, epgLoadTimeout)
+		guide, err := op.LoadEPGGuide(loadCtx, b.EPGURL)
+		cancel()
+		if err != nil {
+			log.Warnf("load epg guide %s error: %v", b.EPGURL, err)
+			failed[b.EPGURL] = true
+			continue
+		}
+		cur, next := guide.NowNext(now, b.TvgID, b.Name)
+		if cur == nil && next == nil {
+			continue
+		}
+		m.Programmes = &model.Programmes{
+			Now:  toProgramme(cur),
+			Next: toProgramme(next),
+		}
+	}
+}
+
+func toProgramme(p *iptv.Programme) *model.Programme {
+	if p == nil {
+		return nil
+	}
+	resp := &model.Programme{
+		Title: p.Title,
+		Desc:  p.Desc,
+		Start: p.Start.UnixMilli(),
+	}
+	if !p.Stop.IsZero() {
+		resp.Stop = p.Stop.UnixMilli()
+	}
+	return resp
+}
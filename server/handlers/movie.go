@@ -13,10 +13,13 @@ import (
 	"net/url"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/PeterChen1997/synctv/internal/conf"
+	"github.com/PeterChen1997/synctv/internal/llhls"
 	dbModel "github.com/PeterChen1997/synctv/internal/model"
 	"github.com/PeterChen1997/synctv/internal/op"
 	"github.com/PeterChen1997/synctv/internal/rtmp"
@@ -171,6 +174,27 @@ func genCurrentRespWithCurrent(
 		Movie:    mr,
 		ExpireID: expireID,
 	}
+	if room.IsMusicMode() {
+		resp.MusicMode = true
+		track, err := opMovie.Track(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get current track error: %w", err)
+		}
+		resp.Track = &model.Track{
+			Title:    track.Title,
+			Artist:   track.Artist,
+			Album:    track.Album,
+			Duration: track.Duration,
+		}
+		if track.HasCover {
+			resp.Track.CoverURL = fmt.Sprintf(
+				"/api/room/movie/proxy/%s?t=cover&token=%s&roomId=%s",
+				opMovie.ID,
+				userToken,
+				opMovie.RoomID,
+			)
+		}
+	}
 	return resp, nil
 }
 
@@ -295,6 +319,7 @@ func Movies(ctx *gin.Context) {
 			resp.Movies[i].Base.Headers = nil
 		}
 	}
+	fillProgrammes(ctx, resp.Movies)
 
 	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
 }
@@ -601,6 +626,50 @@ func SwapMovie(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+// MovieSchedule lists the channel schedule from the item playing now, like an epg.
+func MovieSchedule(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	if !user.HasRoomPermission(room, dbModel.PermissionGetMovieList) {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			model.NewAPIErrorResp(dbModel.ErrNoPermission),
+		)
+		return
+	}
+
+	_, _max, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		log.Errorf("get page and max error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	items, err := room.Schedule(time.Now(), _max)
+	if err != nil {
+		log.Errorf("get room schedule error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := &model.ScheduleResp{
+		ChannelMode: room.IsChannelMode(),
+		Items:       make([]*model.ScheduleItem, len(items)),
+	}
+	for i, item := range items {
+		resp.Items[i] = &model.ScheduleItem{
+			ID:       item.Movie.ID,
+			Name:     item.Movie.Name,
+			Duration: item.Movie.Duration,
+			Start:    item.Start.UnixMilli(),
+			End:      item.End.UnixMilli(),
+		}
+	}
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
+
 func ChangeCurrentMovie(ctx *gin.Context) {
 	room := middlewares.GetRoomEntry(ctx).Value()
 	user := middlewares.GetUserEntry(ctx).Value()
@@ -919,6 +988,14 @@ func JoinHlsLive(ctx *gin.Context) {
 		}
 		return
 	}
+	if m.TranscodeEnabled() {
+		joinTranscodedHlsLive(ctx, room.ID, m)
+		return
+	}
+	if m.LowLatencyHlsEnabled() {
+		joinLowLatencyHlsLive(ctx, room.ID, m)
+		return
+	}
 	channel, err := m.Channel()
 	if err != nil {
 		log.Errorf("join hls live error: %v", err)
@@ -947,6 +1024,108 @@ func JoinHlsLive(ctx *gin.Context) {
 	ctx.Data(http.StatusOK, hls.M3U8ContentType, b)
 }
 
+// joinTranscodedHlsLive serves the multivariant playlist, or the media playlist of
+// the rendition given in the query.
+func joinTranscodedHlsLive(ctx *gin.Context, roomID string, m *op.Movie) {
+	log := middlewares.GetLogger(ctx)
+
+	t, err := m.Transcoder()
+	if err != nil {
+		log.Errorf("join transcoded hls live error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorResp(err))
+		return
+	}
+
+	rendition := ctx.Query("rendition")
+	if rendition == "" {
+		ctx.Data(http.StatusOK, hls.M3U8ContentType, t.MasterPlaylist(func(name string) string {
+			return fmt.Sprintf(
+				"/api/room/movie/live/hls/list/%s.m3u8?rendition=%s&token=%s&roomId=%s",
+				m.ID,
+				name,
+				ctx.GetString("token"),
+				roomID,
+			)
+		}))
+		return
+	}
+
+	b, err := t.MediaPlaylist(rendition, func(seg string) string {
+		seg = strings.TrimSuffix(seg, filepath.Ext(seg))
+		ext := "ts"
+		if settings.TSDisguisedAsPng.Get() {
+			ext = "png"
+		}
+		return fmt.Sprintf(
+			"/api/room/movie/live/hls/data/%s/%s/%s.%s?rendition=%s",
+			roomID,
+			m.ID,
+			seg,
+			ext,
+			rendition,
+		)
+	})
+	if err != nil {
+		log.Errorf("join transcoded hls live error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorResp(err))
+		return
+	}
+	ctx.Data(http.StatusOK, hls.M3U8ContentType, b)
+}
+
+// joinLowLatencyHlsLive serves the low latency playlist, honoring the _HLS_msn and
+// _HLS_part blocking reload directives.
+func joinLowLatencyHlsLive(ctx *gin.Context, roomID string, m *op.Movie) {
+	log := middlewares.GetLogger(ctx)
+
+	src, err := m.LowLatencyHls()
+	if err != nil {
+		log.Errorf("join low latency hls live error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorResp(err))
+		return
+	}
+
+	msn, part := int64(-1), -1
+	if v := ctx.Query("_HLS_msn"); v != "" {
+		msn, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || msn < 0 {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp("invalid _HLS_msn"))
+			return
+		}
+		if v := ctx.Query("_HLS_part"); v != "" {
+			part, err = strconv.Atoi(v)
+			if err != nil || part < 0 {
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp("invalid _HLS_part"))
+				return
+			}
+		}
+	}
+
+	b, err := src.Playlist(ctx.Request.Context(), msn, part, func(name string) string {
+		ext := "ts"
+		if settings.TSDisguisedAsPng.Get() {
+			ext = "png"
+		}
+		return fmt.Sprintf(
+			"/api/room/movie/live/hls/data/%s/%s/%s.%s",
+			roomID,
+			m.ID,
+			name,
+			ext,
+		)
+	})
+	if err != nil {
+		log.Errorf("join low latency hls live error: %v", err)
+		if errors.Is(err, llhls.ErrMSNRange) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorResp(err))
+		return
+	}
+	ctx.Data(http.StatusOK, hls.M3U8ContentType, b)
+}
+
 //nolint:gosec
 func ServeHlsLive(ctx *gin.Context) {
 	log := middlewares.GetLogger(ctx)
@@ -990,7 +1169,7 @@ func ServeHlsLive(ctx *gin.Context) {
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp("live proxy is not enabled"))
 		return
 	}
-	channel, err := m.Channel()
+	getTsFile, err := hlsLiveTsGetter(ctx, m, ctx.Query("rendition"))
 	if err != nil {
 		log.Errorf("serve hls live error: %v", err)
 		ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorResp(err))
@@ -1008,7 +1187,7 @@ func ServeHlsLive(ctx *gin.Context) {
 			)
 			return
 		}
-		b, err := channel.GetTsFile(strings.TrimSuffix(dataID, fileExt))
+		b, err := getTsFile(strings.TrimSuffix(dataID, fileExt))
 		if err != nil {
 			log.Errorf("serve hls live error: %v", err)
 			ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorResp(err))
@@ -1025,7 +1204,7 @@ func ServeHlsLive(ctx *gin.Context) {
 			)
 			return
 		}
-		b, err := channel.GetTsFile(strings.TrimSuffix(dataID, fileExt))
+		b, err := getTsFile(strings.TrimSuffix(dataID, fileExt))
 		if err != nil {
 			log.Errorf("serve hls live error: %v", err)
 			ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorResp(err))
@@ -1051,3 +1230,36 @@ func ServeHlsLive(ctx *gin.Context) {
 		)
 	}
 }
+
+// hlsLiveTsGetter returns the segment reader of the live channel, of a transcoded
+// rendition when one is requested, or of the low latency source when it is enabled.
+func hlsLiveTsGetter(
+	ctx *gin.Context,
+	m *op.Movie,
+	rendition string,
+) (func(tsName string) ([]byte, error), error) {
+	switch {
+	case rendition != "":
+		t, err := m.Transcoder()
+		if err != nil {
+			return nil, err
+		}
+		return func(tsName string) ([]byte, error) {
+			return t.Segment(rendition, tsName+".ts")
+		}, nil
+	case m.LowLatencyHlsEnabled():
+		src, err := m.LowLatencyHls()
+		if err != nil {
+			return nil, err
+		}
+		return func(tsName string) ([]byte, error) {
+			return src.Get(ctx.Request.Context(), tsName)
+		}, nil
+	default:
+		channel, err := m.Channel()
+		if err != nil {
+			return nil, err
+		}
+		return channel.GetTsFile, nil
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+func MusicQueue(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	items, err := room.MusicQueue()
+	if err != nil {
+		log.Errorf("get music queue error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.MusicQueueItem, len(items))
+	for i, item := range items {
+		resp[i] = &model.MusicQueueItem{
+			ID:          item.ID,
+			MovieID:     item.MovieID,
+			SubPath:     item.SubPath,
+			Requester:   op.GetUserName(item.UserID),
+			RequesterID: item.UserID,
+			CreatedAt:   item.CreatedAt.UnixMilli(),
+		}
+		if m, err := room.GetMovieByID(item.MovieID); err == nil {
+			resp[i].Name = m.Name
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
+
+func handleMusicError(ctx *gin.Context, msg string, err error) {
+	log := middlewares.GetLogger(ctx)
+	log.Errorf("%s: %v", msg, err)
+	if errors.Is(err, dbModel.ErrNoPermission) {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			model.NewAPIErrorResp(fmt.Errorf("%s: %w", msg, err)),
+		)
+		return
+	}
+	ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+}
+
+func RequestTrack(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := model.RequestTrackReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	item, err := user.RequestRoomTrack(room, req.ID, req.SubPath)
+	if err != nil {
+		handleMusicError(ctx, "request track error", err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(gin.H{
+		"id": item.ID,
+	}))
+}
+
+func DeleteTrackRequest(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := model.IDReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.DeleteRoomTrackRequest(room, req.ID); err != nil {
+		handleMusicError(ctx, "delete track request error", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func SkipTrack(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	if err := user.SkipRoomTrack(room); err != nil {
+		handleMusicError(ctx, "skip track error", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
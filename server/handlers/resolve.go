@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/extractor"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+const resolveTimeout = 30 * time.Second
+
+// ResolveMovie returns the movies found in a web page for the user to pick one to push.
+func ResolveMovie(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	if !user.HasRoomPermission(room, dbModel.PermissionAddMovie) {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			model.NewAPIErrorResp(
+				fmt.Errorf("resolve movie error: %w", dbModel.ErrNoPermission),
+			),
+		)
+		return
+	}
+
+	req := model.ResolveMovieReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := op.CheckRemoteURL(req.URL, "http", "https"); err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(fmt.Errorf("check url error: %w", err)),
+		)
+		return
+	}
+
+	rctx, cancel := context.WithTimeout(ctx, resolveTimeout)
+	defer cancel()
+	candidates, err := extractor.Resolve(rctx, req.URL)
+	if err != nil {
+		if errors.Is(err, extractor.ErrNoCandidate) ||
+			errors.Is(err, extractor.ErrUnsupportedScheme) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		log.Errorf("resolve movie error: %v", err)
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(fmt.Errorf("resolve movie error: %w", err)),
+		)
+		return
+	}
+
+	// the page can point to any url, only the ones the server may fetch are offered
+	resp := make([]*model.MovieCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if op.CheckRemoteURL(c.Movie.URL, "http", "https") != nil {
+			continue
+		}
+		resp = append(resp, &model.MovieCandidate{
+			Movie:     c.Movie,
+			Extractor: c.Extractor,
+		})
+	}
+	if len(resp) == 0 {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(extractor.ErrNoCandidate),
+		)
+		return
+	}
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
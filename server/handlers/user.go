@@ -896,3 +896,68 @@ func UserExitRoom(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func UserBlockedUsers(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	blocks, err := user.BlockedUsers()
+	if err != nil {
+		log.Errorf("failed to get blocked users: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := make([]*model.UserBlockResp, len(blocks))
+	for i, b := range blocks {
+		resp[i] = &model.UserBlockResp{
+			UserID:    b.BlockedUserID,
+			Username:  op.GetUserName(b.BlockedUserID),
+			CreatedAt: b.CreatedAt.UnixMilli(),
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
+
+// UserBlockUser keeps the user from sending direct messages to the other and the other
+// from sending them to the user.
+func UserBlockUser(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	var req model.UserIDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("failed to decode request: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.BlockUser(req.ID); err != nil {
+		log.Errorf("failed to block user: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func UserUnblockUser(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	var req model.UserIDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		log.Errorf("failed to decode request: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.UnblockUser(req.ID); err != nil {
+		log.Errorf("failed to unblock user: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,371 @@
+package vendors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	bilibiliSearch "github.com/PeterChen1997/synctv/internal/vendors/bilibili"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/PeterChen1997/vendors/api/alist"
+	"github.com/PeterChen1997/vendors/api/bilibili"
+	"github.com/PeterChen1997/vendors/api/emby"
+)
+
+// a slow or unreachable server only drops its own results
+var searchTimeouts = map[string]time.Duration{
+	dbModel.VendorAlist:    8 * time.Second,
+	dbModel.VendorEmby:     8 * time.Second,
+	dbModel.VendorBilibili: 10 * time.Second,
+}
+
+type SearchReq struct {
+	Keyword string `json:"keyword"`
+}
+
+func (r *SearchReq) Validate() error {
+	r.Keyword = strings.TrimSpace(r.Keyword)
+	if r.Keyword == "" {
+		return errors.New("keyword is empty")
+	}
+	if len(r.Keyword) > 128 {
+		return errors.New("keyword is too long")
+	}
+	return nil
+}
+
+func (r *SearchReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type SearchResult struct {
+	// Movie can be pushed to a room as it is
+	Movie  *dbModel.MovieBase `json:"movie"`
+	Vendor string             `json:"vendor"`
+	// Server is the host of the server, or the author of a bilibili video
+	Server string `json:"server"`
+	Cover  string `json:"cover,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Score  int    `json:"score"`
+}
+
+type SearchError struct {
+	Vendor string `json:"vendor"`
+	Server string `json:"server"`
+	Error  string `json:"error"`
+}
+
+type SearchResp struct {
+	Results []*SearchResult `json:"results"`
+	Errors  []*SearchError  `json:"errors,omitempty"`
+}
+
+type searcher struct {
+	vendor string
+	server string
+	search func(ctx context.Context) ([]*SearchResult, error)
+}
+
+// Search fans the keyword out to every alist and emby server and the bilibili account
+// the user has bound, the results of all of them are merged into one ranked list.
+func Search(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	req := SearchReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	page, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	searchers, err := userSearchers(ctx, user, req.Keyword, page, size)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := &SearchResp{
+		Results: []*SearchResult{},
+	}
+	var (
+		lock sync.Mutex
+		wg   sync.WaitGroup
+	)
+	for _, s := range searchers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sctx, cancel := context.WithTimeout(ctx, searchTimeouts[s.vendor])
+			defer cancel()
+			results, err := s.search(sctx)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				log.Warnf("search %s server %s error: %v", s.vendor, s.server, err)
+				resp.Errors = append(resp.Errors, &SearchError{
+					Vendor: s.vendor,
+					Server: s.server,
+					Error:  err.Error(),
+				})
+				return
+			}
+			resp.Results = append(resp.Results, results...)
+		}()
+	}
+	wg.Wait()
+
+	rankSearchResults(resp.Results, req.Keyword)
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
+
+func userSearchers(
+	ctx context.Context,
+	user *op.User,
+	keyword string,
+	page, size int,
+) ([]*searcher, error) {
+	var searchers []*searcher
+
+	avs, err := db.GetAlistVendors(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range avs {
+		searchers = append(searchers, &searcher{
+			vendor: dbModel.VendorAlist,
+			server: v.Host,
+			search: func(ctx context.Context) ([]*SearchResult, error) {
+				aucd, err := user.AlistCache().LoadOrStore(ctx, v.ServerID)
+				if err != nil {
+					return nil, err
+				}
+				return searchAlist(ctx, aucd, keyword, page, size)
+			},
+		})
+	}
+
+	evs, err := db.GetEmbyVendors(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range evs {
+		searchers = append(searchers, &searcher{
+			vendor: dbModel.VendorEmby,
+			server: v.Host,
+			search: func(ctx context.Context) ([]*SearchResult, error) {
+				eucd, err := user.EmbyCache().LoadOrStore(ctx, v.ServerID)
+				if err != nil {
+					return nil, err
+				}
+				return searchEmby(ctx, eucd, keyword, page, size)
+			},
+		})
+	}
+
+	bucd, err := user.BilibiliCache().Get(ctx)
+	if err != nil {
+		if !errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			return nil, err
+		}
+	} else {
+		searchers = append(searchers, &searcher{
+			vendor: dbModel.VendorBilibili,
+			server: "bilibili.com",
+			search: func(ctx context.Context) ([]*SearchResult, error) {
+				return searchBilibili(ctx, bucd, keyword, page, size)
+			},
+		})
+	}
+
+	return searchers, nil
+}
+
+func searchAlist(
+	ctx context.Context,
+	aucd *cache.AlistUserCacheData,
+	keyword string,
+	page, size int,
+) ([]*SearchResult, error) {
+	data, err := vendor.LoadAlistClient(aucd.Backend).FsSearch(ctx, &alist.FsSearchReq{
+		Token:    aucd.Token,
+		Parent:   "/",
+		Keywords: keyword,
+		Host:     aucd.Host,
+		Page:     uint64(page),
+		PerPage:  uint64(size),
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*SearchResult, 0, len(data.GetContent()))
+	for _, c := range data.GetContent() {
+		p := strings.Trim(fmt.Sprintf("%s/%s", c.GetParent(), c.GetName()), "/")
+		results = append(results, &SearchResult{
+			Vendor: dbModel.VendorAlist,
+			Server: aucd.Host,
+			Movie: &dbModel.MovieBase{
+				Name:     c.GetName(),
+				IsFolder: c.GetIsDir(),
+				VendorInfo: dbModel.VendorInfo{
+					Vendor: dbModel.VendorAlist,
+					Alist: &dbModel.AlistStreamingInfo{
+						Path: dbModel.FormatAlistPath(aucd.ServerID, p),
+					},
+				},
+			},
+		})
+	}
+	return results, nil
+}
+
+func searchEmby(
+	ctx context.Context,
+	eucd *cache.EmbyUserCacheData,
+	keyword string,
+	page, size int,
+) ([]*SearchResult, error) {
+	data, err := vendor.LoadEmbyClient(eucd.Backend).FsList(ctx, &emby.FsListReq{
+		Host:       eucd.Host,
+		Token:      eucd.APIKey,
+		UserId:     eucd.UserID,
+		Limit:      uint64(size),
+		StartIndex: uint64((page - 1) * size),
+		SearchTerm: keyword,
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*SearchResult, 0, len(data.GetItems()))
+	for _, i := range data.GetItems() {
+		results = append(results, &SearchResult{
+			Vendor: dbModel.VendorEmby,
+			Server: eucd.Host,
+			Type:   i.GetType(),
+			Movie: &dbModel.MovieBase{
+				Name:     i.GetName(),
+				IsFolder: i.GetIsFolder(),
+				VendorInfo: dbModel.VendorInfo{
+					Vendor: dbModel.VendorEmby,
+					Emby: &dbModel.EmbyStreamingInfo{
+						Path: dbModel.FormatEmbyPath(eucd.ServerID, i.GetId()),
+					},
+				},
+			},
+		})
+	}
+	return results, nil
+}
+
+// searchBilibili resolves the first part of every video found, a bilibili movie needs
+// its cid to be played.
+func searchBilibili(
+	ctx context.Context,
+	bucd *cache.BilibiliUserCacheData,
+	keyword string,
+	page, size int,
+) ([]*SearchResult, error) {
+	// bilibili pages its search results by 20 and can not change the page size
+	videos, _, err := bilibiliSearch.SearchVideos(ctx, bucd.Cookies, keyword, page)
+	if err != nil {
+		return nil, err
+	}
+	if len(videos) > size {
+		videos = videos[:size]
+	}
+
+	cli := vendor.LoadBilibiliClient(bucd.Backend)
+	cookies := utils.HTTPCookieToMap(bucd.Cookies)
+	results := make([]*SearchResult, len(videos))
+	var wg sync.WaitGroup
+	for i, v := range videos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			info, err := cli.ParseVideoPage(ctx, &bilibili.ParseVideoPageReq{
+				Cookies: cookies,
+				Bvid:    v.Bvid,
+			})
+			if err != nil || len(info.GetVideoInfos()) == 0 {
+				return
+			}
+			results[i] = &SearchResult{
+				Vendor: dbModel.VendorBilibili,
+				Server: v.Author,
+				Cover:  v.Cover,
+				Movie: &dbModel.MovieBase{
+					Name:     v.Title,
+					Duration: v.Duration,
+					VendorInfo: dbModel.VendorInfo{
+						Vendor: dbModel.VendorBilibili,
+						Bilibili: &dbModel.BilibiliStreamingInfo{
+							Bvid: v.Bvid,
+							Cid:  info.GetVideoInfos()[0].GetCid(),
+						},
+					},
+				},
+			}
+		}()
+	}
+	wg.Wait()
+	return slices.DeleteFunc(results, func(r *SearchResult) bool {
+		return r == nil
+	}), nil
+}
+
+// rankSearchResults sorts by how well the name matches the keyword, results of the same
+// score keep the order their vendor ranked them in.
+func rankSearchResults(results []*SearchResult, keyword string) {
+	keyword = strings.ToLower(keyword)
+	for _, r := range results {
+		r.Score = searchScore(strings.ToLower(r.Movie.Name), keyword)
+	}
+	slices.SortStableFunc(results, func(a, b *SearchResult) int {
+		return b.Score - a.Score
+	})
+}
+
+func searchScore(name, keyword string) int {
+	base := strings.TrimSuffix(name, path.Ext(name))
+	switch {
+	case name == keyword || base == keyword:
+		return 100
+	case strings.HasPrefix(name, keyword):
+		return 80
+	case strings.Contains(name, keyword):
+		return 60
+	}
+	// fuzzy matches of the vendor, score by the words of the keyword found in the name
+	words := strings.Fields(keyword)
+	found := 0
+	for _, w := range words {
+		if strings.Contains(name, w) {
+			found++
+		}
+	}
+	if len(words) == 0 {
+		return 0
+	}
+	return found * 40 / len(words)
+}
@@ -0,0 +1,299 @@
+package vendorbilibili
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	bilibiliWeb "github.com/PeterChen1997/synctv/internal/vendors/bilibili"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"github.com/PeterChen1997/vendors/api/bilibili"
+)
+
+const (
+	ImportTypeVideo      = "video"
+	ImportTypeFavorites  = "favorites"
+	ImportTypeWatchLater = "watchlater"
+	ImportTypeSeason     = "season"
+
+	// max movies of a favorites folder imported at once
+	maxImportFavorites = 1000
+)
+
+type ImportReq struct {
+	Type string `json:"type"`
+	// ID is the bvid or avid of a video, the media id of a favorites folder or the ssid
+	// or epid of a bangumi season, the watch later list needs none
+	ID string `json:"id"`
+	// Name of the folder, the title of the imported list by default
+	Name     string `json:"name"`
+	ParentID string `json:"parentId"`
+	Shared   bool   `json:"shared"`
+}
+
+func (r *ImportReq) Validate() error {
+	switch r.Type {
+	case ImportTypeWatchLater:
+	case ImportTypeVideo, ImportTypeFavorites, ImportTypeSeason:
+		if r.ID == "" {
+			return errors.New("id is empty")
+		}
+	default:
+		return fmt.Errorf("unknown import type: %s", r.Type)
+	}
+	if len(r.Name) > 256 {
+		r.Name = utils.TruncateByRune(r.Name, 253) + "..."
+	}
+	return nil
+}
+
+func (r *ImportReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type ImportResp struct {
+	Folder *dbModel.Movie `json:"folder"`
+	Count  int            `json:"count"`
+}
+
+// parseID parses an id with an optional prefix, e.g. ss123 or ep123.
+func parseID(id string, prefixes ...string) (string, uint64, error) {
+	lower := strings.ToLower(id)
+	for _, p := range prefixes {
+		if rest, ok := strings.CutPrefix(lower, p); ok {
+			n, err := strconv.ParseUint(rest, 10, 64)
+			return p, n, err
+		}
+	}
+	n, err := strconv.ParseUint(id, 10, 64)
+	return "", n, err
+}
+
+func videoInfosToMovies(infos []*bilibili.VideoInfo, shared bool) []*dbModel.MovieBase {
+	movies := make([]*dbModel.MovieBase, 0, len(infos))
+	for _, v := range infos {
+		if v.GetCid() == 0 {
+			continue
+		}
+		movies = append(movies, &dbModel.MovieBase{
+			Name: v.GetName(),
+			VendorInfo: dbModel.VendorInfo{
+				Vendor: dbModel.VendorBilibili,
+				Bilibili: &dbModel.BilibiliStreamingInfo{
+					Bvid:   v.GetBvid(),
+					Cid:    v.GetCid(),
+					Epid:   v.GetEpid(),
+					Shared: shared,
+				},
+			},
+		})
+	}
+	return movies
+}
+
+func videosToMovies(videos []*bilibiliWeb.Video, shared bool) []*dbModel.MovieBase {
+	movies := make([]*dbModel.MovieBase, len(videos))
+	for i, v := range videos {
+		movies[i] = &dbModel.MovieBase{
+			Name:     v.Title,
+			Duration: v.Duration,
+			VendorInfo: dbModel.VendorInfo{
+				Vendor: dbModel.VendorBilibili,
+				Bilibili: &dbModel.BilibiliStreamingInfo{
+					Bvid:   v.Bvid,
+					Cid:    v.Cid,
+					Shared: shared,
+				},
+			},
+		}
+	}
+	return movies
+}
+
+// Import expands a multi-part video, a favorites folder, the watch later list or a
+// bangumi season into a folder of the room, using the cookies of the bound account.
+//
+//nolint:gosec
+func Import(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	req := ImportReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	// videos and seasons can be imported without login
+	var (
+		cookies []*http.Cookie
+		backend string
+	)
+	bucd, err := user.BilibiliCache().Get(ctx)
+	if err != nil {
+		if !errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+	} else {
+		cookies = bucd.Cookies
+		backend = bucd.Backend
+	}
+	cli := vendor.LoadBilibiliClient(backend)
+
+	var (
+		title  string
+		movies []*dbModel.MovieBase
+	)
+	switch req.Type {
+	case ImportTypeVideo:
+		pvr := &bilibili.ParseVideoPageReq{
+			Cookies: utils.HTTPCookieToMap(cookies),
+		}
+		if prefix, aid, err := parseID(req.ID, "av"); err == nil && prefix != "" {
+			pvr.Aid = aid
+		} else {
+			pvr.Bvid = req.ID
+		}
+		info, err := cli.ParseVideoPage(ctx, pvr)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		title = info.GetTitle()
+		movies = videoInfosToMovies(info.GetVideoInfos(), req.Shared)
+	case ImportTypeSeason:
+		prefix, id, err := parseID(req.ID, "ss", "ep")
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		ppr := &bilibili.ParsePGCPageReq{
+			Cookies: utils.HTTPCookieToMap(cookies),
+		}
+		if prefix == "ep" {
+			ppr.Epid = id
+		} else {
+			ppr.Ssid = id
+		}
+		info, err := cli.ParsePGCPage(ctx, ppr)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		title = info.GetTitle()
+		movies = videoInfosToMovies(info.GetVideoInfos(), req.Shared)
+	case ImportTypeFavorites:
+		_, mediaID, err := parseID(req.ID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		var videos []*bilibiliWeb.Video
+		title, videos, err = bilibiliWeb.FavoriteVideos(ctx, cookies, mediaID, maxImportFavorites)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		movies = videosToMovies(videos, req.Shared)
+	case ImportTypeWatchLater:
+		videos, err := bilibiliWeb.WatchLater(ctx, cookies)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		title = "Watch Later"
+		movies = videosToMovies(videos, req.Shared)
+	}
+
+	if len(movies) == 0 {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("no playable video found"),
+		)
+		return
+	}
+	if req.Name == "" {
+		req.Name = title
+		if len(req.Name) > 256 {
+			req.Name = utils.TruncateByRune(req.Name, 253) + "..."
+		}
+	}
+	if req.Name == "" {
+		req.Name = req.ID
+	}
+
+	folder, err := user.AddRoomMovie(room, &dbModel.MovieBase{
+		Name:     req.Name,
+		IsFolder: true,
+		ParentID: dbModel.EmptyNullString(req.ParentID),
+	})
+	if err != nil {
+		log.Errorf("import bilibili error: %v", err)
+		if errors.Is(err, dbModel.ErrNoPermission) {
+			ctx.AbortWithStatusJSON(
+				http.StatusForbidden,
+				model.NewAPIErrorResp(fmt.Errorf("import bilibili error: %w", err)),
+			)
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	for _, m := range movies {
+		m.ParentID = dbModel.EmptyNullString(folder.ID)
+		if m.Name == "" {
+			m.Name = req.Name
+		} else if len(m.Name) > 256 {
+			m.Name = utils.TruncateByRune(m.Name, 253) + "..."
+		}
+	}
+	if _, err := user.AddRoomMovies(room, movies); err != nil {
+		log.Errorf("import bilibili error: %v", err)
+		// do not leave an empty folder behind
+		if err := room.DeleteMovieByID(folder.ID); err != nil {
+			log.Errorf("delete import folder error: %v", err)
+		}
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&ImportResp{
+		Folder: folder,
+		Count:  len(movies),
+	}))
+}
+
+// Favorites lists the favorites folders of the bound account.
+func Favorites(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	bucd, err := user.BilibiliCache().Get(ctx)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("bilibili not login"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	folders, err := bilibiliWeb.FavoriteFolders(ctx, bucd.Cookies)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(folders))
+}
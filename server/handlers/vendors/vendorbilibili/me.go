@@ -38,7 +38,20 @@ func Me(ctx *gin.Context) {
 	resp, err := vendor.LoadBilibiliClient(bucd.Backend).UserInfo(ctx, &bilibili.UserInfoReq{
 		Cookies: utils.HTTPCookieToMap(bucd.Cookies),
 	})
+	v, verr := db.GetBilibiliVendor(user.ID)
+	if verr != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(verr))
+		return
+	}
 	if err != nil {
+		// the validator found the login expired, the client asks the user to log in again
+		if v.Status.Expired {
+			ctx.JSON(http.StatusOK, model.NewAPIDataResp(&BilibiliMeResp{
+				IsLogin: false,
+				Status:  &v.Status,
+			}))
+			return
+		}
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
 		return
 	}
@@ -46,5 +59,6 @@ func Me(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&BilibiliMeResp{
 		IsLogin: resp.GetIsLogin(),
 		Info:    resp,
+		Status:  &v.Status,
 	}))
 }
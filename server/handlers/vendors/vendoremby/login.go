@@ -76,13 +76,20 @@ func Login(ctx *gin.Context) {
 		return
 	}
 
+	// logging in again keeps the playback report opt-in of the binding
+	var reportPlayback bool
+	if v, err := db.GetEmbyVendor(user.ID, data.GetServerId()); err == nil {
+		reportPlayback = v.ReportPlayback
+	}
+
 	_, err = db.CreateOrSaveEmbyVendor(&dbModel.EmbyVendor{
-		UserID:     user.ID,
-		ServerID:   data.GetServerId(),
-		Host:       req.Host,
-		APIKey:     data.GetToken(),
-		Backend:    backend,
-		EmbyUserID: data.GetUserId(),
+		UserID:         user.ID,
+		ServerID:       data.GetServerId(),
+		Host:           req.Host,
+		APIKey:         data.GetToken(),
+		Backend:        backend,
+		EmbyUserID:     data.GetUserId(),
+		ReportPlayback: reportPlayback,
 	})
 	if err != nil {
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
@@ -92,11 +99,12 @@ func Login(ctx *gin.Context) {
 	_, err = user.EmbyCache().
 		StoreOrRefreshWithDynamicFunc(ctx, data.GetServerId(), func(_ context.Context, key string) (*cache.EmbyUserCacheData, error) {
 			return &cache.EmbyUserCacheData{
-				Host:     req.Host,
-				ServerID: key,
-				APIKey:   data.GetToken(),
-				Backend:  backend,
-				UserID:   data.GetUserId(),
+				Host:           req.Host,
+				ServerID:       key,
+				APIKey:         data.GetToken(),
+				Backend:        backend,
+				UserID:         data.GetUserId(),
+				ReportPlayback: reportPlayback,
 			}, nil
 		})
 	if err != nil {
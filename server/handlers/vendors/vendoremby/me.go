@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
 	"github.com/PeterChen1997/synctv/internal/vendor"
 	"github.com/PeterChen1997/synctv/server/middlewares"
 	"github.com/PeterChen1997/synctv/server/model"
@@ -40,7 +41,20 @@ func Me(ctx *gin.Context) {
 		Host:  eucd.Host,
 		Token: eucd.APIKey,
 	})
+	v, verr := db.GetEmbyVendor(user.ID, serverID)
+	if verr != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(verr))
+		return
+	}
 	if err != nil {
+		// the validator found the login expired, the client asks the user to log in again
+		if v.Status.Expired {
+			ctx.JSON(http.StatusOK, model.NewAPIDataResp(&EmbyMeResp{
+				IsLogin: false,
+				Status:  &v.Status,
+			}))
+			return
+		}
 		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
 		return
 	}
@@ -48,12 +62,15 @@ func Me(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&EmbyMeResp{
 		IsLogin: true,
 		Info:    data,
+		Status:  &v.Status,
 	}))
 }
 
 type EmbyBindsResp []*struct {
-	ServerID string `json:"serverId"`
-	Host     string `json:"host"`
+	ServerID       string                `json:"serverId"`
+	Host           string                `json:"host"`
+	Status         dbModel.BindingStatus `json:"status"`
+	ReportPlayback bool                  `json:"reportPlayback"`
 }
 
 func Binds(ctx *gin.Context) {
@@ -74,11 +91,15 @@ func Binds(ctx *gin.Context) {
 	resp := make(EmbyBindsResp, len(ev))
 	for i, v := range ev {
 		resp[i] = &struct {
-			ServerID string `json:"serverId"`
-			Host     string `json:"host"`
+			ServerID       string                `json:"serverId"`
+			Host           string                `json:"host"`
+			Status         dbModel.BindingStatus `json:"status"`
+			ReportPlayback bool                  `json:"reportPlayback"`
 		}{
-			ServerID: v.ServerID,
-			Host:     v.Host,
+			ServerID:       v.ServerID,
+			Host:           v.Host,
+			Status:         v.Status,
+			ReportPlayback: v.ReportPlayback,
 		}
 	}
 
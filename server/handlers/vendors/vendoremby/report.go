@@ -0,0 +1,55 @@
+package vendoremby
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type ReportPlaybackReq struct {
+	ServerID string `json:"serverId"`
+	Enable   bool   `json:"enable"`
+}
+
+func (r *ReportPlaybackReq) Validate() error {
+	if r.ServerID == "" {
+		return errors.New("serverId is required")
+	}
+	return nil
+}
+
+func (r *ReportPlaybackReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+// ReportPlayback turns reporting the playback of watched rooms to a bound emby server
+// on or off, the server then shows the progress and watched state of the user.
+func ReportPlayback(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := ReportPlaybackReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	err := db.SetEmbyVendorReportPlayback(user.ID, req.ServerID, req.Enable)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("emby server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	// reload the binding on next use
+	user.EmbyCache().Delete(req.ServerID)
+
+	ctx.Status(http.StatusNoContent)
+}
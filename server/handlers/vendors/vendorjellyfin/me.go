@@ -0,0 +1,86 @@
+package vendorjellyfin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	"github.com/PeterChen1997/synctv/proto/vendors/jellyfin"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type JellyfinMeResp = model.VendorMeResp[*jellyfin.SystemInfoResp]
+
+func Me(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	serverID := ctx.Query("serverID")
+	if serverID == "" {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(errors.New("serverID is required")),
+		)
+		return
+	}
+
+	eucd, err := user.JellyfinCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("jellyfin server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	data, err := vendor.LoadJellyfinClient(eucd.Backend).GetSystemInfo(ctx, &jellyfin.SystemInfoReq{
+		Host:  eucd.Host,
+		Token: eucd.APIKey,
+	})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&JellyfinMeResp{
+		IsLogin: true,
+		Info:    data,
+	}))
+}
+
+type JellyfinBindsResp []*struct {
+	ServerID string `json:"serverId"`
+	Host     string `json:"host"`
+}
+
+func Binds(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	ev, err := db.GetJellyfinVendors(user.ID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusOK, model.NewAPIDataResp(&JellyfinMeResp{
+				IsLogin: false,
+			}))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := make(JellyfinBindsResp, len(ev))
+	for i, v := range ev {
+		resp[i] = &struct {
+			ServerID string `json:"serverId"`
+			Host     string `json:"host"`
+		}{
+			ServerID: v.ServerID,
+			Host:     v.Host,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
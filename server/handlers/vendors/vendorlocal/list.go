@@ -0,0 +1,190 @@
+package vendorlocal
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/local"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+type ListReq struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+}
+
+func (r *ListReq) Validate() (err error) {
+	return nil
+}
+
+func (r *ListReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type LocalFileItem struct {
+	*model.Item
+	Size int64 `json:"size"`
+}
+
+type LocalFSListResp = model.VendorFSListResp[*LocalFileItem]
+
+type listResult struct {
+	Items []*dbModel.LocalMediaFile
+	Total int64
+}
+
+// fsList lists a directory of the index, a keyword searches the names below it.
+func fsList(libraryID, dir, keyword string, page, size int) (*listResult, error) {
+	scope := db.WhereLocalMediaFileDir(dir)
+	if keyword != "" {
+		scope = db.WhereLocalMediaFileNameLikeIn(dir, keyword)
+	}
+	total, err := db.GetLocalMediaFilesCount(libraryID, scope)
+	if err != nil {
+		return nil, err
+	}
+	files, err := db.GetLocalMediaFiles(libraryID, scope, db.Paginate(page, size))
+	if err != nil {
+		return nil, err
+	}
+	return &listResult{
+		Items: files,
+		Total: total,
+	}, nil
+}
+
+//nolint:gosec
+func List(ctx *gin.Context) {
+	req := ListReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	page, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if req.Path == "" {
+		if req.Keyword != "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorStringResp(
+					"keywords is not supported when not choose library (library id is empty)",
+				),
+			)
+			return
+		}
+		socpes := [](func(*gorm.DB) *gorm.DB){
+			db.OrderByCreatedAtAsc,
+		}
+
+		total, err := db.GetLocalLibrariesCount(socpes...)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+		if total == 0 {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("local library not found"))
+			return
+		}
+
+		libraries, err := db.GetLocalLibraries(append(socpes, db.Paginate(page, size))...)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+
+		if total == 1 {
+			req.Path = libraries[0].ID + "/"
+			goto LocalFSListResp
+		}
+
+		resp := LocalFSListResp{
+			Paths: []*model.Path{
+				{
+					Name: "",
+					Path: "",
+				},
+			},
+			Total: uint64(total),
+		}
+
+		for _, l := range libraries {
+			resp.Items = append(resp.Items, &LocalFileItem{
+				Item: &model.Item{
+					Name:  l.Name,
+					Path:  l.ID + `/`,
+					IsDir: true,
+				},
+			})
+		}
+
+		ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+
+		return
+	}
+
+LocalFSListResp:
+
+	var libraryID string
+	libraryID, req.Path, err = dbModel.GetLocalLibraryIDFromPath(req.Path)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	req.Path, err = local.CleanPath(req.Path)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	library, err := db.GetLocalLibrary(libraryID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrLocalLibraryNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("local library not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	data, err := fsList(library.ID, req.Path, req.Keyword, page, size)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := LocalFSListResp{
+		Paths: model.GenDefaultPaths(req.Path, true,
+			&model.Path{
+				Name: "",
+				Path: "",
+			},
+			&model.Path{
+				Name: library.Name,
+				Path: library.ID + "/",
+			}),
+		Total: uint64(data.Total),
+	}
+	for _, f := range data.Items {
+		resp.Items = append(resp.Items, &LocalFileItem{
+			Item: &model.Item{
+				Name:  f.Name,
+				Path:  dbModel.FormatLocalPath(library.ID, f.Path),
+				IsDir: f.IsDir,
+			},
+			Size: f.Size,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
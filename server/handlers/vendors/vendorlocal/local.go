@@ -0,0 +1,246 @@
+package vendorlocal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/internal/vendors/local"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+type LocalVendorService struct {
+	room  *op.Room
+	movie *op.Movie
+}
+
+func NewLocalVendorService(room *op.Room, movie *op.Movie) (*LocalVendorService, error) {
+	if movie.VendorInfo.Vendor != dbModel.VendorLocal {
+		return nil, fmt.Errorf("local vendor not support vendor %s", movie.VendorInfo.Vendor)
+	}
+	return &LocalVendorService{
+		room:  room,
+		movie: movie,
+	}, nil
+}
+
+// file resolves the movie, or the selected file of a dynamic folder, to its library and
+// index entry.
+func (s *LocalVendorService) file() (*dbModel.LocalLibrary, *dbModel.LocalMediaFile, error) {
+	libraryID, filePath, err := s.movie.VendorInfo.Local.LibraryIDAndFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
+	filePath, err = local.CleanPath(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.movie.IsFolder {
+		subPath := s.movie.SubPath()
+		if subPath == "" {
+			return nil, nil, errors.New("sub path is empty")
+		}
+		subPath, err = local.CleanPath(subPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !local.InDir(filePath, subPath) {
+			return nil, nil, errors.New("sub path is not in parent path")
+		}
+		filePath = subPath
+	}
+	library, err := db.GetLocalLibrary(libraryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := db.GetLocalMediaFile(libraryID, filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if file.IsDir {
+		return nil, nil, errors.New("local path is a directory")
+	}
+	return library, file, nil
+}
+
+//nolint:gosec
+func (s *LocalVendorService) ListDynamicMovie(
+	_ context.Context,
+	reqUser *op.User,
+	subPath, keyword string,
+	page, _max int,
+) (*model.MovieList, error) {
+	if reqUser.ID != s.movie.CreatorID {
+		return nil, fmt.Errorf("list vendor dynamic folder error: %w", dbModel.ErrNoPermission)
+	}
+
+	resp := &model.MovieList{
+		Paths: []*model.MoviePath{},
+	}
+
+	libraryID, truePath, err := s.movie.VendorInfo.Local.LibraryIDAndFilePath()
+	if err != nil {
+		return nil, fmt.Errorf("load local library id error: %w", err)
+	}
+	truePath, err = local.CleanPath(truePath)
+	if err != nil {
+		return nil, err
+	}
+	dirPath := truePath
+	if subPath != "" {
+		dirPath, err = local.CleanPath(subPath)
+		if err != nil {
+			return nil, err
+		}
+		// check new path is in parent path
+		if !local.InDir(truePath, dirPath) {
+			return nil, errors.New("sub path is not in parent path")
+		}
+	}
+	data, err := fsList(libraryID, dirPath, keyword, page, _max)
+	if err != nil {
+		return nil, fmt.Errorf("local fs list error: %w", err)
+	}
+	resp.Total = data.Total
+	resp.Movies = make([]*model.Movie, len(data.Items))
+	for i, f := range data.Items {
+		resp.Movies[i] = &model.Movie{
+			ID:        s.movie.ID,
+			CreatedAt: s.movie.CreatedAt.UnixMilli(),
+			Creator:   op.GetUserName(s.movie.CreatorID),
+			CreatorID: s.movie.CreatorID,
+			SubPath:   f.Path,
+			Base: dbModel.MovieBase{
+				Name:     f.Name,
+				IsFolder: f.IsDir,
+				ParentID: dbModel.EmptyNullString(s.movie.ID),
+				VendorInfo: dbModel.VendorInfo{
+					Vendor: dbModel.VendorLocal,
+					Local: &dbModel.LocalStreamingInfo{
+						Path: dbModel.FormatLocalPath(libraryID, f.Path),
+					},
+				},
+			},
+		}
+	}
+	resp.Paths = model.GenDefaultSubPaths(s.movie.ID, subPath, true)
+	return resp, nil
+}
+
+// serveFile serves a file of the library with range support, the path on the server
+// is never sent to the client.
+func serveFile(ctx *gin.Context, root, filePath string) error {
+	f, info, err := local.Open(root, filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	http.ServeContent(ctx.Writer, ctx.Request, info.Name(), info.ModTime(), f)
+	return nil
+}
+
+func (s *LocalVendorService) handleProxyMovie(ctx *gin.Context) {
+	log := middlewares.GetLogger(ctx)
+
+	library, file, err := s.file()
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	if err := serveFile(ctx, library.Root, file.Path); err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusNotFound, model.NewAPIErrorStringResp("file not found"))
+	}
+}
+
+func (s *LocalVendorService) handleSubtitle(ctx *gin.Context) error {
+	library, file, err := s.file()
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(ctx.Query("id"))
+	if err != nil {
+		return err
+	}
+
+	if id < 0 || id >= len(file.Subtitles) {
+		return errors.New("id out of range")
+	}
+
+	return serveFile(ctx, library.Root, file.Subtitles[id].Path)
+}
+
+func (s *LocalVendorService) ProxyMovie(ctx *gin.Context) {
+	switch t := ctx.Query("t"); t {
+	case "":
+		s.handleProxyMovie(ctx)
+	case "subtitle":
+		if err := s.handleSubtitle(ctx); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		}
+	default:
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("unknown proxy type: "+t),
+		)
+	}
+}
+
+func (s *LocalVendorService) GenMovieInfo(
+	_ context.Context,
+	_ *op.User,
+	_, userToken string,
+) (*dbModel.Movie, error) {
+	movie := s.movie.Clone()
+
+	_, file, err := s.file()
+	if err != nil {
+		return nil, err
+	}
+
+	rawPath, err := url.JoinPath("/api/room/movie/proxy", movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	rawQuery := url.Values{}
+	rawQuery.Set("token", userToken)
+	rawQuery.Set("roomId", movie.RoomID)
+	movie.URL = (&url.URL{
+		Path:     rawPath,
+		RawQuery: rawQuery.Encode(),
+	}).String()
+	movie.Type = utils.GetFileExtension(file.Name)
+	movie.Headers = nil
+
+	for i, sub := range file.Subtitles {
+		if movie.Subtitles == nil {
+			movie.Subtitles = make(map[string]*dbModel.Subtitle, len(file.Subtitles))
+		}
+		rawQuery := url.Values{}
+		rawQuery.Set("t", "subtitle")
+		rawQuery.Set("id", strconv.Itoa(i))
+		rawQuery.Set("token", userToken)
+		rawQuery.Set("roomId", movie.RoomID)
+		movie.Subtitles[sub.Name] = &dbModel.Subtitle{
+			URL: (&url.URL{
+				Path:     rawPath,
+				RawQuery: rawQuery.Encode(),
+			}).String(),
+			Type: sub.Type,
+		}
+	}
+
+	return movie, nil
+}
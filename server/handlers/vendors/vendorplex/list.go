@@ -0,0 +1,308 @@
+package vendorplex
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/plex"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+type ListReq struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+}
+
+func (r *ListReq) Validate() (err error) {
+	return nil
+}
+
+func (r *ListReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type PlexFileItem struct {
+	*model.Item
+	Type string `json:"type"`
+}
+
+type PlexFSListResp = model.VendorFSListResp[*PlexFileItem]
+
+type listItem struct {
+	Name     string
+	Path     string
+	Type     string
+	IsFolder bool
+}
+
+type listResult struct {
+	// Paths are the breadcrumb below the server, without the root and the server itself
+	Paths []*model.Path
+	Items []*listItem
+	Total uint64
+}
+
+func paginate[T any](s []T, start, size int) []T {
+	if start >= len(s) {
+		return nil
+	}
+	return s[start:min(start+size, len(s))]
+}
+
+func metadataItems(ms []*plex.Metadata) []*listItem {
+	items := make([]*listItem, len(ms))
+	for i, m := range ms {
+		name := m.Title
+		switch m.Type {
+		case "season":
+			if m.ParentTitle != "" {
+				name = fmt.Sprintf("%s - %s", m.ParentTitle, m.Title)
+			}
+		case "episode":
+			if m.GrandparentTitle != "" {
+				name = fmt.Sprintf("%s - %s", m.GrandparentTitle, m.Title)
+			}
+		}
+		items[i] = &listItem{
+			Name:     name,
+			Path:     m.Path(),
+			Type:     m.Type,
+			IsFolder: m.IsFolder(),
+		}
+	}
+	return items
+}
+
+// fsList lists the sections of the server when itemPath is empty, otherwise the
+// children of a section, show or season. A keyword searches the whole server, or only
+// the section of itemPath.
+//
+//nolint:gosec
+func fsList(
+	ctx context.Context,
+	pucd *cache.PlexUserCacheData,
+	itemPath, keyword string,
+	page, size int,
+) (*listResult, error) {
+	cli := pucd.Client()
+	prefix, key, err := plex.ParsePath(itemPath)
+	if err != nil {
+		return nil, err
+	}
+	start := (page - 1) * size
+
+	switch {
+	case keyword != "":
+		var sectionID string
+		if prefix == plex.SectionsPrefix {
+			sectionID = key
+		}
+		ms, err := cli.Search(ctx, keyword, sectionID, 0)
+		if err != nil {
+			return nil, err
+		}
+		return &listResult{
+			Items: metadataItems(paginate(ms, start, size)),
+			Total: uint64(len(ms)),
+		}, nil
+	case prefix == "":
+		dirs, err := cli.Sections(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res := &listResult{Total: uint64(len(dirs))}
+		for _, d := range paginate(dirs, start, size) {
+			res.Items = append(res.Items, &listItem{
+				Name:     d.Title,
+				Path:     plex.SectionsPrefix + "/" + d.Key,
+				Type:     "section",
+				IsFolder: true,
+			})
+		}
+		return res, nil
+	}
+
+	mc, err := cli.Children(ctx, itemPath, uint64(start), uint64(size))
+	if err != nil {
+		return nil, err
+	}
+	res := &listResult{
+		Items: metadataItems(mc.Metadata),
+		Total: mc.TotalSize,
+	}
+	if prefix == plex.SectionsPrefix {
+		res.Paths = append(res.Paths, &model.Path{Name: mc.Title1, Path: itemPath})
+		return res, nil
+	}
+	if mc.LibrarySectionID != 0 {
+		res.Paths = append(res.Paths, &model.Path{
+			Name: mc.LibrarySectionTitle,
+			Path: plex.SectionsPrefix + "/" + strconv.FormatUint(mc.LibrarySectionID, 10),
+		})
+	}
+	if mc.GrandparentRatingKey != "" {
+		res.Paths = append(res.Paths, &model.Path{
+			Name: mc.GrandparentTitle,
+			Path: plex.MetadataPrefix + "/" + mc.GrandparentRatingKey,
+		})
+	}
+	name := mc.Title2
+	if name == "" {
+		name = mc.Title1
+	}
+	res.Paths = append(res.Paths, &model.Path{Name: name, Path: itemPath})
+	return res, nil
+}
+
+//nolint:gosec
+func List(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := ListReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	page, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if req.Path == "" {
+		if req.Keyword != "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorStringResp(
+					"keywords is not supported when not choose server (server id is empty)",
+				),
+			)
+			return
+		}
+		socpes := [](func(*gorm.DB) *gorm.DB){
+			db.OrderByCreatedAtAsc,
+		}
+
+		total, err := db.GetPlexVendorsCount(user.ID, socpes...)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+		if total == 0 {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("plex server not found"))
+			return
+		}
+
+		pv, err := db.GetPlexVendors(user.ID, append(socpes, db.Paginate(page, size))...)
+		if err != nil {
+			if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+				ctx.JSON(
+					http.StatusBadRequest,
+					model.NewAPIErrorStringResp("plex server not found"),
+				)
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+
+		if total == 1 {
+			req.Path = pv[0].ServerID + "/"
+			goto PlexFSListResp
+		}
+
+		resp := PlexFSListResp{
+			Paths: []*model.Path{
+				{
+					Name: "",
+					Path: "",
+				},
+			},
+			Total: uint64(total),
+		}
+
+		for _, pvi := range pv {
+			resp.Items = append(resp.Items, &PlexFileItem{
+				Item: &model.Item{
+					Name:  pvi.Host,
+					Path:  pvi.ServerID + `/`,
+					IsDir: true,
+				},
+				Type: "server",
+			})
+		}
+
+		ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+
+		return
+	}
+
+PlexFSListResp:
+
+	var serverID string
+	serverID, req.Path, err = dbModel.GetPlexServerIDFromPath(req.Path)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	pucd, err := user.PlexCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("plex server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	data, err := fsList(ctx, pucd, req.Path, req.Keyword, page, size)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			model.NewAPIErrorResp(fmt.Errorf("plex fs list error: %w", err)),
+		)
+		return
+	}
+
+	resp := PlexFSListResp{
+		Paths: []*model.Path{
+			{},
+			{
+				Name: pucd.Host,
+				Path: pucd.ServerID + "/",
+			},
+		},
+		Total: data.Total,
+	}
+	for _, p := range data.Paths {
+		resp.Paths = append(resp.Paths, &model.Path{
+			Name: p.Name,
+			Path: dbModel.FormatPlexPath(pucd.ServerID, p.Path),
+		})
+	}
+	for _, i := range data.Items {
+		resp.Items = append(resp.Items, &PlexFileItem{
+			Item: &model.Item{
+				Name:  i.Name,
+				Path:  dbModel.FormatPlexPath(pucd.ServerID, i.Path),
+				IsDir: i.IsFolder,
+			},
+			Type: i.Type,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
@@ -0,0 +1,117 @@
+package vendorplex
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/plex"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type LoginReq struct {
+	Host  string `json:"host"`
+	Token string `json:"token"`
+}
+
+func (r *LoginReq) Validate() error {
+	if r.Host == "" {
+		return errors.New("host is required")
+	}
+	url, err := url.Parse(r.Host)
+	if err != nil {
+		return err
+	}
+	if url.Scheme != "http" && url.Scheme != "https" {
+		return errors.New("host is invalid")
+	}
+	r.Host = strings.TrimRight(url.String(), "/")
+	if r.Token == "" {
+		return errors.New("token is required")
+	}
+	return nil
+}
+
+func (r *LoginReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func Login(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := LoginReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	// the server root needs a valid token, unlike /identity
+	data, err := plex.NewClient(req.Host, req.Token).Server(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if data.MachineIdentifier == "" {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			model.NewAPIErrorStringResp("serverID is empty"),
+		)
+		return
+	}
+
+	_, err = db.CreateOrSavePlexVendor(&dbModel.PlexVendor{
+		UserID:   user.ID,
+		ServerID: data.MachineIdentifier,
+		Host:     req.Host,
+		Token:    req.Token,
+	})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	_, err = user.PlexCache().
+		StoreOrRefreshWithDynamicFunc(ctx, data.MachineIdentifier, func(_ context.Context, key string) (*cache.PlexUserCacheData, error) {
+			return &cache.PlexUserCacheData{
+				Host:     req.Host,
+				ServerID: key,
+				Token:    req.Token,
+			}, nil
+		})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Logout only removes the binding, plex tokens are not bound to a session and stay valid.
+func Logout(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	var req model.ServerIDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	err := db.DeletePlexVendor(user.ID, req.ServerID)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	user.PlexCache().Delete(req.ServerID)
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,91 @@
+package vendorplex
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type PlexServerInfo struct {
+	ServerID string `json:"serverId"`
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+}
+
+type PlexMeResp = model.VendorMeResp[*PlexServerInfo]
+
+func Me(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	serverID := ctx.Query("serverID")
+	if serverID == "" {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(errors.New("serverID is required")),
+		)
+		return
+	}
+
+	pucd, err := user.PlexCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("plex server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	data, err := pucd.Client().Server(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&PlexMeResp{
+		IsLogin: true,
+		Info: &PlexServerInfo{
+			ServerID: data.MachineIdentifier,
+			Name:     data.FriendlyName,
+			Version:  data.Version,
+		},
+	}))
+}
+
+type PlexBindsResp []*struct {
+	ServerID string `json:"serverId"`
+	Host     string `json:"host"`
+}
+
+func Binds(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	pv, err := db.GetPlexVendors(user.ID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusOK, model.NewAPIDataResp(&PlexMeResp{
+				IsLogin: false,
+			}))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := make(PlexBindsResp, len(pv))
+	for i, v := range pv {
+		resp[i] = &struct {
+			ServerID string `json:"serverId"`
+			Host     string `json:"host"`
+		}{
+			ServerID: v.ServerID,
+			Host:     v.Host,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
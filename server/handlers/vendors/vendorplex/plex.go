@@ -0,0 +1,371 @@
+package vendorplex
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/server/handlers/proxy"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+type PlexVendorService struct {
+	room  *op.Room
+	movie *op.Movie
+}
+
+func NewPlexVendorService(room *op.Room, movie *op.Movie) (*PlexVendorService, error) {
+	if movie.VendorInfo.Vendor != dbModel.VendorPlex {
+		return nil, fmt.Errorf("plex vendor not support vendor %s", movie.VendorInfo.Vendor)
+	}
+	return &PlexVendorService{
+		room:  room,
+		movie: movie,
+	}, nil
+}
+
+//nolint:gosec
+func (s *PlexVendorService) ListDynamicMovie(
+	ctx context.Context,
+	reqUser *op.User,
+	subPath, keyword string,
+	page, _max int,
+) (*model.MovieList, error) {
+	if reqUser.ID != s.movie.CreatorID {
+		return nil, fmt.Errorf("list vendor dynamic folder error: %w", dbModel.ErrNoPermission)
+	}
+	user := reqUser
+
+	resp := &model.MovieList{
+		Paths: []*model.MoviePath{},
+	}
+
+	serverID, truePath, err := s.movie.VendorInfo.Plex.ServerIDAndFilePath()
+	if err != nil {
+		return nil, fmt.Errorf("load plex server id error: %w", err)
+	}
+	if subPath != "" {
+		truePath = subPath
+	}
+	pucd, err := user.PlexCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			return nil, errors.New("plex server not found")
+		}
+		return nil, err
+	}
+	data, err := fsList(ctx, pucd, truePath, keyword, page, _max)
+	if err != nil {
+		return nil, fmt.Errorf("plex fs list error: %w", err)
+	}
+	resp.Total = int64(data.Total)
+	resp.Movies = make([]*model.Movie, len(data.Items))
+	for i, flr := range data.Items {
+		resp.Movies[i] = &model.Movie{
+			ID:        s.movie.ID,
+			CreatedAt: s.movie.CreatedAt.UnixMilli(),
+			Creator:   op.GetUserName(s.movie.CreatorID),
+			CreatorID: s.movie.CreatorID,
+			SubPath:   flr.Path,
+			Base: dbModel.MovieBase{
+				Name:     flr.Name,
+				IsFolder: flr.IsFolder,
+				ParentID: dbModel.EmptyNullString(s.movie.ID),
+				VendorInfo: dbModel.VendorInfo{
+					Vendor:  dbModel.VendorPlex,
+					Backend: s.movie.VendorInfo.Backend,
+					Plex: &dbModel.PlexStreamingInfo{
+						Path:      dbModel.FormatPlexPath(serverID, flr.Path),
+						Transcode: s.movie.VendorInfo.Plex.Transcode,
+					},
+				},
+			},
+		}
+	}
+	return resp, nil
+}
+
+func (s *PlexVendorService) handleProxyMovie(ctx *gin.Context) {
+	log := middlewares.GetLogger(ctx)
+
+	if !s.movie.Proxy {
+		log.Errorf("proxy vendor movie error: %v", "proxy is not enabled")
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("proxy is not enabled"),
+		)
+		return
+	}
+
+	u, err := op.LoadOrInitUserByID(s.movie.CreatorID)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	plexC, err := s.movie.PlexCache().Get(ctx, u.Value().PlexCache())
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	if len(plexC.Sources) == 0 {
+		log.Errorf("proxy vendor movie error: %v", "no source")
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp("no source"))
+		return
+	}
+
+	source, err := strconv.Atoi(ctx.Query("source"))
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	if source >= len(plexC.Sources) {
+		log.Errorf("proxy vendor movie error: %v", "source out of range")
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("source out of range"),
+		)
+		return
+	}
+
+	if plexC.Sources[source].IsTranscode {
+		ctx.Redirect(http.StatusFound, plexC.Sources[source].URL)
+		return
+	}
+
+	// ignore the token as cache key
+	sourceCacheKey, err := url.Parse(plexC.Sources[source].URL)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	query := sourceCacheKey.Query()
+	query.Del("X-Plex-Token")
+	sourceCacheKey.RawQuery = query.Encode()
+
+	err = proxy.AutoProxyURL(ctx,
+		plexC.Sources[source].URL,
+		"",
+		nil,
+		ctx.GetString("token"),
+		s.movie.RoomID,
+		s.movie.ID,
+		proxy.WithProxyURLCache(true),
+		proxy.WithProxyURLCacheKey(sourceCacheKey.String()),
+	)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+	}
+}
+
+func (s *PlexVendorService) handleSubtitle(ctx *gin.Context) error {
+	u, err := op.LoadOrInitUserByID(s.movie.CreatorID)
+	if err != nil {
+		return err
+	}
+
+	plexC, err := s.movie.PlexCache().Get(ctx, u.Value().PlexCache())
+	if err != nil {
+		return err
+	}
+
+	source, err := strconv.Atoi(ctx.Query("source"))
+	if err != nil {
+		return err
+	}
+
+	if source >= len(plexC.Sources) {
+		return errors.New("source out of range")
+	}
+
+	id, err := strconv.Atoi(ctx.Query("id"))
+	if err != nil {
+		return err
+	}
+
+	if id >= len(plexC.Sources[source].Subtitles) {
+		return errors.New("id out of range")
+	}
+
+	data, err := plexC.Sources[source].Subtitles[id].Cache.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	http.ServeContent(
+		ctx.Writer,
+		ctx.Request,
+		plexC.Sources[source].Subtitles[id].Name,
+		time.Now(),
+		bytes.NewReader(data),
+	)
+	return nil
+}
+
+func (s *PlexVendorService) ProxyMovie(ctx *gin.Context) {
+	switch t := ctx.Query("t"); t {
+	case "":
+		s.handleProxyMovie(ctx)
+	case "subtitle":
+		_ = s.handleSubtitle(ctx)
+	default:
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("unknown proxy type: "+t),
+		)
+	}
+}
+
+func (s *PlexVendorService) GenMovieInfo(
+	ctx context.Context,
+	user *op.User,
+	userAgent, userToken string,
+) (*dbModel.Movie, error) {
+	if s.movie.Proxy {
+		return s.GenProxyMovieInfo(ctx, user, userAgent, userToken)
+	}
+
+	movie := s.movie.Clone()
+	var err error
+
+	u, err := op.LoadOrInitUserByID(movie.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.movie.PlexCache().Get(ctx, u.Value().PlexCache())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data.Sources) == 0 {
+		return nil, errors.New("no source")
+	}
+	movie.URL = data.Sources[0].URL
+	for _, s := range data.Sources[0].Subtitles {
+		if movie.Subtitles == nil {
+			movie.Subtitles = make(map[string]*dbModel.Subtitle, len(data.Sources[0].Subtitles))
+		}
+		movie.Subtitles[s.Name] = &dbModel.Subtitle{
+			URL:  s.URL,
+			Type: s.Type,
+		}
+	}
+	for _, s := range data.Sources[1:] {
+		movie.MoreSources = append(movie.MoreSources,
+			&dbModel.MoreSource{
+				Name: s.Name,
+				URL:  s.URL,
+			},
+		)
+
+		for _, subt := range s.Subtitles {
+			if movie.Subtitles == nil {
+				movie.Subtitles = make(map[string]*dbModel.Subtitle, len(s.Subtitles))
+			}
+			movie.Subtitles[subt.Name] = &dbModel.Subtitle{
+				URL:  subt.URL,
+				Type: subt.Type,
+			}
+		}
+	}
+
+	return movie, nil
+}
+
+func (s *PlexVendorService) GenProxyMovieInfo(
+	ctx context.Context,
+	_ *op.User,
+	_, userToken string,
+) (*dbModel.Movie, error) {
+	movie := s.movie.Clone()
+	var err error
+
+	u, err := op.LoadOrInitUserByID(movie.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.movie.PlexCache().Get(ctx, u.Value().PlexCache())
+	if err != nil {
+		return nil, err
+	}
+
+	for si, es := range data.Sources {
+		if len(es.URL) == 0 {
+			if si != len(data.Sources)-1 {
+				continue
+			}
+			if movie.URL == "" {
+				return nil, errors.New("no source")
+			}
+		}
+
+		rawPath, err := url.JoinPath("/api/room/movie/proxy", movie.ID)
+		if err != nil {
+			return nil, err
+		}
+		rawQuery := url.Values{}
+		rawQuery.Set("source", strconv.Itoa(si))
+		rawQuery.Set("token", userToken)
+		rawQuery.Set("roomId", movie.RoomID)
+		u := url.URL{
+			Path:     rawPath,
+			RawQuery: rawQuery.Encode(),
+		}
+
+		if si == 0 {
+			movie.URL = u.String()
+			movie.Type = utils.GetURLExtension(es.URL)
+		} else {
+			movie.MoreSources = append(movie.MoreSources,
+				&dbModel.MoreSource{
+					Name: es.Name,
+					URL:  u.String(),
+					Type: utils.GetURLExtension(es.URL),
+				},
+			)
+		}
+
+		if len(es.Subtitles) == 0 {
+			continue
+		}
+		for sbi, s := range es.Subtitles {
+			if movie.Subtitles == nil {
+				movie.Subtitles = make(map[string]*dbModel.Subtitle, len(es.Subtitles))
+			}
+			rawQuery := url.Values{}
+			rawQuery.Set("t", "subtitle")
+			rawQuery.Set("source", strconv.Itoa(si))
+			rawQuery.Set("id", strconv.Itoa(sbi))
+			rawQuery.Set("token", userToken)
+			rawQuery.Set("roomId", movie.RoomID)
+			u := url.URL{
+				Path:     rawPath,
+				RawQuery: rawQuery.Encode(),
+			}
+			movie.Subtitles[s.Name] = &dbModel.Subtitle{
+				URL:  u.String(),
+				Type: s.Type,
+			}
+		}
+	}
+
+	return movie, nil
+}
@@ -0,0 +1,197 @@
+package vendorplugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/plugins"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+type ListReq struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+}
+
+func (r *ListReq) Validate() (err error) {
+	return nil
+}
+
+func (r *ListReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type PluginFileItem struct {
+	*model.Item
+	Size int64 `json:"size"`
+}
+
+type PluginFSListResp = model.VendorFSListResp[*PluginFileItem]
+
+//nolint:gosec
+func fsList(
+	ctx context.Context,
+	v *plugins.Vendor,
+	pucd *cache.PluginUserCacheData,
+	itemPath, keyword string,
+	page, size int,
+) (*pb.ListResp, error) {
+	return v.List(ctx, &pb.ListReq{
+		Credential: pucd.Credential,
+		Path:       itemPath,
+		Keyword:    keyword,
+		Page:       uint64(page),
+		Size:       uint64(size),
+	})
+}
+
+//nolint:gosec
+func List(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	v, ok := loadPlugin(ctx)
+	if !ok {
+		return
+	}
+	name := v.Info.GetName()
+
+	req := ListReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	page, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if req.Path == "" {
+		if req.Keyword != "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorStringResp(
+					"keywords is not supported when not choose server (server id is empty)",
+				),
+			)
+			return
+		}
+		socpes := [](func(*gorm.DB) *gorm.DB){
+			db.OrderByCreatedAtAsc,
+		}
+
+		total, err := db.GetPluginVendorsCount(user.ID, name, socpes...)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+		if total == 0 {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("server not found"))
+			return
+		}
+
+		pv, err := db.GetPluginVendors(user.ID, name, append(socpes, db.Paginate(page, size))...)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+
+		if total == 1 {
+			req.Path = pv[0].ServerID + "/"
+			goto PluginFSListResp
+		}
+
+		resp := PluginFSListResp{
+			Paths: []*model.Path{
+				{
+					Name: "",
+					Path: "",
+				},
+			},
+			Total: uint64(total),
+		}
+
+		for _, pvi := range pv {
+			resp.Items = append(resp.Items, &PluginFileItem{
+				Item: &model.Item{
+					Name:  pvi.Name,
+					Path:  pvi.ServerID + `/`,
+					IsDir: true,
+				},
+			})
+		}
+
+		ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+
+		return
+	}
+
+PluginFSListResp:
+
+	var serverID string
+	serverID, req.Path, err = dbModel.GetPluginServerIDFromPath(req.Path)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	pucd, err := user.PluginCache().LoadOrStore(ctx, cache.PluginUserCacheKey(name, serverID))
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	data, err := fsList(ctx, v, pucd, req.Path, req.Keyword, page, size)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			model.NewAPIErrorResp(fmt.Errorf("%s fs list error: %w", name, err)),
+		)
+		return
+	}
+
+	resp := PluginFSListResp{
+		Paths: []*model.Path{
+			{},
+			{
+				Name: pucd.Name,
+				Path: pucd.ServerID + "/",
+			},
+		},
+		Total: data.GetTotal(),
+	}
+	for _, p := range data.GetPaths() {
+		resp.Paths = append(resp.Paths, &model.Path{
+			Name: p.GetName(),
+			Path: dbModel.FormatPluginPath(pucd.ServerID, p.GetPath()),
+		})
+	}
+	for _, i := range data.GetItems() {
+		resp.Items = append(resp.Items, &PluginFileItem{
+			Item: &model.Item{
+				Name:  i.GetName(),
+				Path:  dbModel.FormatPluginPath(pucd.ServerID, i.GetPath()),
+				IsDir: i.GetIsDir(),
+			},
+			Size: i.GetSize(),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
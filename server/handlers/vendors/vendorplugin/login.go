@@ -0,0 +1,189 @@
+package vendorplugin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/plugins"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type LoginFieldResp struct {
+	Name     string `json:"name"`
+	Label    string `json:"label"`
+	Secret   bool   `json:"secret"`
+	Optional bool   `json:"optional"`
+}
+
+type PluginResp struct {
+	Name        string            `json:"name"`
+	Title       string            `json:"title"`
+	LoginFields []*LoginFieldResp `json:"loginFields"`
+}
+
+// Plugins lists the loaded vendor plugins and the fields of their login forms.
+func Plugins(ctx *gin.Context) {
+	vs := plugins.AllVendors()
+	resp := make([]*PluginResp, len(vs))
+	for i, v := range vs {
+		pr := &PluginResp{
+			Name:        v.Info.GetName(),
+			Title:       v.Info.GetTitle(),
+			LoginFields: make([]*LoginFieldResp, len(v.Info.GetLoginFields())),
+		}
+		for j, f := range v.Info.GetLoginFields() {
+			pr.LoginFields[j] = &LoginFieldResp{
+				Name:     f.GetName(),
+				Label:    f.GetLabel(),
+				Secret:   f.GetSecret(),
+				Optional: f.GetOptional(),
+			}
+		}
+		resp[i] = pr
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
+
+// loadPlugin loads the plugin of the :plugin route param, it aborts the request when
+// the plugin is not loaded.
+func loadPlugin(ctx *gin.Context) (*plugins.Vendor, bool) {
+	v, ok := plugins.LoadVendor(ctx.Param("plugin"))
+	if !ok {
+		ctx.AbortWithStatusJSON(
+			http.StatusNotFound,
+			model.NewAPIErrorStringResp("vendor plugin not found"),
+		)
+		return nil, false
+	}
+	return v, true
+}
+
+func unixTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+type LoginReq struct {
+	Fields map[string]string `json:"fields"`
+}
+
+func (r *LoginReq) Validate() error {
+	return nil
+}
+
+func (r *LoginReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func Login(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	v, ok := loadPlugin(ctx)
+	if !ok {
+		return
+	}
+
+	req := LoginReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	for _, f := range v.Info.GetLoginFields() {
+		if !f.GetOptional() && req.Fields[f.GetName()] == "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorStringResp(f.GetName()+" is required"),
+			)
+			return
+		}
+	}
+
+	resp, err := v.Login(ctx, &pb.LoginReq{Fields: req.Fields})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	if resp.GetServerId() == "" || strings.Contains(resp.GetServerId(), "/") ||
+		len(resp.GetServerId()) > 64 {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			model.NewAPIErrorStringResp("vendor plugin returned an invalid server id"),
+		)
+		return
+	}
+
+	_, err = db.CreateOrSavePluginVendor(&dbModel.PluginVendor{
+		UserID:     user.ID,
+		Plugin:     v.Info.GetName(),
+		ServerID:   resp.GetServerId(),
+		Name:       resp.GetName(),
+		Credential: resp.GetCredential(),
+		ExpireAt:   unixTime(resp.GetExpireAt()),
+	})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	_, err = user.PluginCache().StoreOrRefreshWithDynamicFunc(
+		ctx,
+		cache.PluginUserCacheKey(v.Info.GetName(), resp.GetServerId()),
+		func(_ context.Context, _ string) (*cache.PluginUserCacheData, error) {
+			return &cache.PluginUserCacheData{
+				ExpireAt:   unixTime(resp.GetExpireAt()),
+				Plugin:     v.Info.GetName(),
+				ServerID:   resp.GetServerId(),
+				Name:       resp.GetName(),
+				Credential: resp.GetCredential(),
+			}, nil
+		},
+	)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func Logout(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	v, ok := loadPlugin(ctx)
+	if !ok {
+		return
+	}
+
+	var req model.ServerIDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	err := db.DeletePluginVendor(user.ID, v.Info.GetName(), req.ServerID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	user.PluginCache().Delete(cache.PluginUserCacheKey(v.Info.GetName(), req.ServerID))
+
+	ctx.Status(http.StatusNoContent)
+}
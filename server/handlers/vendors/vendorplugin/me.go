@@ -0,0 +1,149 @@
+package vendorplugin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	pb "github.com/PeterChen1997/synctv/proto/vendors/plugin"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type PluginServerInfo struct {
+	ServerID string `json:"serverId"`
+	Name     string `json:"name"`
+	// ExpireAt is a unix millisecond timestamp, 0 means the login never expires
+	ExpireAt int64 `json:"expireAt"`
+}
+
+type PluginMeResp = model.VendorMeResp[*PluginServerInfo]
+
+func expireAtMilli(pucd *cache.PluginUserCacheData) int64 {
+	if pucd.ExpireAt.IsZero() {
+		return 0
+	}
+	return pucd.ExpireAt.UnixMilli()
+}
+
+// Me checks the login with the plugin, a refreshed credential returned by the plugin
+// replaces the stored one.
+func Me(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	v, ok := loadPlugin(ctx)
+	if !ok {
+		return
+	}
+
+	serverID := ctx.Query("serverID")
+	if serverID == "" {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(errors.New("serverID is required")),
+		)
+		return
+	}
+
+	key := cache.PluginUserCacheKey(v.Info.GetName(), serverID)
+	pucd, err := user.PluginCache().LoadOrStore(ctx, key)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusOK, model.NewAPIDataResp(&PluginMeResp{
+				IsLogin: false,
+			}))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp, err := v.Me(ctx, &pb.MeReq{Credential: pucd.Credential})
+	if err != nil {
+		ctx.JSON(http.StatusOK, model.NewAPIDataResp(&PluginMeResp{
+			IsLogin: false,
+		}))
+		return
+	}
+
+	if resp.GetCredential() != "" || resp.GetName() != pucd.Name ||
+		!unixTime(resp.GetExpireAt()).Equal(pucd.ExpireAt) {
+		credential := pucd.Credential
+		if resp.GetCredential() != "" {
+			credential = resp.GetCredential()
+		}
+		_, err = db.CreateOrSavePluginVendor(&dbModel.PluginVendor{
+			UserID:     user.ID,
+			Plugin:     v.Info.GetName(),
+			ServerID:   serverID,
+			Name:       resp.GetName(),
+			Credential: credential,
+			ExpireAt:   unixTime(resp.GetExpireAt()),
+		})
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+		pucd, err = user.PluginCache().StoreOrRefreshWithDynamicFunc(
+			ctx,
+			key,
+			func(_ context.Context, _ string) (*cache.PluginUserCacheData, error) {
+				return &cache.PluginUserCacheData{
+					ExpireAt:   unixTime(resp.GetExpireAt()),
+					Plugin:     v.Info.GetName(),
+					ServerID:   serverID,
+					Name:       resp.GetName(),
+					Credential: credential,
+				}, nil
+			},
+		)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&PluginMeResp{
+		IsLogin: true,
+		Info: &PluginServerInfo{
+			ServerID: pucd.ServerID,
+			Name:     pucd.Name,
+			ExpireAt: expireAtMilli(pucd),
+		},
+	}))
+}
+
+type PluginBindsResp []*PluginServerInfo
+
+func Binds(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	v, ok := loadPlugin(ctx)
+	if !ok {
+		return
+	}
+
+	pv, err := db.GetPluginVendors(user.ID, v.Info.GetName())
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := make(PluginBindsResp, len(pv))
+	for i, b := range pv {
+		info := &PluginServerInfo{
+			ServerID: b.ServerID,
+			Name:     b.Name,
+		}
+		if !b.ExpireAt.IsZero() {
+			info.ExpireAt = b.ExpireAt.UnixMilli()
+		}
+		resp[i] = info
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
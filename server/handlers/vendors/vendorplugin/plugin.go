@@ -0,0 +1,266 @@
+package vendorplugin
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/internal/vendors/plugins"
+	"github.com/PeterChen1997/synctv/server/handlers/proxy"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+type PluginVendorService struct {
+	room   *op.Room
+	movie  *op.Movie
+	vendor *plugins.Vendor
+}
+
+func NewPluginVendorService(room *op.Room, movie *op.Movie) (*PluginVendorService, error) {
+	if movie.VendorInfo.Vendor != dbModel.VendorPlugin {
+		return nil, fmt.Errorf("plugin vendor not support vendor %s", movie.VendorInfo.Vendor)
+	}
+	v, ok := plugins.LoadVendor(movie.VendorInfo.Plugin.Name)
+	if !ok {
+		return nil, fmt.Errorf("vendor plugin not loaded: %s", movie.VendorInfo.Plugin.Name)
+	}
+	return &PluginVendorService{
+		room:   room,
+		movie:  movie,
+		vendor: v,
+	}, nil
+}
+
+//nolint:gosec
+func (s *PluginVendorService) ListDynamicMovie(
+	ctx context.Context,
+	reqUser *op.User,
+	subPath, keyword string,
+	page, _max int,
+) (*model.MovieList, error) {
+	if reqUser.ID != s.movie.CreatorID {
+		return nil, fmt.Errorf("list vendor dynamic folder error: %w", dbModel.ErrNoPermission)
+	}
+	user := reqUser
+
+	resp := &model.MovieList{
+		Paths: []*model.MoviePath{},
+	}
+
+	info := s.movie.VendorInfo.Plugin
+	serverID, truePath, err := info.ServerIDAndFilePath()
+	if err != nil {
+		return nil, fmt.Errorf("load plugin server id error: %w", err)
+	}
+	if subPath != "" {
+		truePath = subPath
+	}
+	pucd, err := user.PluginCache().LoadOrStore(ctx, cache.PluginUserCacheKey(info.Name, serverID))
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			return nil, errors.New("server not found")
+		}
+		return nil, err
+	}
+	data, err := fsList(ctx, s.vendor, pucd, truePath, keyword, page, _max)
+	if err != nil {
+		return nil, fmt.Errorf("%s fs list error: %w", info.Name, err)
+	}
+	resp.Total = int64(data.GetTotal())
+	resp.Movies = make([]*model.Movie, len(data.GetItems()))
+	for i, flr := range data.GetItems() {
+		resp.Movies[i] = &model.Movie{
+			ID:        s.movie.ID,
+			CreatedAt: s.movie.CreatedAt.UnixMilli(),
+			Creator:   op.GetUserName(s.movie.CreatorID),
+			CreatorID: s.movie.CreatorID,
+			SubPath:   flr.GetPath(),
+			Base: dbModel.MovieBase{
+				Name:     flr.GetName(),
+				IsFolder: flr.GetIsDir(),
+				ParentID: dbModel.EmptyNullString(s.movie.ID),
+				VendorInfo: dbModel.VendorInfo{
+					Vendor: dbModel.VendorPlugin,
+					Plugin: &dbModel.PluginStreamingInfo{
+						Name: info.Name,
+						Path: dbModel.FormatPluginPath(serverID, flr.GetPath()),
+					},
+				},
+			},
+		}
+	}
+	return resp, nil
+}
+
+func (s *PluginVendorService) cacheData(ctx context.Context) (*cache.PluginMovieCacheData, error) {
+	u, err := op.LoadOrInitUserByID(s.movie.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	return s.movie.PluginCache().Get(ctx, u.Value().PluginCache())
+}
+
+func (s *PluginVendorService) handleProxyMovie(ctx *gin.Context) {
+	log := middlewares.GetLogger(ctx)
+
+	data, err := s.cacheData(ctx)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	source, err := strconv.Atoi(ctx.DefaultQuery("source", "0"))
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	if source < 0 || source >= len(data.Sources) {
+		log.Errorf("proxy vendor movie error: %v", "source out of range")
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("source out of range"),
+		)
+		return
+	}
+
+	err = proxy.AutoProxyURL(ctx,
+		data.Sources[source].URL,
+		data.Sources[source].Type,
+		data.Sources[source].Headers,
+		ctx.GetString("token"),
+		s.movie.RoomID,
+		s.movie.ID,
+		proxy.WithProxyURLCache(!data.Live),
+	)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+	}
+}
+
+func (s *PluginVendorService) handleSubtitle(ctx *gin.Context) error {
+	data, err := s.cacheData(ctx)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(ctx.Query("id"))
+	if err != nil {
+		return err
+	}
+
+	if id < 0 || id >= len(data.Subtitles) {
+		return errors.New("id out of range")
+	}
+
+	b, err := data.Subtitles[id].Cache.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	http.ServeContent(
+		ctx.Writer,
+		ctx.Request,
+		data.Subtitles[id].Name,
+		time.Now(),
+		bytes.NewReader(b),
+	)
+	return nil
+}
+
+func (s *PluginVendorService) ProxyMovie(ctx *gin.Context) {
+	switch t := ctx.Query("t"); t {
+	case "":
+		s.handleProxyMovie(ctx)
+	case "subtitle":
+		if err := s.handleSubtitle(ctx); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		}
+	default:
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("unknown proxy type: "+t),
+		)
+	}
+}
+
+// GenMovieInfo hands out the urls of the plugin directly unless the movie is proxied,
+// sources that need headers are always proxied so the headers stay on the server.
+func (s *PluginVendorService) GenMovieInfo(
+	ctx context.Context,
+	_ *op.User,
+	_, userToken string,
+) (*dbModel.Movie, error) {
+	movie := s.movie.Clone()
+
+	data, err := s.cacheData(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rawPath, err := url.JoinPath("/api/room/movie/proxy", movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	proxyURL := func(query url.Values) string {
+		query.Set("token", userToken)
+		query.Set("roomId", movie.RoomID)
+		return (&url.URL{
+			Path:     rawPath,
+			RawQuery: query.Encode(),
+		}).String()
+	}
+
+	movie.Live = data.Live
+	movie.Duration = data.Duration
+	movie.Headers = nil
+	for si, es := range data.Sources {
+		u := es.URL
+		if movie.Proxy || len(es.Headers) != 0 {
+			u = proxyURL(url.Values{"source": {strconv.Itoa(si)}})
+		}
+		t := es.Type
+		if t == "" {
+			t = utils.GetURLExtension(es.URL)
+		}
+		if si == 0 {
+			movie.URL = u
+			movie.Type = t
+			continue
+		}
+		movie.MoreSources = append(movie.MoreSources, &dbModel.MoreSource{
+			Name: es.Name,
+			URL:  u,
+			Type: t,
+		})
+	}
+
+	for i, sub := range data.Subtitles {
+		if movie.Subtitles == nil {
+			movie.Subtitles = make(map[string]*dbModel.Subtitle, len(data.Subtitles))
+		}
+		movie.Subtitles[sub.Name] = &dbModel.Subtitle{
+			URL: proxyURL(url.Values{
+				"t":  {"subtitle"},
+				"id": {strconv.Itoa(i)},
+			}),
+			Type: sub.Type,
+		}
+	}
+
+	return movie, nil
+}
@@ -14,6 +14,12 @@ import (
 	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendoralist"
 	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorbilibili"
 	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendoremby"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorjellyfin"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorlocal"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorplex"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorplugin"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorsubsonic"
+	"github.com/PeterChen1997/synctv/server/handlers/vendors/vendorwebdav"
 	"github.com/PeterChen1997/synctv/server/model"
 )
 
@@ -26,6 +32,8 @@ func Backends(ctx *gin.Context) {
 		backends = slices.Collect(maps.Keys(vendor.LoadClients().AlistClients()))
 	case dbModel.VendorEmby:
 		backends = slices.Collect(maps.Keys(vendor.LoadClients().EmbyClients()))
+	case dbModel.VendorJellyfin:
+		backends = slices.Collect(maps.Keys(vendor.LoadClients().JellyfinClients()))
 	default:
 		ctx.AbortWithStatusJSON(
 			http.StatusBadRequest,
@@ -63,6 +71,18 @@ func NewVendorService(room *op.Room, movie *op.Movie) (VendorService, error) {
 		return vendoralist.NewAlistVendorService(room, movie)
 	case dbModel.VendorEmby:
 		return vendoremby.NewEmbyVendorService(room, movie)
+	case dbModel.VendorJellyfin:
+		return vendorjellyfin.NewJellyfinVendorService(room, movie)
+	case dbModel.VendorPlex:
+		return vendorplex.NewPlexVendorService(room, movie)
+	case dbModel.VendorWebDAV:
+		return vendorwebdav.NewWebDAVVendorService(room, movie)
+	case dbModel.VendorSubsonic:
+		return vendorsubsonic.NewSubsonicVendorService(room, movie)
+	case dbModel.VendorLocal:
+		return vendorlocal.NewLocalVendorService(room, movie)
+	case dbModel.VendorPlugin:
+		return vendorplugin.NewPluginVendorService(room, movie)
 	default:
 		return nil, fmt.Errorf("vendor %s not support", movie.VendorInfo.Vendor)
 	}
@@ -0,0 +1,309 @@
+package vendorsubsonic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/subsonic"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+type ListReq struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+}
+
+func (r *ListReq) Validate() (err error) {
+	return nil
+}
+
+func (r *ListReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type SubsonicFileItem struct {
+	*model.Item
+	Type   string `json:"type"`
+	Artist string `json:"artist,omitempty"`
+	Album  string `json:"album,omitempty"`
+	// Duration of a song in seconds
+	Duration float64 `json:"duration,omitempty"`
+}
+
+type SubsonicFSListResp = model.VendorFSListResp[*SubsonicFileItem]
+
+type listItem struct {
+	Name     string
+	Path     string
+	Type     string
+	Artist   string
+	Album    string
+	Duration float64
+	IsFolder bool
+}
+
+type listResult struct {
+	// Paths are the breadcrumb below the server, without the root and the server itself
+	Paths []*model.Path
+	Items []*listItem
+	Total uint64
+}
+
+func paginate[T any](s []T, start, size int) []T {
+	if start >= len(s) {
+		return nil
+	}
+	return s[start:min(start+size, len(s))]
+}
+
+func songItems(songs []*subsonic.Song) []*listItem {
+	items := make([]*listItem, len(songs))
+	for i, s := range songs {
+		items[i] = &listItem{
+			Name:     s.Title,
+			Path:     subsonic.FormatPath(subsonic.KindSong, s.ID),
+			Type:     subsonic.KindSong,
+			Artist:   s.Artist,
+			Album:    s.Album,
+			Duration: float64(s.Duration),
+		}
+	}
+	return items
+}
+
+// fsList lists the artists of the server when itemPath is empty, the albums of an
+// artist or the songs of an album. A keyword searches the songs of the whole server.
+func fsList(
+	ctx context.Context,
+	sucd *cache.SubsonicUserCacheData,
+	itemPath, keyword string,
+	page, size int,
+) (*listResult, error) {
+	cli, err := sucd.Client()
+	if err != nil {
+		return nil, err
+	}
+	kind, id, err := subsonic.ParsePath(itemPath)
+	if err != nil {
+		return nil, err
+	}
+	start := (page - 1) * size
+
+	switch {
+	case keyword != "":
+		songs, err := cli.SearchSongs(ctx, keyword, start, size)
+		if err != nil {
+			return nil, err
+		}
+		// search3 does not count the matches, a full page means there may be more
+		total := start + len(songs)
+		if len(songs) == size {
+			total++
+		}
+		return &listResult{
+			Items: songItems(songs),
+			Total: uint64(total),
+		}, nil
+	case kind == "":
+		artists, err := cli.Artists(ctx)
+		if err != nil {
+			return nil, err
+		}
+		res := &listResult{Total: uint64(len(artists))}
+		for _, a := range paginate(artists, start, size) {
+			res.Items = append(res.Items, &listItem{
+				Name:     a.Name,
+				Path:     subsonic.FormatPath(subsonic.KindArtist, a.ID),
+				Type:     subsonic.KindArtist,
+				IsFolder: true,
+			})
+		}
+		return res, nil
+	case kind == subsonic.KindArtist:
+		name, albums, err := cli.ArtistAlbums(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		res := &listResult{
+			Paths: []*model.Path{{Name: name, Path: itemPath}},
+			Total: uint64(len(albums)),
+		}
+		for _, a := range paginate(albums, start, size) {
+			res.Items = append(res.Items, &listItem{
+				Name:     a.Name,
+				Path:     subsonic.FormatPath(subsonic.KindAlbum, a.ID),
+				Type:     subsonic.KindAlbum,
+				Artist:   a.Artist,
+				Duration: float64(a.Duration),
+				IsFolder: true,
+			})
+		}
+		return res, nil
+	case kind == subsonic.KindAlbum:
+		album, songs, err := cli.AlbumSongs(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return &listResult{
+			Paths: []*model.Path{{Name: album.Name, Path: itemPath}},
+			Items: songItems(paginate(songs, start, size)),
+			Total: uint64(len(songs)),
+		}, nil
+	default:
+		return nil, errors.New("a song is not a folder")
+	}
+}
+
+func List(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := ListReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	page, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if req.Path == "" {
+		if req.Keyword != "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorStringResp(
+					"keywords is not supported when not choose server (server id is empty)",
+				),
+			)
+			return
+		}
+		socpes := [](func(*gorm.DB) *gorm.DB){
+			db.OrderByCreatedAtAsc,
+		}
+
+		total, err := db.GetSubsonicVendorsCount(user.ID, socpes...)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+		if total == 0 {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("subsonic server not found"))
+			return
+		}
+
+		sv, err := db.GetSubsonicVendors(user.ID, append(socpes, db.Paginate(page, size))...)
+		if err != nil {
+			if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+				ctx.JSON(
+					http.StatusBadRequest,
+					model.NewAPIErrorStringResp("subsonic server not found"),
+				)
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+
+		if total == 1 {
+			req.Path = sv[0].ServerID + "/"
+			goto SubsonicFSListResp
+		}
+
+		resp := SubsonicFSListResp{
+			Paths: []*model.Path{
+				{
+					Name: "",
+					Path: "",
+				},
+			},
+			Total: uint64(total),
+		}
+
+		for _, svi := range sv {
+			resp.Items = append(resp.Items, &SubsonicFileItem{
+				Item: &model.Item{
+					Name:  fmt.Sprintf("%s@%s", svi.Username, svi.Host),
+					Path:  svi.ServerID + `/`,
+					IsDir: true,
+				},
+				Type: "server",
+			})
+		}
+
+		ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+
+		return
+	}
+
+SubsonicFSListResp:
+
+	var serverID string
+	serverID, req.Path, err = dbModel.GetSubsonicServerIDFromPath(req.Path)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	sucd, err := user.SubsonicCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("subsonic server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	data, err := fsList(ctx, sucd, req.Path, req.Keyword, page, size)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			model.NewAPIErrorResp(fmt.Errorf("subsonic fs list error: %w", err)),
+		)
+		return
+	}
+
+	resp := SubsonicFSListResp{
+		Paths: []*model.Path{
+			{},
+			{
+				Name: sucd.Host,
+				Path: sucd.ServerID + "/",
+			},
+		},
+		Total: data.Total,
+	}
+	for _, p := range data.Paths {
+		resp.Paths = append(resp.Paths, &model.Path{
+			Name: p.Name,
+			Path: dbModel.FormatSubsonicPath(sucd.ServerID, p.Path),
+		})
+	}
+	for _, i := range data.Items {
+		resp.Items = append(resp.Items, &SubsonicFileItem{
+			Item: &model.Item{
+				Name:  i.Name,
+				Path:  dbModel.FormatSubsonicPath(sucd.ServerID, i.Path),
+				IsDir: i.IsFolder,
+			},
+			Type:     i.Type,
+			Artist:   i.Artist,
+			Album:    i.Album,
+			Duration: i.Duration,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
@@ -0,0 +1,98 @@
+package vendorsubsonic
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type SubsonicServerInfo struct {
+	ServerID string `json:"serverId"`
+	Host     string `json:"host"`
+	Username string `json:"username"`
+}
+
+type SubsonicMeResp = model.VendorMeResp[*SubsonicServerInfo]
+
+func Me(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	serverID := ctx.Query("serverID")
+	if serverID == "" {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(errors.New("serverID is required")),
+		)
+		return
+	}
+
+	sucd, err := user.SubsonicCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("subsonic server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	cli, err := sucd.Client()
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+	if err := cli.Ping(ctx); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&SubsonicMeResp{
+		IsLogin: true,
+		Info: &SubsonicServerInfo{
+			ServerID: sucd.ServerID,
+			Host:     sucd.Host,
+			Username: sucd.Username,
+		},
+	}))
+}
+
+type SubsonicBindsResp []*struct {
+	ServerID string `json:"serverId"`
+	Host     string `json:"host"`
+	Username string `json:"username"`
+}
+
+func Binds(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	sv, err := db.GetSubsonicVendors(user.ID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusOK, model.NewAPIDataResp(&SubsonicMeResp{
+				IsLogin: false,
+			}))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := make(SubsonicBindsResp, len(sv))
+	for i, v := range sv {
+		resp[i] = &struct {
+			ServerID string `json:"serverId"`
+			Host     string `json:"host"`
+			Username string `json:"username"`
+		}{
+			ServerID: v.ServerID,
+			Host:     v.Host,
+			Username: v.Username,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
@@ -0,0 +1,182 @@
+package vendorsubsonic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/server/handlers/proxy"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type SubsonicVendorService struct {
+	room  *op.Room
+	movie *op.Movie
+}
+
+func NewSubsonicVendorService(room *op.Room, movie *op.Movie) (*SubsonicVendorService, error) {
+	if movie.VendorInfo.Vendor != dbModel.VendorSubsonic {
+		return nil, fmt.Errorf("subsonic vendor not support vendor %s", movie.VendorInfo.Vendor)
+	}
+	return &SubsonicVendorService{
+		room:  room,
+		movie: movie,
+	}, nil
+}
+
+//nolint:gosec
+func (s *SubsonicVendorService) ListDynamicMovie(
+	ctx context.Context,
+	reqUser *op.User,
+	subPath, keyword string,
+	page, _max int,
+) (*model.MovieList, error) {
+	if reqUser.ID != s.movie.CreatorID {
+		return nil, fmt.Errorf("list vendor dynamic folder error: %w", dbModel.ErrNoPermission)
+	}
+	user := reqUser
+
+	resp := &model.MovieList{
+		Paths: []*model.MoviePath{},
+	}
+
+	serverID, truePath, err := s.movie.VendorInfo.Subsonic.ServerIDAndItemPath()
+	if err != nil {
+		return nil, fmt.Errorf("load subsonic server id error: %w", err)
+	}
+	if subPath != "" {
+		truePath = subPath
+	}
+	sucd, err := user.SubsonicCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			return nil, errors.New("subsonic server not found")
+		}
+		return nil, err
+	}
+	data, err := fsList(ctx, sucd, truePath, keyword, page, _max)
+	if err != nil {
+		return nil, fmt.Errorf("subsonic fs list error: %w", err)
+	}
+	resp.Total = int64(data.Total)
+	resp.Movies = make([]*model.Movie, len(data.Items))
+	for i, flr := range data.Items {
+		resp.Movies[i] = &model.Movie{
+			ID:        s.movie.ID,
+			CreatedAt: s.movie.CreatedAt.UnixMilli(),
+			Creator:   op.GetUserName(s.movie.CreatorID),
+			CreatorID: s.movie.CreatorID,
+			SubPath:   flr.Path,
+			Base: dbModel.MovieBase{
+				Name:     flr.Name,
+				IsFolder: flr.IsFolder,
+				Duration: flr.Duration,
+				ParentID: dbModel.EmptyNullString(s.movie.ID),
+				VendorInfo: dbModel.VendorInfo{
+					Vendor: dbModel.VendorSubsonic,
+					Subsonic: &dbModel.SubsonicStreamingInfo{
+						Path: dbModel.FormatSubsonicPath(serverID, flr.Path),
+					},
+				},
+			},
+		}
+	}
+	return resp, nil
+}
+
+// handleProxyMovie always proxies, the stream url is signed with the password of the
+// creator which must not be handed to the viewers.
+func (s *SubsonicVendorService) handleProxyMovie(ctx *gin.Context, cover bool) {
+	log := middlewares.GetLogger(ctx)
+
+	u, err := op.LoadOrInitUserByID(s.movie.CreatorID)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	data, err := s.movie.SubsonicCache().Get(ctx, u.Value().SubsonicCache())
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	target := data.URL
+	if cover {
+		if data.CoverURL == "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusNotFound,
+				model.NewAPIErrorStringResp("song has no cover art"),
+			)
+			return
+		}
+		target = data.CoverURL
+	}
+
+	err = proxy.URL(ctx, target, nil, proxy.WithProxyURLCache(true))
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+	}
+}
+
+func (s *SubsonicVendorService) ProxyMovie(ctx *gin.Context) {
+	switch t := ctx.Query("t"); t {
+	case "":
+		s.handleProxyMovie(ctx, false)
+	case "cover":
+		s.handleProxyMovie(ctx, true)
+	default:
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("unknown proxy type: "+t),
+		)
+	}
+}
+
+func (s *SubsonicVendorService) GenMovieInfo(
+	ctx context.Context,
+	_ *op.User,
+	_, userToken string,
+) (*dbModel.Movie, error) {
+	movie := s.movie.Clone()
+
+	u, err := op.LoadOrInitUserByID(movie.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.movie.SubsonicCache().Get(ctx, u.Value().SubsonicCache())
+	if err != nil {
+		return nil, err
+	}
+
+	rawPath, err := url.JoinPath("/api/room/movie/proxy", movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	rawQuery := url.Values{}
+	rawQuery.Set("token", userToken)
+	rawQuery.Set("roomId", movie.RoomID)
+	movie.URL = (&url.URL{
+		Path:     rawPath,
+		RawQuery: rawQuery.Encode(),
+	}).String()
+	movie.Type = data.Song.Suffix
+	if data.Song.TranscodedSuffix != "" {
+		movie.Type = data.Song.TranscodedSuffix
+	}
+	movie.Headers = nil
+	if movie.Duration == 0 {
+		movie.Duration = float64(data.Song.Duration)
+	}
+
+	return movie, nil
+}
@@ -0,0 +1,220 @@
+package vendorwebdav
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/webdav"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+	"gorm.io/gorm"
+)
+
+type ListReq struct {
+	Path    string `json:"path"`
+	Keyword string `json:"keyword"`
+}
+
+func (r *ListReq) Validate() (err error) {
+	return nil
+}
+
+func (r *ListReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type WebDAVFileItem struct {
+	*model.Item
+	Size int64 `json:"size"`
+}
+
+type WebDAVFSListResp = model.VendorFSListResp[*WebDAVFileItem]
+
+type listResult struct {
+	Items []*webdav.File
+	Total uint64
+}
+
+// fsList lists the directory dirPath, a keyword filters the names of the directory
+// entries since webdav has no search.
+func fsList(
+	ctx context.Context,
+	wucd *cache.WebDAVUserCacheData,
+	dirPath, keyword string,
+	page, size int,
+) (*listResult, error) {
+	cli, err := wucd.Client()
+	if err != nil {
+		return nil, err
+	}
+	files, err := cli.List(ctx, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	if keyword != "" {
+		keyword = strings.ToLower(keyword)
+		filtered := files[:0]
+		for _, f := range files {
+			if strings.Contains(strings.ToLower(f.Name), keyword) {
+				filtered = append(filtered, f)
+			}
+		}
+		files = filtered
+	}
+	res := &listResult{Total: uint64(len(files))}
+	if start := (page - 1) * size; start < len(files) {
+		res.Items = files[start:min(start+size, len(files))]
+	}
+	return res, nil
+}
+
+//nolint:gosec
+func List(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := ListReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	page, size, err := utils.GetPageAndMax(ctx)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if req.Path == "" {
+		if req.Keyword != "" {
+			ctx.AbortWithStatusJSON(
+				http.StatusBadRequest,
+				model.NewAPIErrorStringResp(
+					"keywords is not supported when not choose server (server id is empty)",
+				),
+			)
+			return
+		}
+		socpes := [](func(*gorm.DB) *gorm.DB){
+			db.OrderByCreatedAtAsc,
+		}
+
+		total, err := db.GetWebDAVVendorsCount(user.ID, socpes...)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+		if total == 0 {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("webdav server not found"))
+			return
+		}
+
+		wv, err := db.GetWebDAVVendors(user.ID, append(socpes, db.Paginate(page, size))...)
+		if err != nil {
+			if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+				ctx.JSON(
+					http.StatusBadRequest,
+					model.NewAPIErrorStringResp("webdav server not found"),
+				)
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+			return
+		}
+
+		if total == 1 {
+			req.Path = wv[0].ServerID + "/"
+			goto WebDAVFSListResp
+		}
+
+		resp := WebDAVFSListResp{
+			Paths: []*model.Path{
+				{
+					Name: "",
+					Path: "",
+				},
+			},
+			Total: uint64(total),
+		}
+
+		for _, wvi := range wv {
+			resp.Items = append(resp.Items, &WebDAVFileItem{
+				Item: &model.Item{
+					Name:  fmt.Sprintf("%s@%s", wvi.Username, wvi.Host),
+					Path:  wvi.ServerID + `/`,
+					IsDir: true,
+				},
+			})
+		}
+
+		ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+
+		return
+	}
+
+WebDAVFSListResp:
+
+	var serverID string
+	serverID, req.Path, err = dbModel.GetWebDAVServerIDFromPath(req.Path)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	req.Path, err = webdav.CleanPath(req.Path)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	wucd, err := user.WebDAVCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("webdav server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	data, err := fsList(ctx, wucd, req.Path, req.Keyword, page, size)
+	if err != nil {
+		ctx.AbortWithStatusJSON(
+			http.StatusInternalServerError,
+			model.NewAPIErrorResp(fmt.Errorf("webdav fs list error: %w", err)),
+		)
+		return
+	}
+
+	resp := WebDAVFSListResp{
+		Paths: model.GenDefaultPaths(req.Path, true,
+			&model.Path{
+				Name: "",
+				Path: "",
+			},
+			&model.Path{
+				Name: wucd.Host,
+				Path: wucd.ServerID + "/",
+			}),
+		Total: data.Total,
+	}
+	for _, f := range data.Items {
+		resp.Items = append(resp.Items, &WebDAVFileItem{
+			Item: &model.Item{
+				Name:  f.Name,
+				Path:  dbModel.FormatWebDAVPath(wucd.ServerID, f.Path),
+				IsDir: f.IsDir,
+			},
+			Size: f.Size,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
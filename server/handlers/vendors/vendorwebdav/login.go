@@ -0,0 +1,122 @@
+package vendorwebdav
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/cache"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendors/webdav"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type LoginReq struct {
+	Host     string `json:"host"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (r *LoginReq) Validate() error {
+	if r.Host == "" {
+		return errors.New("host is required")
+	}
+	url, err := url.Parse(r.Host)
+	if err != nil {
+		return err
+	}
+	if url.Scheme != "http" && url.Scheme != "https" {
+		return errors.New("host is invalid")
+	}
+	r.Host = strings.TrimRight(url.String(), "/")
+	return nil
+}
+
+func (r *LoginReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func Login(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := LoginReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	cli, err := webdav.NewClient(req.Host, req.Username, req.Password)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	root, err := cli.Stat(ctx, "")
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+	if !root.IsDir {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("host is not a webdav directory"),
+		)
+		return
+	}
+
+	v := &dbModel.WebDAVVendor{
+		UserID:   user.ID,
+		Host:     req.Host,
+		Username: req.Username,
+		Password: req.Password,
+	}
+	dbModel.GenWebDAVServerID(v)
+	serverID := v.ServerID
+
+	_, err = db.CreateOrSaveWebDAVVendor(v)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	_, err = user.WebDAVCache().
+		StoreOrRefreshWithDynamicFunc(ctx, serverID, func(_ context.Context, key string) (*cache.WebDAVUserCacheData, error) {
+			return &cache.WebDAVUserCacheData{
+				Host:     req.Host,
+				ServerID: key,
+				Username: req.Username,
+				Password: req.Password,
+			}, nil
+		})
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func Logout(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	var req model.ServerIDReq
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	err := db.DeleteWebDAVVendor(user.ID, req.ServerID)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	user.WebDAVCache().Delete(req.ServerID)
+
+	ctx.Status(http.StatusNoContent)
+}
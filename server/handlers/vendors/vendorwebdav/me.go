@@ -0,0 +1,98 @@
+package vendorwebdav
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+type WebDAVServerInfo struct {
+	ServerID string `json:"serverId"`
+	Host     string `json:"host"`
+	Username string `json:"username"`
+}
+
+type WebDAVMeResp = model.VendorMeResp[*WebDAVServerInfo]
+
+func Me(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	serverID := ctx.Query("serverID")
+	if serverID == "" {
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorResp(errors.New("serverID is required")),
+		)
+		return
+	}
+
+	wucd, err := user.WebDAVCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusBadRequest, model.NewAPIErrorStringResp("webdav server not found"))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	cli, err := wucd.Client()
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+	if _, err := cli.Stat(ctx, ""); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(&WebDAVMeResp{
+		IsLogin: true,
+		Info: &WebDAVServerInfo{
+			ServerID: wucd.ServerID,
+			Host:     wucd.Host,
+			Username: wucd.Username,
+		},
+	}))
+}
+
+type WebDAVBindsResp []*struct {
+	ServerID string `json:"serverId"`
+	Host     string `json:"host"`
+	Username string `json:"username"`
+}
+
+func Binds(ctx *gin.Context) {
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	wv, err := db.GetWebDAVVendors(user.ID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			ctx.JSON(http.StatusOK, model.NewAPIDataResp(&WebDAVMeResp{
+				IsLogin: false,
+			}))
+			return
+		}
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	resp := make(WebDAVBindsResp, len(wv))
+	for i, v := range wv {
+		resp[i] = &struct {
+			ServerID string `json:"serverId"`
+			Host     string `json:"host"`
+			Username string `json:"username"`
+		}{
+			ServerID: v.ServerID,
+			Host:     v.Host,
+			Username: v.Username,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
@@ -0,0 +1,244 @@
+package vendorwebdav
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/internal/vendors/webdav"
+	"github.com/PeterChen1997/synctv/server/handlers/proxy"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+type WebDAVVendorService struct {
+	room  *op.Room
+	movie *op.Movie
+}
+
+func NewWebDAVVendorService(room *op.Room, movie *op.Movie) (*WebDAVVendorService, error) {
+	if movie.VendorInfo.Vendor != dbModel.VendorWebDAV {
+		return nil, fmt.Errorf("webdav vendor not support vendor %s", movie.VendorInfo.Vendor)
+	}
+	return &WebDAVVendorService{
+		room:  room,
+		movie: movie,
+	}, nil
+}
+
+//nolint:gosec
+func (s *WebDAVVendorService) ListDynamicMovie(
+	ctx context.Context,
+	reqUser *op.User,
+	subPath, keyword string,
+	page, _max int,
+) (*model.MovieList, error) {
+	if reqUser.ID != s.movie.CreatorID {
+		return nil, fmt.Errorf("list vendor dynamic folder error: %w", dbModel.ErrNoPermission)
+	}
+	user := reqUser
+
+	resp := &model.MovieList{
+		Paths: []*model.MoviePath{},
+	}
+
+	serverID, truePath, err := s.movie.VendorInfo.WebDAV.ServerIDAndFilePath()
+	if err != nil {
+		return nil, fmt.Errorf("load webdav server id error: %w", err)
+	}
+	truePath, err = webdav.CleanPath(truePath)
+	if err != nil {
+		return nil, err
+	}
+	dirPath := truePath
+	if subPath != "" {
+		dirPath, err = webdav.CleanPath(subPath)
+		if err != nil {
+			return nil, err
+		}
+		// check new path is in parent path
+		if !webdav.InDir(truePath, dirPath) {
+			return nil, errors.New("sub path is not in parent path")
+		}
+	}
+	wucd, err := user.WebDAVCache().LoadOrStore(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, db.NotFoundError(db.ErrVendorNotFound)) {
+			return nil, errors.New("webdav server not found")
+		}
+		return nil, err
+	}
+	data, err := fsList(ctx, wucd, dirPath, keyword, page, _max)
+	if err != nil {
+		return nil, fmt.Errorf("webdav fs list error: %w", err)
+	}
+	resp.Total = int64(data.Total)
+	resp.Movies = make([]*model.Movie, len(data.Items))
+	for i, f := range data.Items {
+		resp.Movies[i] = &model.Movie{
+			ID:        s.movie.ID,
+			CreatedAt: s.movie.CreatedAt.UnixMilli(),
+			Creator:   op.GetUserName(s.movie.CreatorID),
+			CreatorID: s.movie.CreatorID,
+			SubPath:   f.Path,
+			Base: dbModel.MovieBase{
+				Name:     f.Name,
+				IsFolder: f.IsDir,
+				ParentID: dbModel.EmptyNullString(s.movie.ID),
+				VendorInfo: dbModel.VendorInfo{
+					Vendor: dbModel.VendorWebDAV,
+					WebDAV: &dbModel.WebDAVStreamingInfo{
+						Path: dbModel.FormatWebDAVPath(serverID, f.Path),
+					},
+				},
+			},
+		}
+	}
+	resp.Paths = model.GenDefaultSubPaths(s.movie.ID, subPath, true)
+	return resp, nil
+}
+
+// handleProxyMovie always proxies, the upstream needs the credentials of the creator
+// which must not be handed to the viewers.
+func (s *WebDAVVendorService) handleProxyMovie(ctx *gin.Context) {
+	log := middlewares.GetLogger(ctx)
+
+	u, err := op.LoadOrInitUserByID(s.movie.CreatorID)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	data, err := s.movie.WebDAVCache().Get(ctx, u.Value().WebDAVCache())
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorStringResp(err.Error()))
+		return
+	}
+
+	err = proxy.AutoProxyURL(ctx,
+		data.URL,
+		"",
+		data.Headers,
+		ctx.GetString("token"),
+		s.movie.RoomID,
+		s.movie.ID,
+		proxy.WithProxyURLCache(true),
+	)
+	if err != nil {
+		log.Errorf("proxy vendor movie error: %v", err)
+	}
+}
+
+func (s *WebDAVVendorService) handleSubtitle(ctx *gin.Context) error {
+	u, err := op.LoadOrInitUserByID(s.movie.CreatorID)
+	if err != nil {
+		return err
+	}
+
+	data, err := s.movie.WebDAVCache().Get(ctx, u.Value().WebDAVCache())
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(ctx.Query("id"))
+	if err != nil {
+		return err
+	}
+
+	if id < 0 || id >= len(data.Subtitles) {
+		return errors.New("id out of range")
+	}
+
+	b, err := data.Subtitles[id].Cache.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	http.ServeContent(
+		ctx.Writer,
+		ctx.Request,
+		data.Subtitles[id].Name,
+		time.Now(),
+		bytes.NewReader(b),
+	)
+	return nil
+}
+
+func (s *WebDAVVendorService) ProxyMovie(ctx *gin.Context) {
+	switch t := ctx.Query("t"); t {
+	case "":
+		s.handleProxyMovie(ctx)
+	case "subtitle":
+		if err := s.handleSubtitle(ctx); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		}
+	default:
+		ctx.AbortWithStatusJSON(
+			http.StatusBadRequest,
+			model.NewAPIErrorStringResp("unknown proxy type: "+t),
+		)
+	}
+}
+
+func (s *WebDAVVendorService) GenMovieInfo(
+	ctx context.Context,
+	_ *op.User,
+	_, userToken string,
+) (*dbModel.Movie, error) {
+	movie := s.movie.Clone()
+
+	u, err := op.LoadOrInitUserByID(movie.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.movie.WebDAVCache().Get(ctx, u.Value().WebDAVCache())
+	if err != nil {
+		return nil, err
+	}
+
+	rawPath, err := url.JoinPath("/api/room/movie/proxy", movie.ID)
+	if err != nil {
+		return nil, err
+	}
+	rawQuery := url.Values{}
+	rawQuery.Set("token", userToken)
+	rawQuery.Set("roomId", movie.RoomID)
+	movie.URL = (&url.URL{
+		Path:     rawPath,
+		RawQuery: rawQuery.Encode(),
+	}).String()
+	movie.Type = utils.GetURLExtension(data.URL)
+	movie.Headers = nil
+
+	for i, sub := range data.Subtitles {
+		if movie.Subtitles == nil {
+			movie.Subtitles = make(map[string]*dbModel.Subtitle, len(data.Subtitles))
+		}
+		rawQuery := url.Values{}
+		rawQuery.Set("t", "subtitle")
+		rawQuery.Set("id", strconv.Itoa(i))
+		rawQuery.Set("token", userToken)
+		rawQuery.Set("roomId", movie.RoomID)
+		movie.Subtitles[sub.Name] = &dbModel.Subtitle{
+			URL: (&url.URL{
+				Path:     rawPath,
+				RawQuery: rawQuery.Encode(),
+			}).String(),
+			Type: sub.Type,
+		}
+	}
+
+	return movie, nil
+}
@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/PeterChen1997/synctv/internal/db"
+	"github.com/PeterChen1997/synctv/internal/ical"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/op"
+	"github.com/PeterChen1997/synctv/internal/settings"
+	"github.com/PeterChen1997/synctv/server/middlewares"
+	"github.com/PeterChen1997/synctv/server/model"
+)
+
+const (
+	// the list shows the parties of the last day by default, the feed of the last month
+	watchPartyListHistory     = 24 * time.Hour
+	watchPartyCalendarHistory = 30 * 24 * time.Hour
+	// the length of the events of the movies of unknown duration
+	watchPartyDefaultDuration = 2 * time.Hour
+)
+
+func handleWatchPartyError(ctx *gin.Context, msg string, err error) {
+	log := middlewares.GetLogger(ctx)
+	log.Errorf("%s: %v", msg, err)
+	if errors.Is(err, dbModel.ErrNoPermission) {
+		ctx.AbortWithStatusJSON(
+			http.StatusForbidden,
+			model.NewAPIErrorResp(fmt.Errorf("%s: %w", msg, err)),
+		)
+		return
+	}
+	ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+}
+
+// WatchParties lists the parties of the room, since is in unix milliseconds.
+func WatchParties(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	since := time.Now().Add(-watchPartyListHistory)
+	if s := ctx.Query("since"); s != "" {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+			return
+		}
+		since = time.UnixMilli(ms)
+	}
+
+	parties, err := room.WatchParties(since)
+	if err != nil {
+		log.Errorf("get watch parties error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+	resp, err := newWatchPartyResps(room, user.ID, parties)
+	if err != nil {
+		log.Errorf("get watch party rsvps error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, model.NewAPIDataResp(resp))
+}
+
+func newWatchPartyResps(
+	room *op.Room,
+	userID string,
+	parties []*dbModel.WatchParty,
+) ([]*model.WatchPartyResp, error) {
+	ids := make([]string, len(parties))
+	resp := make([]*model.WatchPartyResp, len(parties))
+	byID := make(map[string]*model.WatchPartyResp, len(parties))
+	for i, p := range parties {
+		ids[i] = p.ID
+		resp[i] = &model.WatchPartyResp{
+			ID:          p.ID,
+			MovieID:     p.MovieID,
+			Description: p.Description,
+			Creator:     op.GetUserName(p.CreatorID),
+			CreatorID:   p.CreatorID,
+			Status:      p.Status.String(),
+			StartAt:     p.StartAt.UnixMilli(),
+			CreatedAt:   p.CreatedAt.UnixMilli(),
+		}
+		if m, err := room.GetMovieByID(p.MovieID); err == nil {
+			resp[i].MovieName = m.Name
+		}
+		byID[p.ID] = resp[i]
+	}
+
+	rsvps, err := db.GetWatchPartyRSVPs(ids...)
+	if err != nil {
+		return nil, err
+	}
+	for _, rsvp := range rsvps {
+		r := byID[rsvp.PartyID]
+		switch rsvp.Response {
+		case dbModel.RSVPGoing:
+			r.Going++
+		case dbModel.RSVPMaybe:
+			r.Maybe++
+		case dbModel.RSVPNotGoing:
+			r.NotGoing++
+		}
+		if rsvp.UserID == userID {
+			r.MyResponse = rsvp.Response
+		}
+	}
+	return resp, nil
+}
+
+func CreateWatchParty(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := model.WatchPartyReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	p, err := user.CreateRoomWatchParty(
+		room,
+		req.MovieID,
+		req.Description,
+		time.UnixMilli(req.StartAt),
+	)
+	if err != nil {
+		handleWatchPartyError(ctx, "create watch party error", err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, model.NewAPIDataResp(gin.H{
+		"id": p.ID,
+	}))
+}
+
+func EditWatchParty(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := model.EditWatchPartyReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	_, err := user.UpdateRoomWatchParty(
+		room,
+		req.ID,
+		req.MovieID,
+		req.Description,
+		time.UnixMilli(req.StartAt),
+	)
+	if err != nil {
+		handleWatchPartyError(ctx, "edit watch party error", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func DeleteWatchParty(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := model.IDReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.DeleteRoomWatchParty(room, req.ID); err != nil {
+		handleWatchPartyError(ctx, "delete watch party error", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func RSVPWatchParty(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	user := middlewares.GetUserEntry(ctx).Value()
+
+	req := model.WatchPartyRSVPReq{}
+	if err := model.Decode(ctx, &req); err != nil {
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, model.NewAPIErrorResp(err))
+		return
+	}
+
+	if err := user.RSVPRoomWatchParty(room, req.ID, req.Response); err != nil {
+		handleWatchPartyError(ctx, "rsvp watch party error", err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// WatchPartyCalendar serves the parties of the room as an iCalendar feed, calendar apps
+// subscribe to it with the token and roomId queries.
+func WatchPartyCalendar(ctx *gin.Context) {
+	room := middlewares.GetRoomEntry(ctx).Value()
+	log := middlewares.GetLogger(ctx)
+
+	parties, err := room.WatchParties(time.Now().Add(-watchPartyCalendarHistory))
+	if err != nil {
+		log.Errorf("get watch parties error: %v", err)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, model.NewAPIErrorResp(err))
+		return
+	}
+
+	var roomURL string
+	if host := settings.HOST.Get(); host != "" {
+		if u, err := url.Parse(host); err == nil {
+			u.Path = "web/cinema/" + room.ID
+			roomURL = u.String()
+		}
+	}
+
+	cal := &ical.Calendar{
+		Name:   "SyncTV " + room.Name,
+		Events: make([]*ical.Event, len(parties)),
+	}
+	for i, p := range parties {
+		e := &ical.Event{
+			UID:         p.ID + "@synctv",
+			Stamp:       p.UpdatedAt,
+			Start:       p.StartAt,
+			End:         p.StartAt.Add(watchPartyDefaultDuration),
+			Summary:     "Watch party",
+			Description: p.Description,
+			URL:         roomURL,
+			Cancelled:   p.Status == dbModel.WatchPartyStatusMissed,
+		}
+		if m, err := room.GetMovieByID(p.MovieID); err == nil {
+			e.Summary = m.Name
+			if m.Duration > 0 {
+				e.End = p.StartAt.Add(time.Duration(m.Duration * float64(time.Second)))
+			}
+		}
+		cal.Events[i] = e
+	}
+
+	ctx.Header("Content-Type", ical.ContentType)
+	ctx.Header("Content-Disposition", fmt.Sprintf(
+		`inline; filename="synctv-%s.ics"`,
+		room.ID,
+	))
+	ctx.Status(http.StatusOK)
+	if _, err := cal.WriteTo(ctx.Writer); err != nil {
+		log.Errorf("write watch party calendar error: %v", err)
+	}
+}
@@ -203,7 +203,13 @@ func handleElementMsg(cli *op.Client, msg *pb.Message) error {
 
 	switch msg.GetType() {
 	case pb.MessageType_CHAT:
-		return handleChatMessage(cli, msg.GetChatContent())
+		return handleChatMessage(cli, msg)
+	case pb.MessageType_CHAT_EDIT:
+		return handleChatEditMessage(cli, msg)
+	case pb.MessageType_CHAT_REACTION:
+		return handleChatReactionMessage(cli, msg.GetChatReaction())
+	case pb.MessageType_DIRECT_MESSAGE:
+		return handleDirectMessage(cli, msg.GetDirectMessage())
 	case pb.MessageType_STATUS:
 		return handleStatusMessage(cli, msg, timeDiff)
 	case pb.MessageType_SYNC:
@@ -370,21 +376,112 @@ func calculateTimeDiff(timestamp int64) float64 {
 	return timeDiff
 }
 
-func handleChatMessage(cli *op.Client, message string) error {
+// prepareChatContent filters and escapes the content of a chat message, the error is
+// already sent to the client when ok is false.
+func prepareChatContent(cli *op.Client, message string) (content string, ok bool, err error) {
 	if message == "" {
-		return sendErrorMessage(cli, "message is empty")
+		return "", false, sendErrorMessage(cli, "message is empty")
 	}
-	sanitizedMessage := template.HTMLEscapeString(message)
-	if len(sanitizedMessage) > MaxChatMessageLength {
-		return sendErrorMessage(cli, "message too long")
+	message, err = cli.Room().FilterChatMessage(cli.User().ID, message)
+	if errors.Is(err, op.ErrChatMessageBlocked) {
+		return "", false, sendErrorMessage(cli, err.Error())
+	} else if err != nil {
+		log.Errorf("filter chat message error: %v", err)
+		return "", false, sendErrorMessage(cli, "failed to check message")
 	}
-	err := cli.SendChatMessage(sanitizedMessage)
-	if err != nil && errors.Is(err, model.ErrNoPermission) {
+	content = template.HTMLEscapeString(message)
+	if len(content) > MaxChatMessageLength {
+		return "", false, sendErrorMessage(cli, "message too long")
+	}
+	return content, true, nil
+}
+
+// handleChatError tells the client why its chat message was refused.
+func handleChatError(cli *op.Client, err error) error {
+	switch {
+	case errors.Is(err, model.ErrNoPermission):
 		return sendErrorMessage(cli, "failed to send message due to permission issue")
+	case errors.Is(err, op.ErrChatMuted),
+		errors.Is(err, op.ErrChatSlowMode),
+		errors.Is(err, op.ErrChatMessageNotFound),
+		errors.Is(err, op.ErrChatEditExpired),
+		errors.Is(err, op.ErrInvalidReaction):
+		return sendErrorMessage(cli, err.Error())
 	}
 	return err
 }
 
+func handleChatMessage(cli *op.Client, msg *pb.Message) error {
+	// the mentions are looked up only for a user who can chat
+	if err := cli.CheckCanChat(); err != nil {
+		return handleChatError(cli, err)
+	}
+	content, ok, err := prepareChatContent(cli, msg.GetChatContent())
+	if !ok {
+		return err
+	}
+	conf := []op.ChatMessageConfig{
+		op.WithChatMentions(cli.Room().ChatMentions(msg.GetChatContent())),
+	}
+	if replyTo := msg.GetChatMeta().GetReplyTo(); replyTo != "" {
+		conf = append(conf, op.WithChatReplyTo(replyTo))
+	}
+	return handleChatError(cli, cli.SendChatMessage(content, conf...))
+}
+
+func handleChatEditMessage(cli *op.Client, msg *pb.Message) error {
+	if msg.GetId() == "" {
+		return sendErrorMessage(cli, "message id is empty")
+	}
+	if err := cli.CheckCanChat(); err != nil {
+		return handleChatError(cli, err)
+	}
+	content, ok, err := prepareChatContent(cli, msg.GetChatContent())
+	if !ok {
+		return err
+	}
+	return handleChatError(cli, cli.EditChatMessage(
+		msg.GetId(),
+		content,
+		cli.Room().ChatMentions(msg.GetChatContent()),
+	))
+}
+
+func handleChatReactionMessage(cli *op.Client, reaction *pb.ChatReaction) error {
+	if reaction.GetMessageId() == "" {
+		return sendErrorMessage(cli, "message id is empty")
+	}
+	return handleChatError(cli, cli.ReactChatMessage(
+		reaction.GetMessageId(),
+		reaction.GetEmoji(),
+		reaction.GetAdd(),
+	))
+}
+
+// handleDirectMessage escapes a private message, the filters of the room only apply to
+// the room chat.
+func handleDirectMessage(cli *op.Client, dm *pb.DirectMessage) error {
+	if dm.GetToUserId() == "" {
+		return sendErrorMessage(cli, "recipient is empty")
+	}
+	if dm.GetContent() == "" {
+		return sendErrorMessage(cli, "message is empty")
+	}
+	content := template.HTMLEscapeString(dm.GetContent())
+	if len(content) > MaxChatMessageLength {
+		return sendErrorMessage(cli, "message too long")
+	}
+	err := cli.SendDirectMessage(dm.GetToUserId(), content)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, model.ErrNoPermission):
+		return sendErrorMessage(cli, "failed to send direct message due to permission issue")
+	default:
+		return sendErrorMessage(cli, err.Error())
+	}
+}
+
 func handleStatusMessage(cli *op.Client, msg *pb.Message, timeDiff float64) error {
 	playbackStatus := msg.GetPlaybackStatus()
 	if playbackStatus == nil {
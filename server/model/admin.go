@@ -6,6 +6,8 @@ import (
 	"github.com/gin-gonic/gin"
 	json "github.com/json-iterator/go"
 	dbModel "github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/internal/vendor"
+	"github.com/PeterChen1997/synctv/internal/vendors/local"
 	"google.golang.org/grpc/connectivity"
 )
 
@@ -135,6 +137,7 @@ func (aur *AdminRoomPasswordReq) Decode(ctx *gin.Context) error {
 
 type GetVendorBackendResp struct {
 	Info   *dbModel.VendorBackend `json:"info"`
+	Health *vendor.HealthStatus   `json:"health"`
 	Status connectivity.State     `json:"status"`
 }
 
@@ -156,6 +159,11 @@ func (avbr *AddVendorBackendReq) Validate() error {
 			return errors.New("emby backend name has invalid char")
 		}
 	}
+	if avbr.UsedBy.JellyfinBackendName != "" {
+		if !alnumPrintHanReg.MatchString(avbr.UsedBy.JellyfinBackendName) {
+			return errors.New("jellyfin backend name has invalid char")
+		}
+	}
 	return avbr.Backend.Validate()
 }
 
@@ -192,3 +200,39 @@ func (ster *SendTestEmailReq) Validate() error {
 func (ster *SendTestEmailReq) Decode(ctx *gin.Context) error {
 	return json.NewDecoder(ctx.Request.Body).Decode(ster)
 }
+
+type AddLocalLibraryReq struct {
+	Name  string `json:"name"`
+	Root  string `json:"root"`
+	Watch bool   `json:"watch"`
+}
+
+func (allr *AddLocalLibraryReq) Validate() error {
+	if allr.Name == "" {
+		return errors.New("name is empty")
+	}
+	if len(allr.Name) > 64 {
+		return errors.New("name is too long")
+	}
+	return local.CheckRoot(allr.Root)
+}
+
+func (allr *AddLocalLibraryReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(allr)
+}
+
+type UpdateLocalLibraryReq struct {
+	ID string `json:"id"`
+	AddLocalLibraryReq
+}
+
+func (ullr *UpdateLocalLibraryReq) Validate() error {
+	if ullr.ID == "" {
+		return errors.New("id is empty")
+	}
+	return ullr.AddLocalLibraryReq.Validate()
+}
+
+func (ullr *UpdateLocalLibraryReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(ullr)
+}
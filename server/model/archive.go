@@ -0,0 +1,25 @@
+package model
+
+import "github.com/PeterChen1997/synctv/internal/archive"
+
+type ImportResultsResp struct {
+	Results  []*archive.ImportResult `json:"results"`
+	Imported int                     `json:"imported"`
+	Skipped  int                     `json:"skipped"`
+	Failed   int                     `json:"failed"`
+}
+
+func NewImportResultsResp(results []*archive.ImportResult) *ImportResultsResp {
+	resp := &ImportResultsResp{Results: results}
+	for _, r := range results {
+		switch r.Status {
+		case archive.ImportStatusImported:
+			resp.Imported++
+		case archive.ImportStatusSkipped:
+			resp.Skipped++
+		case archive.ImportStatusFailed:
+			resp.Failed++
+		}
+	}
+	return resp
+}
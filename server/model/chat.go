@@ -0,0 +1,82 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+const (
+	maxChatFilterPattern     = 256
+	maxChatFilterReplacement = 64
+)
+
+var (
+	ErrEmptyChatFilterPattern   = errors.New("empty filter pattern")
+	ErrChatFilterPatternTooLong = errors.New("filter pattern too long")
+	ErrReplacementTooLong       = errors.New("replacement too long")
+	ErrInvalidChatFilterAction  = errors.New("action must be replace or block")
+	ErrInvalidMuteDuration      = errors.New("invalid mute duration")
+)
+
+type RoomMuteMemberReq struct {
+	UserIDReq
+	// Duration is in seconds
+	Duration int64 `json:"duration"`
+}
+
+func (r *RoomMuteMemberReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *RoomMuteMemberReq) Validate() error {
+	if err := r.UserIDReq.Validate(); err != nil {
+		return err
+	}
+	if r.Duration <= 0 {
+		return ErrInvalidMuteDuration
+	}
+	return nil
+}
+
+type RoomUnmuteMemberReq = UserIDReq
+
+type ChatSlowModeReq struct {
+	// Seconds is the minimum interval between two messages of a member, 0 disables it
+	Seconds uint32 `json:"seconds"`
+}
+
+func (c *ChatSlowModeReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *ChatSlowModeReq) Validate() error {
+	return nil
+}
+
+type ChatFilterReq struct {
+	Pattern     string                 `json:"pattern"`
+	Regex       bool                   `json:"regex"`
+	Action      model.ChatFilterAction `json:"action"`
+	Replacement string                 `json:"replacement"`
+}
+
+func (c *ChatFilterReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *ChatFilterReq) Validate() error {
+	switch {
+	case c.Pattern == "":
+		return ErrEmptyChatFilterPattern
+	case len(c.Pattern) > maxChatFilterPattern:
+		return ErrChatFilterPatternTooLong
+	case len(c.Replacement) > maxChatFilterReplacement:
+		return ErrReplacementTooLong
+	case !c.Action.Valid():
+		return ErrInvalidChatFilterAction
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/model"
+	"github.com/PeterChen1997/synctv/utils"
+)
+
+// max size of a playlist posted as is
+const maxM3UContent = 8 << 20
+
+type ImportM3UReq struct {
+	// URL of the playlist, or Content the playlist itself
+	URL     string `json:"url"`
+	Content string `json:"content"`
+	// EPGURL is the XMLTV guide of the channels, the x-tvg-url of the playlist by
+	// default
+	EPGURL string `json:"epgUrl"`
+	// Name of the folder, the last element of the url by default
+	Name     string `json:"name"`
+	ParentID string `json:"parentId"`
+	Proxy    bool   `json:"proxy"`
+	// GroupFolders puts the channels of each group-title in a folder of its own
+	GroupFolders bool `json:"groupFolders"`
+}
+
+func (r *ImportM3UReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *ImportM3UReq) Validate() error {
+	switch {
+	case r.URL == "" && r.Content == "":
+		return errors.New("url and content are both empty")
+	case len(r.URL) > 8192, len(r.EPGURL) > 8192:
+		return ErrURLTooLong
+	case len(r.Content) > maxM3UContent:
+		return errors.New("playlist too large")
+	}
+	if len(r.Name) > 256 {
+		r.Name = utils.TruncateByRune(r.Name, 253) + "..."
+	}
+	return nil
+}
+
+type ImportM3UResp struct {
+	Folder *model.Movie `json:"folder"`
+	Count  int          `json:"count"`
+}
+
+type Programme struct {
+	Title string `json:"title"`
+	Desc  string `json:"desc,omitempty"`
+	Start int64  `json:"start"`
+	Stop  int64  `json:"stop,omitempty"`
+}
+
+type Programmes struct {
+	Now  *Programme `json:"now,omitempty"`
+	Next *Programme `json:"next,omitempty"`
+}
@@ -16,6 +16,8 @@ type RoomMembersResp struct {
 	AdminPermissions dbModel.RoomAdminPermission  `json:"adminPermissions"`
 	Role             dbModel.RoomMemberRole       `json:"role"`
 	Status           dbModel.RoomMemberStatus     `json:"status"`
+	// MutedUntil is in unix milliseconds, 0 when the member is not muted
+	MutedUntil int64 `json:"mutedUntil"`
 }
 
 type (
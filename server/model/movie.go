@@ -15,6 +15,7 @@ var (
 	ErrURLTooLong  = errors.New("url too long")
 	ErrEmptyName   = errors.New("empty name")
 	ErrTypeTooLong = errors.New("type too long")
+	ErrDuration    = errors.New("duration must not be negative")
 
 	ErrID = errors.New("id length must be 32")
 
@@ -43,6 +44,14 @@ func (p *PushMovieReq) Validate() error {
 		return ErrTypeTooLong
 	}
 
+	if p.Duration < 0 {
+		return ErrDuration
+	}
+
+	if len(p.EPGURL) > 8192 || len(p.Logo) > 8192 {
+		return ErrURLTooLong
+	}
+
 	return nil
 }
 
@@ -100,6 +109,25 @@ func (s *SetRoomCurrentMovieReq) Decode(ctx *gin.Context) error {
 	return json.NewDecoder(ctx.Request.Body).Decode(s)
 }
 
+type RequestTrackReq struct {
+	IDReq
+	SubPath string `json:"subPath"`
+}
+
+func (r *RequestTrackReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+type MusicQueueItem struct {
+	ID          string `json:"id"`
+	MovieID     string `json:"movieId"`
+	SubPath     string `json:"subPath"`
+	Name        string `json:"name"`
+	Requester   string `json:"requester"`
+	RequesterID string `json:"requesterId"`
+	CreatedAt   int64  `json:"createdAt"`
+}
+
 type EditMovieReq struct {
 	IDReq
 	PushMovieReq
@@ -202,12 +230,37 @@ type Movie struct {
 	SubPath   string          `json:"subPath"`
 	Base      model.MovieBase `json:"base"`
 	CreatedAt int64           `json:"createAt"`
+	// Programmes of a live channel with an EPG guide
+	Programmes *Programmes `json:"programmes,omitempty"`
 }
 
 type CurrentMovieResp struct {
-	Movie    *Movie       `json:"movie"`
-	Status   model.Status `json:"status"`
-	ExpireID uint64       `json:"expireId"`
+	Movie     *Movie       `json:"movie"`
+	Track     *Track       `json:"track,omitempty"`
+	Status    model.Status `json:"status"`
+	ExpireID  uint64       `json:"expireId"`
+	MusicMode bool         `json:"musicMode,omitempty"`
+}
+
+type Track struct {
+	Title    string  `json:"title"`
+	Artist   string  `json:"artist,omitempty"`
+	Album    string  `json:"album,omitempty"`
+	CoverURL string  `json:"coverUrl,omitempty"`
+	Duration float64 `json:"duration"`
+}
+
+type ScheduleItem struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Duration float64 `json:"duration"`
+	Start    int64   `json:"start"`
+	End      int64   `json:"end"`
+}
+
+type ScheduleResp struct {
+	ChannelMode bool            `json:"channelMode"`
+	Items       []*ScheduleItem `json:"items"`
 }
 
 type ClearMoviesReq struct {
@@ -224,3 +277,27 @@ func (c *ClearMoviesReq) Validate() error {
 	}
 	return nil
 }
+
+type ResolveMovieReq struct {
+	URL string `json:"url"`
+}
+
+func (r *ResolveMovieReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(r)
+}
+
+func (r *ResolveMovieReq) Validate() error {
+	switch {
+	case r.URL == "":
+		return errors.New("url is empty")
+	case len(r.URL) > 8192:
+		return ErrURLTooLong
+	}
+	return nil
+}
+
+type MovieCandidate struct {
+	Movie *model.MovieBase `json:"movie"`
+	// Extractor is the name of the extractor that found the movie
+	Extractor string `json:"extractor"`
+}
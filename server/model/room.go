@@ -57,6 +57,19 @@ func (c *CreateRoomReq) Validate() error {
 	return nil
 }
 
+type CloneRoomReq struct {
+	RoomName string `json:"roomName"`
+	Password string `json:"password"`
+}
+
+func (c *CloneRoomReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(c)
+}
+
+func (c *CloneRoomReq) Validate() error {
+	return (&CreateRoomReq{RoomName: c.RoomName, Password: c.Password}).Validate()
+}
+
 type RoomListResp struct {
 	RoomID       string             `json:"roomId"`
 	RoomName     string             `json:"roomName"`
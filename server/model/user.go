@@ -154,6 +154,12 @@ func (u *UserIDReq) Validate() error {
 	return nil
 }
 
+type UserBlockResp struct {
+	UserID    string `json:"userId"`
+	Username  string `json:"username"`
+	CreatedAt int64  `json:"createdAt"`
+}
+
 type UserBindProviderResp map[provider.OAuth2Provider]struct {
 	ProviderUserID string `json:"providerUserId"`
 	CreatedAt      int64  `json:"createdAt"`
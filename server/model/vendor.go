@@ -7,11 +7,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	json "github.com/json-iterator/go"
+	dbModel "github.com/PeterChen1997/synctv/internal/model"
 )
 
 type VendorMeResp[T any] struct {
-	Info    T    `json:"info,omitempty"`
-	IsLogin bool `json:"isLogin"`
+	Info T `json:"info,omitempty"`
+	// Status is the last check of the binding by the validator, for the vendors it checks
+	Status  *dbModel.BindingStatus `json:"status,omitempty"`
+	IsLogin bool                   `json:"isLogin"`
 }
 
 type VendorFSListResp[T any] struct {
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	json "github.com/json-iterator/go"
+	"github.com/PeterChen1997/synctv/internal/model"
+)
+
+const maxWatchPartyDescription = 2048
+
+var (
+	ErrEmptyStartAt        = errors.New("empty start time")
+	ErrDescriptionTooLong  = errors.New("description too long")
+	ErrInvalidRSVPResponse = errors.New("response must be 1 (going), 2 (maybe) or 3 (not going)")
+)
+
+type WatchPartyReq struct {
+	MovieID     string `json:"movieId"`
+	Description string `json:"description"`
+	// StartAt is in unix milliseconds
+	StartAt int64 `json:"startAt"`
+}
+
+func (w *WatchPartyReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(w)
+}
+
+func (w *WatchPartyReq) Validate() error {
+	switch {
+	case len(w.MovieID) != 32:
+		return ErrID
+	case w.StartAt <= 0:
+		return ErrEmptyStartAt
+	case len(w.Description) > maxWatchPartyDescription:
+		return ErrDescriptionTooLong
+	}
+	return nil
+}
+
+type EditWatchPartyReq struct {
+	IDReq
+	WatchPartyReq
+}
+
+func (e *EditWatchPartyReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(e)
+}
+
+func (e *EditWatchPartyReq) Validate() error {
+	if err := e.IDReq.Validate(); err != nil {
+		return err
+	}
+	return e.WatchPartyReq.Validate()
+}
+
+type WatchPartyRSVPReq struct {
+	IDReq
+	Response model.RSVPResponse `json:"response"`
+}
+
+func (w *WatchPartyRSVPReq) Decode(ctx *gin.Context) error {
+	return json.NewDecoder(ctx.Request.Body).Decode(w)
+}
+
+func (w *WatchPartyRSVPReq) Validate() error {
+	if err := w.IDReq.Validate(); err != nil {
+		return err
+	}
+	if !w.Response.Valid() {
+		return ErrInvalidRSVPResponse
+	}
+	return nil
+}
+
+type WatchPartyResp struct {
+	ID          string `json:"id"`
+	MovieID     string `json:"movieId"`
+	MovieName   string `json:"movieName"`
+	Description string `json:"description"`
+	Creator     string `json:"creator"`
+	CreatorID   string `json:"creatorId"`
+	Status      string `json:"status"`
+	StartAt     int64  `json:"startAt"`
+	CreatedAt   int64  `json:"createdAt"`
+	Going       int    `json:"going"`
+	Maybe       int    `json:"maybe"`
+	NotGoing    int    `json:"notGoing"`
+	// MyResponse is the answer of the user, 0 when they did not answer
+	MyResponse model.RSVPResponse `json:"myResponse"`
+}
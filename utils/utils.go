@@ -14,6 +14,8 @@ import (
 	"strings"
 	"sync"
 	"sync/atomic"
+	"syscall"
+	"time"
 	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
@@ -294,17 +296,65 @@ func IsLocalIP(address string) bool {
 		return false
 	}
 
-	localIPs := getLocalIPs()
+	return isLocalAddr(ipAddr.IP)
+}
 
-	for _, localIP := range localIPs {
-		if ipAddr.IP.Equal(localIP) {
+// isLocalAddr reports whether an ip reaches the server itself or its link, the
+// unspecified address connects to the server too.
+func isLocalAddr(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() {
+		return true
+	}
+	for _, localIP := range getLocalIPs() {
+		if ip.Equal(localIP) {
 			return true
 		}
 	}
-
 	return false
 }
 
+var ErrLocalIP = errors.New("local ip is not allowed")
+
+// NewRemoteHTTPClient returns a client for the urls given by the users. Unless
+// allowLocal returns true it refuses to connect to a local ip, the check runs on the
+// resolved address of every connection so it also covers the redirects and the hosts
+// resolving to a local ip.
+func NewRemoteHTTPClient(allowLocal func() bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			if allowLocal() {
+				return nil
+			}
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isLocalAddr(ip) {
+				return fmt.Errorf("%w: %s", ErrLocalIP, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would connect to the address for us, past the check of the dialer
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme: %s", req.URL.Scheme)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+}
+
 func getLocalIPs() []net.IP {
 	var localIPs []net.IP
 
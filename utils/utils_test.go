@@ -1,8 +1,12 @@
 package utils_test
 
 import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/PeterChen1997/synctv/utils"
@@ -112,6 +116,65 @@ func TestIsLocalIP(t *testing.T) {
 	}
 }
 
+func TestRemoteHTTPClient(t *testing.T) {
+	local := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer local.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, local.URL, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	tests := []struct {
+		name string
+		url  string
+		// number of connections allowed to a local ip
+		allowed int32
+		wantErr bool
+	}{
+		{
+			name:    "local allowed",
+			url:     local.URL,
+			allowed: 2,
+		},
+		{
+			name:    "local refused",
+			url:     local.URL,
+			wantErr: true,
+		},
+		{
+			name:    "unspecified refused",
+			url:     strings.Replace(local.URL, "127.0.0.1", "0.0.0.0", 1),
+			wantErr: true,
+		},
+		{
+			name:    "redirect to local refused",
+			url:     redirect.URL,
+			allowed: 1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dials atomic.Int32
+			cli := utils.NewRemoteHTTPClient(func() bool {
+				return dials.Add(1) <= tt.allowed
+			})
+			resp, err := cli.Get(tt.url)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get(%s) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, utils.ErrLocalIP) {
+				t.Fatalf("Get(%s) error = %v, want %v", tt.url, err, utils.ErrLocalIP)
+			}
+		})
+	}
+}
+
 func TestTruncateByRune(t *testing.T) {
 	// len("测") = 3
 	name := "abcd测试"